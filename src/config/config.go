@@ -0,0 +1,451 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "******"
+
+// Config is loaded from, in increasing order of precedence: the defaults
+// below, the YAML file named by CONFIG_FILE, the .env file and the process
+// environment. Every field is bound to an environment variable by its env tag.
+type Config struct {
+	// Environment is development or any name of a deployed environment.
+	Environment     string        `yaml:"environment" env:"APP_ENV" default:"production"`
+	HTTP            HTTP          `yaml:"http"`
+	Storage         Storage       `yaml:"storage"`
+	Mongo           Mongo         `yaml:"mongo"`
+	Postgres        Postgres      `yaml:"postgres"`
+	Auth            Auth          `yaml:"auth"`
+	Payment         Payment       `yaml:"payment"`
+	Jobs            Jobs          `yaml:"jobs"`
+	Health          Health        `yaml:"health"`
+	Tracing         Tracing       `yaml:"tracing"`
+	Logging         Logging       `yaml:"logging"`
+	Outbox          Outbox        `yaml:"outbox"`
+	Webhooks        Webhooks      `yaml:"webhooks"`
+	Stream          Stream        `yaml:"stream"`
+	Idempotency     Idempotency   `yaml:"idempotency"`
+	Vehicles        Vehicles      `yaml:"vehicles"`
+	ShutdownDelay   time.Duration `yaml:"shutdown_delay" env:"SHUTDOWN_DELAY" default:"0s"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s"`
+}
+
+type HTTP struct {
+	Port              int           `yaml:"port" env:"PORT" default:"8080"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" default:"15s"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT" default:"5s"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" default:"60s"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" default:"120s"`
+}
+
+type Storage struct {
+	// Backend is mongodb, memory or postgres.
+	Backend string `yaml:"backend" env:"STORAGE_BACKEND" default:"mongodb"`
+	// SnapshotDir is where the memory backend saves vehicles and sales, to
+	// load them again at startup. Nothing is saved when it is empty.
+	SnapshotDir string `yaml:"snapshot_dir" env:"MEMORY_SNAPSHOT_DIR"`
+}
+
+type Mongo struct {
+	URI                    string        `yaml:"uri" env:"MONGO_URI" secret:"true"`
+	Database               string        `yaml:"database" env:"MONGO_DATABASE"`
+	MaxPoolSize            uint64        `yaml:"max_pool_size" env:"MONGO_MAX_POOL_SIZE" default:"100"`
+	MinPoolSize            uint64        `yaml:"min_pool_size" env:"MONGO_MIN_POOL_SIZE" default:"0"`
+	ConnectTimeout         time.Duration `yaml:"connect_timeout" env:"MONGO_CONNECT_TIMEOUT" default:"10s"`
+	ServerSelectionTimeout time.Duration `yaml:"server_selection_timeout" env:"MONGO_SERVER_SELECTION_TIMEOUT" default:"10s"`
+}
+
+type Postgres struct {
+	URL            string        `yaml:"url" env:"POSTGRES_URL" secret:"true"`
+	MaxConns       int           `yaml:"max_conns" env:"POSTGRES_MAX_CONNS" default:"10"`
+	ConnectTimeout time.Duration `yaml:"connect_timeout" env:"POSTGRES_CONNECT_TIMEOUT" default:"10s"`
+}
+
+type Auth struct {
+	JWTSecretKey string `yaml:"jwt_secret_key" env:"JWT_SECRET_KEY" required:"true" secret:"true"`
+}
+
+type Payment struct {
+	// Gateway is the payment provider. Only fake, which accepts every
+	// payment confirmed by a signed webhook, is available, and only in
+	// development.
+	Gateway       string `yaml:"gateway" env:"PAYMENT_GATEWAY" default:"fake"`
+	WebhookSecret string `yaml:"webhook_secret" env:"PAYMENT_WEBHOOK_SECRET" required:"true" secret:"true"`
+}
+
+type Jobs struct {
+	Workers     int `yaml:"workers" env:"JOB_WORKERS" default:"4"`
+	MaxAttempts int `yaml:"max_attempts" env:"JOB_MAX_ATTEMPTS" default:"5"`
+}
+
+type Health struct {
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+}
+
+type Tracing struct {
+	// Exporter is none, stdout or otlp.
+	Exporter     string  `yaml:"exporter" env:"TRACING_EXPORTER" default:"none"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" default:"http://localhost:4318"`
+	ServiceName  string  `yaml:"service_name" env:"OTEL_SERVICE_NAME" default:"vehicle-resale-api"`
+	SampleRatio  float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1"`
+}
+
+type Logging struct {
+	Format string `yaml:"format" env:"LOG_FORMAT" default:"json"`
+	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info"`
+}
+
+type Outbox struct {
+	// Publisher is memory, webhook or nats.
+	Publisher         string        `yaml:"publisher" env:"OUTBOX_PUBLISHER" default:"memory"`
+	PollInterval      time.Duration `yaml:"poll_interval" env:"OUTBOX_POLL_INTERVAL" default:"1s"`
+	BatchSize         int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" default:"100"`
+	WebhookURL        string        `yaml:"webhook_url" env:"OUTBOX_WEBHOOK_URL"`
+	NATSURL           string        `yaml:"nats_url" env:"NATS_URL" default:"nats://localhost:4222" secret:"true"`
+	NATSSubjectPrefix string        `yaml:"nats_subject_prefix" env:"NATS_SUBJECT_PREFIX" default:"vehicle-resale"`
+}
+
+type Webhooks struct {
+	DeliveryInterval time.Duration `yaml:"delivery_interval" env:"WEBHOOK_DELIVERY_INTERVAL" default:"1s"`
+	BatchSize        int           `yaml:"batch_size" env:"WEBHOOK_BATCH_SIZE" default:"100"`
+	MaxAttempts      int           `yaml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" default:"10"`
+	Timeout          time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT" default:"10s"`
+}
+
+type Stream struct {
+	// BufferSize is how many recent events clients may resume from.
+	BufferSize       int `yaml:"buffer_size" env:"STREAM_BUFFER_SIZE" default:"1000"`
+	SubscriberBuffer int `yaml:"subscriber_buffer" env:"STREAM_SUBSCRIBER_BUFFER" default:"100"`
+}
+
+type Idempotency struct {
+	// TTL is how long a completed response is replayed for its key.
+	TTL           time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" default:"24h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"IDEMPOTENCY_PURGE_INTERVAL" default:"1h"`
+}
+
+type Vehicles struct {
+	// DeletedRetention is how long deleted vehicles may be restored before
+	// they are permanently removed.
+	DeletedRetention time.Duration `yaml:"deleted_retention" env:"DELETED_VEHICLE_RETENTION" default:"720h"`
+	PurgeInterval    time.Duration `yaml:"purge_interval" env:"DELETED_VEHICLE_PURGE_INTERVAL" default:"1h"`
+	// DealershipSellerID is the seller of the vehicles taken as trade-ins.
+	DealershipSellerID string `yaml:"dealership_seller_id" env:"DEALERSHIP_SELLER_ID" default:"dealership"`
+}
+
+func Load() (*Config, error) {
+	var config Config
+
+	if err := visit(reflect.ValueOf(&config).Elem(), applyDefault); err != nil {
+		return nil, err
+	}
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read config file: %w", err)
+		}
+
+		if err = yaml.Unmarshal(content, &config); err != nil {
+			return nil, fmt.Errorf("could not parse config file: %w", err)
+		}
+	}
+
+	// Variables already set in the environment take precedence over .env.
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("could not load .env file: %w", err)
+	}
+
+	if err := visit(reflect.ValueOf(&config).Elem(), applyEnv); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+func (ref Config) Validate() error {
+	var problems []string
+
+	_ = visit(reflect.ValueOf(&ref).Elem(), func(field reflect.StructField, value reflect.Value) error {
+		if field.Tag.Get("required") == "true" && value.IsZero() {
+			problems = append(problems, field.Tag.Get("env")+" is required")
+		}
+		return nil
+	})
+
+	if ref.HTTP.Port < 1 || ref.HTTP.Port > 65535 {
+		problems = append(problems, "PORT must be between 1 and 65535")
+	}
+
+	if ref.ShutdownTimeout <= 0 {
+		problems = append(problems, "SHUTDOWN_TIMEOUT must be positive")
+	}
+
+	if ref.Health.CheckTimeout <= 0 {
+		problems = append(problems, "HEALTH_CHECK_TIMEOUT must be positive")
+	}
+
+	switch ref.Storage.Backend {
+	case "memory":
+	case "mongodb":
+		if ref.Mongo.URI == "" {
+			problems = append(problems, "MONGO_URI is required when STORAGE_BACKEND is mongodb")
+		}
+
+		if ref.Mongo.Database == "" {
+			problems = append(problems, "MONGO_DATABASE is required when STORAGE_BACKEND is mongodb")
+		}
+	case "postgres":
+		if ref.Postgres.URL == "" {
+			problems = append(problems, "POSTGRES_URL is required when STORAGE_BACKEND is postgres")
+		}
+	default:
+		problems = append(problems, "STORAGE_BACKEND must be one of mongodb, memory or postgres")
+	}
+
+	if ref.Mongo.MinPoolSize > ref.Mongo.MaxPoolSize {
+		problems = append(problems, "MONGO_MIN_POOL_SIZE must not be greater than MONGO_MAX_POOL_SIZE")
+	}
+
+	if ref.Postgres.MaxConns < 1 {
+		problems = append(problems, "POSTGRES_MAX_CONNS must be at least 1")
+	}
+
+	switch ref.Payment.Gateway {
+	case "fake":
+		if ref.Environment != "development" {
+			problems = append(problems, "PAYMENT_GATEWAY fake is only allowed when APP_ENV is development")
+		}
+	default:
+		problems = append(problems, "PAYMENT_GATEWAY must be fake")
+	}
+
+	if ref.Jobs.Workers < 1 {
+		problems = append(problems, "JOB_WORKERS must be at least 1")
+	}
+
+	if ref.Jobs.MaxAttempts < 1 {
+		problems = append(problems, "JOB_MAX_ATTEMPTS must be at least 1")
+	}
+
+	switch ref.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		problems = append(problems, "TRACING_EXPORTER must be one of none, stdout or otlp")
+	}
+
+	if ref.Tracing.SampleRatio < 0 || ref.Tracing.SampleRatio > 1 {
+		problems = append(problems, "TRACING_SAMPLE_RATIO must be between 0 and 1")
+	}
+
+	switch ref.Logging.Format {
+	case "json", "text":
+	default:
+		problems = append(problems, "LOG_FORMAT must be one of json or text")
+	}
+
+	switch ref.Logging.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, "LOG_LEVEL must be one of debug, info, warn or error")
+	}
+
+	switch ref.Outbox.Publisher {
+	case "memory", "nats":
+	case "webhook":
+		if ref.Outbox.WebhookURL == "" {
+			problems = append(problems, "OUTBOX_WEBHOOK_URL is required when OUTBOX_PUBLISHER is webhook")
+		}
+	default:
+		problems = append(problems, "OUTBOX_PUBLISHER must be one of memory, webhook or nats")
+	}
+
+	if ref.Outbox.PollInterval <= 0 {
+		problems = append(problems, "OUTBOX_POLL_INTERVAL must be positive")
+	}
+
+	if ref.Outbox.BatchSize < 1 {
+		problems = append(problems, "OUTBOX_BATCH_SIZE must be at least 1")
+	}
+
+	if ref.Webhooks.DeliveryInterval <= 0 {
+		problems = append(problems, "WEBHOOK_DELIVERY_INTERVAL must be positive")
+	}
+
+	if ref.Webhooks.BatchSize < 1 {
+		problems = append(problems, "WEBHOOK_BATCH_SIZE must be at least 1")
+	}
+
+	if ref.Webhooks.MaxAttempts < 1 {
+		problems = append(problems, "WEBHOOK_MAX_ATTEMPTS must be at least 1")
+	}
+
+	if ref.Webhooks.Timeout <= 0 {
+		problems = append(problems, "WEBHOOK_TIMEOUT must be positive")
+	}
+
+	if ref.Stream.BufferSize < 1 {
+		problems = append(problems, "STREAM_BUFFER_SIZE must be at least 1")
+	}
+
+	if ref.Stream.SubscriberBuffer < 1 {
+		problems = append(problems, "STREAM_SUBSCRIBER_BUFFER must be at least 1")
+	}
+
+	if ref.Idempotency.TTL <= 0 {
+		problems = append(problems, "IDEMPOTENCY_TTL must be positive")
+	}
+
+	if ref.Idempotency.PurgeInterval <= 0 {
+		problems = append(problems, "IDEMPOTENCY_PURGE_INTERVAL must be positive")
+	}
+
+	if ref.Vehicles.DeletedRetention <= 0 {
+		problems = append(problems, "DELETED_VEHICLE_RETENTION must be positive")
+	}
+
+	if ref.Vehicles.PurgeInterval <= 0 {
+		problems = append(problems, "DELETED_VEHICLE_PURGE_INTERVAL must be positive")
+	}
+
+	if ref.Vehicles.DealershipSellerID == "" {
+		problems = append(problems, "DEALERSHIP_SELLER_ID must not be empty")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// String lists every setting by its environment variable, with secrets
+// redacted, so that the configuration can be logged at startup.
+func (ref Config) String() string {
+	var builder strings.Builder
+
+	_ = visit(reflect.ValueOf(&ref).Elem(), func(field reflect.StructField, value reflect.Value) error {
+		rendered := fmt.Sprint(value.Interface())
+
+		if field.Tag.Get("secret") == "true" && !value.IsZero() {
+			rendered = redacted
+		}
+
+		fmt.Fprintf(&builder, "%s=%s\n", field.Tag.Get("env"), rendered)
+		return nil
+	})
+
+	return builder.String()
+}
+
+// LogValue logs the settings as attributes named by their environment
+// variables, with secrets redacted.
+func (ref Config) LogValue() slog.Value {
+	var attrs []slog.Attr
+
+	_ = visit(reflect.ValueOf(&ref).Elem(), func(field reflect.StructField, value reflect.Value) error {
+		rendered := fmt.Sprint(value.Interface())
+
+		if field.Tag.Get("secret") == "true" && !value.IsZero() {
+			rendered = redacted
+		}
+
+		attrs = append(attrs, slog.String(field.Tag.Get("env"), rendered))
+		return nil
+	})
+
+	return slog.GroupValue(attrs...)
+}
+
+func (ref Config) Addr() string {
+	return ":" + strconv.Itoa(ref.HTTP.Port)
+}
+
+func visit(value reflect.Value, fn func(field reflect.StructField, value reflect.Value) error) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := visit(value.Field(i), fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fn(field, value.Field(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyDefault(field reflect.StructField, value reflect.Value) error {
+	raw, ok := field.Tag.Lookup("default")
+	if !ok {
+		return nil
+	}
+
+	return set(field, value, raw)
+}
+
+func applyEnv(field reflect.StructField, value reflect.Value) error {
+	raw, ok := os.LookupEnv(field.Tag.Get("env"))
+	if !ok || raw == "" {
+		return nil
+	}
+
+	return set(field, value, raw)
+}
+
+func set(field reflect.StructField, value reflect.Value, raw string) error {
+	name := field.Tag.Get("env")
+
+	switch {
+	case value.Type() == reflect.TypeOf(time.Duration(0)):
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%s must be a duration: %w", name, err)
+		}
+		value.SetInt(int64(duration))
+	case value.Kind() == reflect.String:
+		value.SetString(raw)
+	case value.Kind() == reflect.Int:
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%s must be an integer: %w", name, err)
+		}
+		value.SetInt(int64(number))
+	case value.Kind() == reflect.Uint64:
+		number, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s must be a positive integer: %w", name, err)
+		}
+		value.SetUint(number)
+	case value.Kind() == reflect.Float64:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%s must be a number: %w", name, err)
+		}
+		value.SetFloat(number)
+	default:
+		return fmt.Errorf("%s has unsupported type %s", name, value.Type())
+	}
+
+	return nil
+}
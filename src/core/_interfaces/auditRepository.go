@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type AuditRepository interface {
+	Append(ctx context.Context, record entity.AuditRecord) error
+	Search(ctx context.Context, entityID string) ([]entity.AuditRecord, error)
+}
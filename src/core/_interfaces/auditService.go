@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, entityType, entityID, action string, before, after any) error
+	Search(ctx context.Context, entityID string) ([]entity.AuditRecord, error)
+}
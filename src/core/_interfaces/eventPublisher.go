@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.Event) error
+}
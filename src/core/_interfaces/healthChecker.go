@@ -0,0 +1,8 @@
+package interfaces
+
+import "context"
+
+type HealthChecker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type HealthService interface {
+	Readiness(ctx context.Context) entity.HealthReport
+	Shutdown()
+}
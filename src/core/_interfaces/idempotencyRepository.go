@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type IdempotencyRepository interface {
+	// Reserve stores the record unless a record with the same key that
+	// expires after now exists, and returns that record instead.
+	Reserve(ctx context.Context, record entity.IdempotencyRecord, now time.Time) (*entity.IdempotencyRecord, error)
+	Complete(ctx context.Context, record entity.IdempotencyRecord) error
+	Delete(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
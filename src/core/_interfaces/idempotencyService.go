@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type IdempotencyService interface {
+	// Begin reserves the key for a request. It returns nil when the caller
+	// must process the request, or the record of the earlier request that
+	// used the key.
+	Begin(ctx context.Context, key, fingerprint string) (*entity.IdempotencyRecord, error)
+	Complete(ctx context.Context, key, fingerprint string, statusCode int, contentType string, body []byte) error
+	// Release forgets the key, so that a retry processes the request again.
+	Release(ctx context.Context, key string) error
+	PurgeExpired(ctx context.Context) (int, error)
+}
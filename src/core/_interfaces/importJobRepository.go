@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job entity.ImportJob) (*entity.ImportJob, error)
+	GetByID(ctx context.Context, id string) (*entity.ImportJob, error)
+	Update(ctx context.Context, id string, job entity.ImportJob) (*entity.ImportJob, error)
+}
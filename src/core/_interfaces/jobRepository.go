@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type JobRepository interface {
+	Create(ctx context.Context, job entity.Job) (*entity.Job, error)
+	GetByID(ctx context.Context, id string) (*entity.Job, error)
+	Update(ctx context.Context, id string, job entity.Job) (*entity.Job, error)
+	// Cancel marks the job as canceled only while it is pending or running,
+	// and returns it. It returns nil when the job does not exist or already
+	// finished.
+	Cancel(ctx context.Context, id string, finishedAt time.Time) (*entity.Job, error)
+	// ClaimNext atomically marks as running the oldest job of the given types
+	// that is due, or whose lock expired, and returns it. It returns nil when
+	// there is nothing to run.
+	ClaimNext(ctx context.Context, types []string, now, lockedUntil time.Time) (*entity.Job, error)
+	// Heartbeat extends the lock of a running job and returns its current
+	// state, so that the worker notices when it was canceled.
+	Heartbeat(ctx context.Context, id string, lockedUntil time.Time) (*entity.Job, error)
+}
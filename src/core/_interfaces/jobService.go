@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type JobService interface {
+	Enqueue(ctx context.Context, jobType, userID string, payload any) (*entity.Job, error)
+	GetByID(ctx context.Context, id, userID string) (*entity.Job, error)
+	Cancel(ctx context.Context, id, userID string) (*entity.Job, error)
+}
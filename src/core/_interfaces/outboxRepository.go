@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type OutboxRepository interface {
+	Append(ctx context.Context, event entity.Event) error
+	// ClaimPending locks up to limit pending messages that are available at
+	// now, oldest first, until lockedUntil and increments their attempts.
+	// Messages whose lock expires are claimed again.
+	ClaimPending(ctx context.Context, limit int, now, lockedUntil time.Time) ([]entity.OutboxMessage, error)
+	MarkPublished(ctx context.Context, id string, publishedAt time.Time) error
+	MarkFailed(ctx context.Context, id, lastError string, availableAt time.Time) error
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "context"
+
+type OutboxService interface {
+	// Append stores an event about the entity in the outbox. It must be
+	// called with the transaction context of the change it describes.
+	Append(ctx context.Context, eventType, entityID string, data any) error
+	// Relay publishes a batch of pending events and returns how many were
+	// published.
+	Relay(ctx context.Context) (int, error)
+}
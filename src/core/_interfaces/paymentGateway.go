@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type PaymentGateway interface {
+	CreateIntent(ctx context.Context, payment entity.Payment) (*entity.PaymentIntent, error)
+	CancelIntent(ctx context.Context, intentID string) error
+	ParseWebhook(payload []byte, signature string) (*entity.PaymentEvent, error)
+}
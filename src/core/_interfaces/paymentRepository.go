@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment entity.Payment) (*entity.Payment, error)
+	GetByID(ctx context.Context, id string) (*entity.Payment, error)
+	GetByIntentID(ctx context.Context, intentID string) (*entity.Payment, error)
+	SearchExpired(ctx context.Context, before time.Time) ([]entity.Payment, error)
+	Update(ctx context.Context, id string, payment entity.Payment) (*entity.Payment, error)
+	// UpdatePending is Update for a payment that is still pending, and returns
+	// nil when it is not, so that only one webhook completes each payment.
+	UpdatePending(ctx context.Context, id string, payment entity.Payment) (*entity.Payment, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type PaymentService interface {
+	// GetByID returns the payment only to the user who made it.
+	GetByID(ctx context.Context, id, userID string) (*entity.Payment, error)
+	HandleWebhook(ctx context.Context, payload []byte, signature string) (*entity.Payment, error)
+	ReleaseExpired(ctx context.Context) (int, error)
+}
@@ -9,4 +9,6 @@ import (
 type SaleRepository interface {
 	Create(ctx context.Context, sale entity.Sale) (*entity.Sale, error)
 	Search(ctx context.Context) ([]entity.Sale, error)
+	Iterate(ctx context.Context, fn func(entity.Sale) error) error
+	Report(ctx context.Context, filter entity.SalesReportFilter) ([]entity.SalesReportGroup, error)
 }
@@ -9,4 +9,6 @@ import (
 type SaleService interface {
 	Create(ctx context.Context, sale entity.Sale) (*entity.Sale, error)
 	Search(ctx context.Context) ([]entity.Sale, error)
+	Iterate(ctx context.Context, fn func(entity.Sale) error) error
+	Report(ctx context.Context, filter entity.SalesReportFilter) (*entity.SalesReport, error)
 }
@@ -0,0 +1,11 @@
+package interfaces
+
+import "context"
+
+type Transactor interface {
+	// WithinTransaction runs fn in a transaction that is committed when fn
+	// returns nil and rolled back otherwise. Repositories join the
+	// transaction when called with the context passed to fn. fn may be
+	// retried on transient errors.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
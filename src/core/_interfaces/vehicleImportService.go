@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type VehicleImportService interface {
+	Import(ctx context.Context, userID string, rows []entity.ImportRow, dryRun bool) (*entity.ImportJob, error)
+	GetByID(ctx context.Context, id, userID string) (*entity.ImportJob, error)
+	Process(ctx context.Context, job entity.Job) error
+}
@@ -2,13 +2,26 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
 )
 
 type VehicleRepository interface {
 	Create(ctx context.Context, vehicle entity.Vehicle) (*entity.Vehicle, error)
+	// GetByID also returns deleted vehicles.
 	GetByID(ctx context.Context, id string) (*entity.Vehicle, error)
-	Search(ctx context.Context, isSold *bool) ([]entity.Vehicle, error)
+	Search(ctx context.Context, filter entity.VehicleFilter) ([]entity.Vehicle, error)
+	Iterate(ctx context.Context, filter entity.VehicleFilter, fn func(entity.Vehicle) error) error
+	// Update sets the non-zero fields of vehicle.
 	Update(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error)
+	// Replace sets the listing fields of vehicle (brand, model, year, color,
+	// price and status), including zero values.
+	Replace(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error)
+	// Delete marks the vehicle as deleted by deletedBy and Restore unmarks it.
+	// Both are conditional on version like Update.
+	Delete(ctx context.Context, id string, version int64, deletedBy string) (*entity.Vehicle, error)
+	Restore(ctx context.Context, id string, version int64) (*entity.Vehicle, error)
+	// Purge permanently removes the vehicles deleted before deletedBefore.
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
 }
@@ -2,14 +2,36 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
 )
 
 type VehicleService interface {
 	Create(ctx context.Context, vehicle entity.Vehicle) (*entity.Vehicle, error)
+	// GetByID also returns deleted vehicles, which callers hide unless asked
+	// for them.
 	GetByID(ctx context.Context, id string) (*entity.Vehicle, error)
-	Search(ctx context.Context, isSold *bool) ([]entity.Vehicle, error)
+	// GetAsOf returns the vehicle as it was at asOf, or nil if it did not
+	// exist yet.
+	GetAsOf(ctx context.Context, id string, asOf time.Time) (*entity.Vehicle, error)
+	// History returns every recorded version of the vehicle from the oldest,
+	// with the fields changed by each one.
+	History(ctx context.Context, id string) ([]entity.VehicleHistoryEntry, error)
+	Search(ctx context.Context, filter entity.VehicleFilter) ([]entity.Vehicle, error)
+	Iterate(ctx context.Context, filter entity.VehicleFilter, fn func(entity.Vehicle) error) error
+	// Update replaces the listing fields of the vehicle with those of vehicle,
+	// so partial changes must be applied to the current vehicle first.
 	Update(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error)
-	Buy(ctx context.Context, vehicleID, userID string) (*entity.Vehicle, error)
+	// Delete soft deletes the vehicle, which is kept until PurgeDeleted
+	// removes it and may be restored meanwhile.
+	Delete(ctx context.Context, id, userID string) (*entity.Vehicle, error)
+	Restore(ctx context.Context, id string) (*entity.Vehicle, error)
+	// Publish makes a draft vehicle, such as a trade-in, available for sale.
+	Publish(ctx context.Context, id string) (*entity.Vehicle, error)
+	// PurgeDeleted permanently removes the vehicles deleted longer than
+	// retention ago.
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int, error)
+	InventoryAging(ctx context.Context, limit int) (*entity.InventoryAgingReport, error)
+	Buy(ctx context.Context, vehicleID, userID string, tradeIn *entity.TradeIn) (*entity.Payment, error)
 }
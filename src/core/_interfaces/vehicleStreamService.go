@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type VehicleStreamService interface {
+	// Publish buffers vehicle events and fans them out without blocking,
+	// which makes the service an EventPublisher fed by the outbox.
+	Publish(ctx context.Context, event entity.Event) error
+	// Subscribe streams the events after lastEventID, or only new events
+	// when it is empty, of the vehicles matching isSold like Search.
+	Subscribe(lastEventID string, isSold *bool) *entity.VehicleStreamSubscription
+	// Close ends every subscription, and those made afterwards, so that
+	// streams do not hold the server open while it shuts down.
+	Close()
+}
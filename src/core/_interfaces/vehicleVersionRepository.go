@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type VehicleVersionRepository interface {
+	Append(ctx context.Context, version entity.VehicleVersion) error
+	// Search returns the versions of a vehicle from the oldest.
+	Search(ctx context.Context, vehicleID string) ([]entity.VehicleVersion, error)
+	// GetAsOf returns the last version of a vehicle recorded up to asOf.
+	GetAsOf(ctx context.Context, vehicleID string, asOf time.Time) (*entity.VehicleVersion, error)
+}
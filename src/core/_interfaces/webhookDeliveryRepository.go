@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type WebhookDeliveryRepository interface {
+	// Enqueue creates the delivery unless the same event was already enqueued
+	// for the same subscription, so that republished events are not posted
+	// twice.
+	Enqueue(ctx context.Context, delivery entity.WebhookDelivery) error
+	GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+	// SearchBySubscriptionID lists the deliveries of a subscription, newest
+	// first.
+	SearchBySubscriptionID(ctx context.Context, subscriptionID string) ([]entity.WebhookDelivery, error)
+	// ClaimPending locks up to limit pending deliveries due at now, oldest
+	// first, until lockedUntil and increments their attempts.
+	ClaimPending(ctx context.Context, limit int, now, lockedUntil time.Time) ([]entity.WebhookDelivery, error)
+	Update(ctx context.Context, id string, delivery entity.WebhookDelivery) (*entity.WebhookDelivery, error)
+}
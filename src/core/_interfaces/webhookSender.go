@@ -0,0 +1,9 @@
+package interfaces
+
+import "context"
+
+type WebhookSender interface {
+	// Send posts body to url and returns the response status code. Only
+	// failures to get a response are returned as errors.
+	Send(ctx context.Context, url string, header map[string]string, body []byte) (int, error)
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, subscription entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id string) (*entity.WebhookSubscription, error)
+	SearchSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id string) (*entity.WebhookSubscription, error)
+	SearchDeliveries(ctx context.Context, subscriptionID string) ([]entity.WebhookDelivery, error)
+	Replay(ctx context.Context, subscriptionID, deliveryID string) (*entity.WebhookDelivery, error)
+	// Publish enqueues a delivery of the event to every subscription to its
+	// type, which makes the service an EventPublisher fed by the outbox.
+	Publish(ctx context.Context, event entity.Event) error
+	Deliver(ctx context.Context) (int, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	GetByID(ctx context.Context, id string) (*entity.WebhookSubscription, error)
+	Search(ctx context.Context) ([]entity.WebhookSubscription, error)
+	SearchByEventType(ctx context.Context, eventType string) ([]entity.WebhookSubscription, error)
+	Delete(ctx context.Context, id string) error
+}
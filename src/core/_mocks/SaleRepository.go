@@ -45,6 +45,54 @@ func (_m *SaleRepository) Create(ctx context.Context, sale entity.Sale) (*entity
 	return r0, r1
 }
 
+// Iterate provides a mock function with given fields: ctx, fn
+func (_m *SaleRepository) Iterate(ctx context.Context, fn func(entity.Sale) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Iterate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(entity.Sale) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Report provides a mock function with given fields: ctx, filter
+func (_m *SaleRepository) Report(ctx context.Context, filter entity.SalesReportFilter) ([]entity.SalesReportGroup, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Report")
+	}
+
+	var r0 []entity.SalesReportGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SalesReportFilter) ([]entity.SalesReportGroup, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SalesReportFilter) []entity.SalesReportGroup); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.SalesReportGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.SalesReportFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Search provides a mock function with given fields: ctx
 func (_m *SaleRepository) Search(ctx context.Context) ([]entity.Sale, error) {
 	ret := _m.Called(ctx)
@@ -8,6 +8,8 @@ import (
 	entity "github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // VehicleRepository is an autogenerated mock type for the VehicleRepository type
@@ -45,6 +47,36 @@ func (_m *VehicleRepository) Create(ctx context.Context, vehicle entity.Vehicle)
 	return r0, r1
 }
 
+// Delete provides a mock function with given fields: ctx, id, version, deletedBy
+func (_m *VehicleRepository) Delete(ctx context.Context, id string, version int64, deletedBy string) (*entity.Vehicle, error) {
+	ret := _m.Called(ctx, id, version, deletedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *entity.Vehicle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string) (*entity.Vehicle, error)); ok {
+		return rf(ctx, id, version, deletedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string) *entity.Vehicle); ok {
+		r0 = rf(ctx, id, version, deletedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Vehicle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, string) error); ok {
+		r1 = rf(ctx, id, version, deletedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *VehicleRepository) GetByID(ctx context.Context, id string) (*entity.Vehicle, error) {
 	ret := _m.Called(ctx, id)
@@ -75,9 +107,115 @@ func (_m *VehicleRepository) GetByID(ctx context.Context, id string) (*entity.Ve
 	return r0, r1
 }
 
-// Search provides a mock function with given fields: ctx, isSold
-func (_m *VehicleRepository) Search(ctx context.Context, isSold *bool) ([]entity.Vehicle, error) {
-	ret := _m.Called(ctx, isSold)
+// Iterate provides a mock function with given fields: ctx, filter, fn
+func (_m *VehicleRepository) Iterate(ctx context.Context, filter entity.VehicleFilter, fn func(entity.Vehicle) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Iterate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.VehicleFilter, func(entity.Vehicle) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Purge provides a mock function with given fields: ctx, deletedBefore
+func (_m *VehicleRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Replace provides a mock function with given fields: ctx, id, vehicle
+func (_m *VehicleRepository) Replace(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	ret := _m.Called(ctx, id, vehicle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replace")
+	}
+
+	var r0 *entity.Vehicle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Vehicle) (*entity.Vehicle, error)); ok {
+		return rf(ctx, id, vehicle)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Vehicle) *entity.Vehicle); ok {
+		r0 = rf(ctx, id, vehicle)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Vehicle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.Vehicle) error); ok {
+		r1 = rf(ctx, id, vehicle)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id, version
+func (_m *VehicleRepository) Restore(ctx context.Context, id string, version int64) (*entity.Vehicle, error) {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *entity.Vehicle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*entity.Vehicle, error)); ok {
+		return rf(ctx, id, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *entity.Vehicle); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Vehicle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, filter
+func (_m *VehicleRepository) Search(ctx context.Context, filter entity.VehicleFilter) ([]entity.Vehicle, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Search")
@@ -85,19 +223,19 @@ func (_m *VehicleRepository) Search(ctx context.Context, isSold *bool) ([]entity
 
 	var r0 []entity.Vehicle
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *bool) ([]entity.Vehicle, error)); ok {
-		return rf(ctx, isSold)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.VehicleFilter) ([]entity.Vehicle, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *bool) []entity.Vehicle); ok {
-		r0 = rf(ctx, isSold)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.VehicleFilter) []entity.Vehicle); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Vehicle)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *bool) error); ok {
-		r1 = rf(ctx, isSold)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.VehicleFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
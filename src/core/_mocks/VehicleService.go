@@ -8,6 +8,8 @@ import (
 	entity "github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // VehicleService is an autogenerated mock type for the VehicleService type
@@ -15,29 +17,29 @@ type VehicleService struct {
 	mock.Mock
 }
 
-// Buy provides a mock function with given fields: ctx, vehicleID, userID
-func (_m *VehicleService) Buy(ctx context.Context, vehicleID string, userID string) (*entity.Vehicle, error) {
-	ret := _m.Called(ctx, vehicleID, userID)
+// Buy provides a mock function with given fields: ctx, vehicleID, userID, tradeIn
+func (_m *VehicleService) Buy(ctx context.Context, vehicleID string, userID string, tradeIn *entity.TradeIn) (*entity.Payment, error) {
+	ret := _m.Called(ctx, vehicleID, userID, tradeIn)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Buy")
 	}
 
-	var r0 *entity.Vehicle
+	var r0 *entity.Payment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Vehicle, error)); ok {
-		return rf(ctx, vehicleID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *entity.TradeIn) (*entity.Payment, error)); ok {
+		return rf(ctx, vehicleID, userID, tradeIn)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Vehicle); ok {
-		r0 = rf(ctx, vehicleID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *entity.TradeIn) *entity.Payment); ok {
+		r0 = rf(ctx, vehicleID, userID, tradeIn)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Vehicle)
+			r0 = ret.Get(0).(*entity.Payment)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, vehicleID, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *entity.TradeIn) error); ok {
+		r1 = rf(ctx, vehicleID, userID, tradeIn)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,6 +77,66 @@ func (_m *VehicleService) Create(ctx context.Context, vehicle entity.Vehicle) (*
 	return r0, r1
 }
 
+// Delete provides a mock function with given fields: ctx, id, userID
+func (_m *VehicleService) Delete(ctx context.Context, id string, userID string) (*entity.Vehicle, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *entity.Vehicle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Vehicle, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Vehicle); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Vehicle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAsOf provides a mock function with given fields: ctx, id, asOf
+func (_m *VehicleService) GetAsOf(ctx context.Context, id string, asOf time.Time) (*entity.Vehicle, error) {
+	ret := _m.Called(ctx, id, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAsOf")
+	}
+
+	var r0 *entity.Vehicle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*entity.Vehicle, error)); ok {
+		return rf(ctx, id, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *entity.Vehicle); ok {
+		r0 = rf(ctx, id, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Vehicle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, id, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *VehicleService) GetByID(ctx context.Context, id string) (*entity.Vehicle, error) {
 	ret := _m.Called(ctx, id)
@@ -105,9 +167,175 @@ func (_m *VehicleService) GetByID(ctx context.Context, id string) (*entity.Vehic
 	return r0, r1
 }
 
-// Search provides a mock function with given fields: ctx, isSold
-func (_m *VehicleService) Search(ctx context.Context, isSold *bool) ([]entity.Vehicle, error) {
-	ret := _m.Called(ctx, isSold)
+// History provides a mock function with given fields: ctx, id
+func (_m *VehicleService) History(ctx context.Context, id string) ([]entity.VehicleHistoryEntry, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []entity.VehicleHistoryEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.VehicleHistoryEntry, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.VehicleHistoryEntry); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.VehicleHistoryEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InventoryAging provides a mock function with given fields: ctx, limit
+func (_m *VehicleService) InventoryAging(ctx context.Context, limit int) (*entity.InventoryAgingReport, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InventoryAging")
+	}
+
+	var r0 *entity.InventoryAgingReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*entity.InventoryAgingReport, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.InventoryAgingReport); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.InventoryAgingReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Iterate provides a mock function with given fields: ctx, filter, fn
+func (_m *VehicleService) Iterate(ctx context.Context, filter entity.VehicleFilter, fn func(entity.Vehicle) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Iterate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.VehicleFilter, func(entity.Vehicle) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Publish provides a mock function with given fields: ctx, id
+func (_m *VehicleService) Publish(ctx context.Context, id string) (*entity.Vehicle, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 *entity.Vehicle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Vehicle, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Vehicle); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Vehicle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeleted provides a mock function with given fields: ctx, retention
+func (_m *VehicleService) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	ret := _m.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeleted")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int, error)); ok {
+		return rf(ctx, retention)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, retention)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *VehicleService) Restore(ctx context.Context, id string) (*entity.Vehicle, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *entity.Vehicle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Vehicle, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Vehicle); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Vehicle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, filter
+func (_m *VehicleService) Search(ctx context.Context, filter entity.VehicleFilter) ([]entity.Vehicle, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Search")
@@ -115,19 +343,19 @@ func (_m *VehicleService) Search(ctx context.Context, isSold *bool) ([]entity.Ve
 
 	var r0 []entity.Vehicle
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *bool) ([]entity.Vehicle, error)); ok {
-		return rf(ctx, isSold)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.VehicleFilter) ([]entity.Vehicle, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *bool) []entity.Vehicle); ok {
-		r0 = rf(ctx, isSold)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.VehicleFilter) []entity.Vehicle); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Vehicle)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *bool) error); ok {
-		r1 = rf(ctx, isSold)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.VehicleFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
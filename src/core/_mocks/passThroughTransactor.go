@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewPassThroughTransactor creates a Transactor whose WithinTransaction runs
+// the given function and returns its error, as if the transaction committed
+// or rolled back with it.
+func NewPassThroughTransactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Transactor {
+	transactor := NewTransactor(t)
+
+	transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	return transactor
+}
@@ -0,0 +1,29 @@
+// Package actor carries the authenticated user of a request through the
+// context, for logs and the audit trail.
+package actor
+
+import "context"
+
+const RoleAdmin = "admin"
+
+type Actor struct {
+	UserID string
+	Role   string
+}
+
+func (ref Actor) IsAdmin() bool {
+	return ref.Role == RoleAdmin
+}
+
+type actorKey struct{}
+
+func NewContext(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// FromContext returns the actor of the request, or the zero Actor for
+// unauthenticated requests and background work.
+func FromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
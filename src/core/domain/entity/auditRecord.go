@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+const (
+	AuditEntityVehicle = "vehicle"
+	AuditEntitySale    = "sale"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionBuy     = "buy"
+	AuditActionSell    = "sell"
+	AuditActionCancel  = "cancel"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+// AuditActorSystem is recorded for changes made without an authenticated
+// user, such as payment webhooks and background jobs.
+const AuditActorSystem = "system"
+
+type AuditRecord struct {
+	ID         string
+	EntityType string
+	EntityID   string
+	Action     string
+	ActorID    string
+	ActorRole  string
+	RequestID  string
+	Changes    []AuditChange
+	OccurredAt time.Time
+}
+
+type AuditChange struct {
+	Field  string
+	Before any
+	After  any
+}
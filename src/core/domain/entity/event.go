@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+const (
+	EventVehicleCreated      = "VehicleCreated"
+	EventVehicleUpdated      = "VehicleUpdated"
+	EventVehiclePriceChanged = "VehiclePriceChanged"
+	EventVehicleSold         = "VehicleSold"
+	EventVehicleDeleted      = "VehicleDeleted"
+	EventVehicleRestored     = "VehicleRestored"
+	EventSaleCreated         = "SaleCreated"
+)
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusPublished = "published"
+)
+
+// Event is a change to a vehicle or sale that other systems may react to.
+// Events are delivered at least once; consumers discard redeliveries by ID.
+type Event struct {
+	ID         string
+	Type       string
+	EntityID   string
+	Payload    []byte
+	RequestID  string
+	OccurredAt time.Time
+}
+
+// OutboxMessage is an event waiting in the outbox to be published.
+type OutboxMessage struct {
+	Event       Event
+	Status      string
+	Attempts    int
+	LastError   string
+	AvailableAt time.Time
+	PublishedAt *time.Time
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+type HealthReport struct {
+	Status       string
+	ShuttingDown bool
+	Checks       []HealthCheck
+}
+
+type HealthCheck struct {
+	Name    string
+	Status  string
+	Latency time.Duration
+	Error   string
+}
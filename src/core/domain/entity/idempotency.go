@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+const (
+	IdempotencyStatusInProgress = "in_progress"
+	IdempotencyStatusCompleted  = "completed"
+)
+
+// IdempotencyRecord remembers the response to a request sent with an
+// Idempotency-Key, so that retries get the same response. Fingerprint
+// identifies the request the key was first used with.
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string
+	Status      string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (ref IdempotencyRecord) IsCompleted() bool {
+	return ref.Status == IdempotencyStatusCompleted
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+const (
+	ImportJobStatusPending   = "pending"
+	ImportJobStatusRunning   = "running"
+	ImportJobStatusCompleted = "completed"
+	ImportJobStatusFailed    = "failed"
+)
+
+type ImportJob struct {
+	ID         string
+	JobID      string
+	UserID     string
+	DryRun     bool
+	Status     string
+	Total      int
+	Valid      int
+	Processed  int
+	Imported   int
+	Errors     []ImportRowError
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// ImportRow is a parsed line of an import file. Vehicle is nil when the line
+// could not be parsed or validated, in which case Error explains why.
+type ImportRow struct {
+	Row     int
+	Vehicle *Vehicle
+	Error   string
+}
+
+type ImportRowError struct {
+	Row   int
+	Error string
+}
@@ -0,0 +1,27 @@
+package entity
+
+type InventoryAgingReport struct {
+	Buckets []InventoryAgingBucket
+	Brands  []InventoryAgingBrand
+	Oldest  []InventoryAgingVehicle
+}
+
+type InventoryAgingBucket struct {
+	Label      string
+	MinDays    int
+	MaxDays    *int
+	Count      int
+	TotalValue float64
+}
+
+type InventoryAgingBrand struct {
+	Brand      string
+	Count      int
+	TotalValue float64
+	Buckets    []InventoryAgingBucket
+}
+
+type InventoryAgingVehicle struct {
+	Vehicle    Vehicle
+	DaysListed int
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	JobStatusCanceled  = "canceled"
+)
+
+type Job struct {
+	ID          string
+	Type        string
+	UserID      string
+	Payload     []byte
+	Status      string
+	Attempts    int
+	MaxAttempts int
+	LastError   string
+	RunAt       time.Time
+	LockedUntil *time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (ref Job) IsFinished() bool {
+	return ref.Status == JobStatusSucceeded || ref.Status == JobStatusFailed || ref.Status == JobStatusCanceled
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PaymentStatusPending   = "pending"
+	PaymentStatusSucceeded = "succeeded"
+	PaymentStatusFailed    = "failed"
+	PaymentStatusCanceled  = "canceled"
+)
+
+var (
+	ErrInvalidPaymentSignature = errors.New("invalid payment webhook signature")
+	// ErrPaymentIntentNotFound is returned by gateways that do not know the
+	// intent, such as one that was already canceled.
+	ErrPaymentIntentNotFound = errors.New("payment intent does not exist")
+	// ErrPaymentNotFound is returned for webhooks about intents of no
+	// payment, such as one created by another environment.
+	ErrPaymentNotFound = errors.New("payment does not exist")
+)
+
+type Payment struct {
+	ID           string
+	VehicleID    string
+	UserID       string
+	Price        float64
+	TradeIn      *TradeIn
+	Amount       float64
+	Status       string
+	IntentID     string
+	ClientSecret string
+	SaleID       string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type PaymentIntent struct {
+	ID           string
+	ClientSecret string
+	Amount       float64
+}
+
+type PaymentEvent struct {
+	IntentID string
+	Status   string
+}
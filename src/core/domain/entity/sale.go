@@ -3,9 +3,12 @@ package entity
 import "time"
 
 type Sale struct {
-	ID        string
-	VehicleID string
-	UserID    string
-	Price     float64
-	SoldAt    time.Time
+	ID               string
+	VehicleID        string
+	UserID           string
+	Price            float64
+	TradeInVehicleID string
+	TradeInCredit    float64
+	NetPrice         float64
+	SoldAt           time.Time
 }
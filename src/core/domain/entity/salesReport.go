@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+const (
+	SalesReportGroupByDay    = "day"
+	SalesReportGroupByWeek   = "week"
+	SalesReportGroupByMonth  = "month"
+	SalesReportGroupByBrand  = "brand"
+	SalesReportGroupByModel  = "model"
+	SalesReportGroupBySeller = "seller"
+)
+
+type SalesReportFilter struct {
+	GroupBy string
+	From    *time.Time
+	To      *time.Time
+}
+
+type SalesReport struct {
+	GroupBy string
+	Totals  SalesReportGroup
+	Groups  []SalesReportGroup
+}
+
+// SalesReportGroup reports revenue net of trade-ins: Revenue and AverageTicket
+// use the price of each sale less its trade-in credit. That is its NetPrice,
+// and also holds for sales stored without one, such as those created directly.
+type SalesReportGroup struct {
+	Key                 string
+	Count               int
+	Revenue             float64
+	AverageTicket       float64
+	AverageDaysOnMarket float64
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "errors"
+
+var (
+	ErrInvalidTradeInValuation = errors.New("trade-in valuation must be greater than zero")
+	ErrTradeInExceedsPrice     = errors.New("trade-in valuation exceeds vehicle price")
+)
+
+type TradeIn struct {
+	Brand     string
+	Model     string
+	Year      int
+	Color     string
+	Valuation float64
+}
@@ -1,7 +1,36 @@
 package entity
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
+const (
+	VehicleStatusAvailable = "available"
+	VehicleStatusDraft     = "draft"
+	VehicleStatusReserved  = "reserved"
+	VehicleStatusSold      = "sold"
+)
+
+var (
+	// ErrVehicleVersionMismatch is returned when a vehicle is updated from a
+	// version that was already changed by another request.
+	ErrVehicleVersionMismatch = errors.New("vehicle was changed by another request")
+	// ErrVehicleNotDeletable is returned when deleting a vehicle that was sold
+	// or is reserved by a purchase, since sales and payments refer to it.
+	ErrVehicleNotDeletable = errors.New("sold or reserved vehicles cannot be deleted")
+	// ErrVehicleNotFound, ErrVehicleSold, ErrVehicleNotForSale and
+	// ErrVehicleReserved are returned when buying a vehicle that cannot be
+	// bought.
+	ErrVehicleNotFound   = errors.New("vehicle does not exist")
+	ErrVehicleSold       = errors.New("vehicle already sold")
+	ErrVehicleNotForSale = errors.New("vehicle is not available for sale")
+	ErrVehicleReserved   = errors.New("vehicle is reserved by another purchase")
+)
+
+// Vehicle.Version is incremented by every update. When updating, a non-zero
+// Version is the version the change was made from. Deleted vehicles keep
+// their data until the retention period ends, with DeletedAt set.
 type Vehicle struct {
 	ID        string
 	Brand     string
@@ -9,7 +38,23 @@ type Vehicle struct {
 	Year      int
 	Color     string
 	Price     float64
+	Status    string
+	SellerID  string
 	SoldAt    *time.Time
+	Version   int64
+	DeletedAt *time.Time
+	DeletedBy string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+func (ref Vehicle) IsDeleted() bool {
+	return ref.DeletedAt != nil
+}
+
+// VehicleFilter selects vehicles in searches. A nil IsSold matches sold and
+// unsold vehicles, and deleted vehicles only match with IncludeDeleted.
+type VehicleFilter struct {
+	IsSold         *bool
+	IncludeDeleted bool
+}
@@ -0,0 +1,25 @@
+package entity
+
+// VehicleStreamEvent is a vehicle event as pushed to listing screens. IDs
+// increase by one per event, so that a client resumes after the last ID it
+// saw.
+type VehicleStreamEvent struct {
+	ID        uint64
+	EventID   string
+	Type      string
+	VehicleID string
+	Sold      bool
+	Data      []byte
+}
+
+// VehicleStreamSubscription replays the buffered events a client missed and
+// then receives new ones. Reset is set when the missed events are no longer
+// buffered and the client must reload the listing instead. Events is closed
+// when the subscriber falls behind or the server shuts down, so that it
+// reconnects and resumes.
+type VehicleStreamSubscription struct {
+	Replay []VehicleStreamEvent
+	Reset  bool
+	Events <-chan VehicleStreamEvent
+	Cancel func()
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// VehicleVersion is a snapshot of a vehicle as it was saved at
+// Vehicle.Version.
+type VehicleVersion struct {
+	Vehicle    Vehicle
+	RecordedAt time.Time
+}
+
+// VehicleHistoryEntry is a version of a vehicle with the fields that changed
+// from the previous version.
+type VehicleHistoryEntry struct {
+	VehicleVersion
+	Changes []AuditChange
+}
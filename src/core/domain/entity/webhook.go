@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusDead      = "dead"
+)
+
+var ErrWebhookDeliveryPending = errors.New("webhook delivery is still pending")
+
+// WebhookSubscription asks for the events of the given types to be posted to
+// URL, signed with Secret.
+type WebhookSubscription struct {
+	ID         string
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (ref WebhookSubscription) Subscribes(eventType string) bool {
+	return slices.Contains(ref.EventTypes, eventType)
+}
+
+// WebhookDelivery is one event to be posted to one subscription. Deliveries
+// that keep failing are dead-lettered and only sent again when replayed.
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -0,0 +1,49 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type AuditRecord struct {
+	ID         string        `json:"id"`
+	EntityType string        `json:"entity_type"`
+	EntityID   string        `json:"entity_id"`
+	Action     string        `json:"action"`
+	ActorID    string        `json:"actor_id"`
+	ActorRole  string        `json:"actor_role,omitempty"`
+	RequestID  string        `json:"request_id,omitempty"`
+	Changes    []AuditChange `json:"changes"`
+	OccurredAt time.Time     `json:"occurred_at"`
+}
+
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+func AuditRecordFromDomain(record entity.AuditRecord) AuditRecord {
+	changes := make([]AuditChange, 0, len(record.Changes))
+
+	for _, change := range record.Changes {
+		changes = append(changes, AuditChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	return AuditRecord{
+		ID:         record.ID,
+		EntityType: record.EntityType,
+		EntityID:   record.EntityID,
+		Action:     record.Action,
+		ActorID:    record.ActorID,
+		ActorRole:  record.ActorRole,
+		RequestID:  record.RequestID,
+		Changes:    changes,
+		OccurredAt: record.OccurredAt,
+	}
+}
@@ -0,0 +1,49 @@
+package responses
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAuditRecordFromDomain(t *testing.T) {
+	recordID := primitive.NewObjectID().Hex()
+	vehicleID := primitive.NewObjectID().Hex()
+
+	now := time.Now()
+
+	record := entity.AuditRecord{
+		ID:         recordID,
+		EntityType: entity.AuditEntityVehicle,
+		EntityID:   vehicleID,
+		Action:     entity.AuditActionUpdate,
+		ActorID:    "user-123",
+		ActorRole:  "admin",
+		RequestID:  "request-123",
+		Changes: []entity.AuditChange{
+			{Field: "price", Before: float64(80000), After: float64(75000)},
+		},
+		OccurredAt: now,
+	}
+
+	expected := AuditRecord{
+		ID:         recordID,
+		EntityType: entity.AuditEntityVehicle,
+		EntityID:   vehicleID,
+		Action:     entity.AuditActionUpdate,
+		ActorID:    "user-123",
+		ActorRole:  "admin",
+		RequestID:  "request-123",
+		Changes: []AuditChange{
+			{Field: "price", Before: float64(80000), After: float64(75000)},
+		},
+		OccurredAt: now,
+	}
+
+	actual := AuditRecordFromDomain(record)
+
+	assert.Equal(t, expected, actual)
+}
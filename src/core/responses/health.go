@@ -0,0 +1,35 @@
+package responses
+
+import "github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+
+type HealthReport struct {
+	Status       string        `json:"status"`
+	ShuttingDown bool          `json:"shutting_down,omitempty"`
+	Checks       []HealthCheck `json:"checks"`
+}
+
+type HealthCheck struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+func HealthReportFromDomain(report entity.HealthReport) HealthReport {
+	checks := make([]HealthCheck, len(report.Checks))
+
+	for i, check := range report.Checks {
+		checks[i] = HealthCheck{
+			Name:      check.Name,
+			Status:    check.Status,
+			LatencyMs: float64(check.Latency.Microseconds()) / 1000,
+			Error:     check.Error,
+		}
+	}
+
+	return HealthReport{
+		Status:       report.Status,
+		ShuttingDown: report.ShuttingDown,
+		Checks:       checks,
+	}
+}
@@ -0,0 +1,41 @@
+package responses
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthReportFromDomain(t *testing.T) {
+	report := entity.HealthReport{
+		Status:       entity.HealthStatusDown,
+		ShuttingDown: true,
+		Checks: []entity.HealthCheck{
+			{
+				Name:    "mongodb",
+				Status:  entity.HealthStatusDown,
+				Latency: 1500 * time.Microsecond,
+				Error:   "connection refused",
+			},
+		},
+	}
+
+	expected := HealthReport{
+		Status:       entity.HealthStatusDown,
+		ShuttingDown: true,
+		Checks: []HealthCheck{
+			{
+				Name:      "mongodb",
+				Status:    entity.HealthStatusDown,
+				LatencyMs: 1.5,
+				Error:     "connection refused",
+			},
+		},
+	}
+
+	actual := HealthReportFromDomain(report)
+
+	assert.Equal(t, expected, actual)
+}
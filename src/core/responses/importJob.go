@@ -0,0 +1,55 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type ImportJob struct {
+	ID         string           `json:"id"`
+	JobID      string           `json:"job_id,omitempty"`
+	UserID     string           `json:"user_id,omitempty"`
+	DryRun     bool             `json:"dry_run"`
+	Status     string           `json:"status"`
+	Total      int              `json:"total"`
+	Valid      int              `json:"valid"`
+	Processed  int              `json:"processed"`
+	Imported   int              `json:"imported"`
+	Errors     []ImportRowError `json:"errors"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+}
+
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+func ImportJobFromDomain(job entity.ImportJob) ImportJob {
+	rowErrors := make([]ImportRowError, len(job.Errors))
+
+	for i, rowError := range job.Errors {
+		rowErrors[i] = ImportRowError{
+			Row:   rowError.Row,
+			Error: rowError.Error,
+		}
+	}
+
+	return ImportJob{
+		ID:         job.ID,
+		JobID:      job.JobID,
+		UserID:     job.UserID,
+		DryRun:     job.DryRun,
+		Status:     job.Status,
+		Total:      job.Total,
+		Valid:      job.Valid,
+		Processed:  job.Processed,
+		Imported:   job.Imported,
+		Errors:     rowErrors,
+		CreatedAt:  job.CreatedAt,
+		UpdatedAt:  job.UpdatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
@@ -0,0 +1,58 @@
+package responses
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestImportJobFromDomain(t *testing.T) {
+	jobID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+	backgroundJobID := primitive.NewObjectID().Hex()
+
+	now := time.Now()
+
+	job := entity.ImportJob{
+		ID:        jobID,
+		JobID:     backgroundJobID,
+		UserID:    userID,
+		DryRun:    true,
+		Status:    entity.ImportJobStatusCompleted,
+		Total:     3,
+		Valid:     2,
+		Processed: 2,
+		Imported:  0,
+		Errors: []entity.ImportRowError{
+			{Row: 2, Error: "invalid year"},
+		},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		FinishedAt: &now,
+	}
+
+	expected := ImportJob{
+		ID:        jobID,
+		JobID:     backgroundJobID,
+		UserID:    userID,
+		DryRun:    true,
+		Status:    entity.ImportJobStatusCompleted,
+		Total:     3,
+		Valid:     2,
+		Processed: 2,
+		Imported:  0,
+		Errors: []ImportRowError{
+			{Row: 2, Error: "invalid year"},
+		},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		FinishedAt: &now,
+	}
+
+	actual := ImportJobFromDomain(job)
+
+	assert.Equal(t, expected, actual)
+}
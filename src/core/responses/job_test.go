@@ -0,0 +1,50 @@
+package responses
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestJobFromDomain(t *testing.T) {
+	jobID := primitive.NewObjectID().Hex()
+
+	now := time.Now()
+
+	job := entity.Job{
+		ID:          jobID,
+		Type:        "vehicle_import",
+		Payload:     []byte(`{}`),
+		Status:      entity.JobStatusFailed,
+		Attempts:    3,
+		MaxAttempts: 3,
+		LastError:   "unexpected error",
+		RunAt:       now,
+		LockedUntil: &now,
+		StartedAt:   &now,
+		FinishedAt:  &now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	expected := Job{
+		ID:          jobID,
+		Type:        "vehicle_import",
+		Status:      entity.JobStatusFailed,
+		Attempts:    3,
+		MaxAttempts: 3,
+		LastError:   "unexpected error",
+		RunAt:       now,
+		StartedAt:   &now,
+		FinishedAt:  &now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	actual := JobFromDomain(job)
+
+	assert.Equal(t, expected, actual)
+}
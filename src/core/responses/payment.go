@@ -0,0 +1,61 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type Payment struct {
+	ID           string    `json:"id"`
+	VehicleID    string    `json:"vehicle_id"`
+	UserID       string    `json:"user_id"`
+	Price        float64   `json:"price"`
+	TradeIn      *TradeIn  `json:"trade_in,omitempty"`
+	Amount       float64   `json:"amount"`
+	Status       string    `json:"status"`
+	IntentID     string    `json:"intent_id,omitempty"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+	SaleID       string    `json:"sale_id,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type TradeIn struct {
+	Brand     string  `json:"brand"`
+	Model     string  `json:"model"`
+	Year      int     `json:"year"`
+	Color     string  `json:"color"`
+	Valuation float64 `json:"valuation"`
+}
+
+// PaymentFromDomain leaves out the client secret, which only the response
+// that starts the payment carries.
+func PaymentFromDomain(payment entity.Payment) Payment {
+	response := Payment{
+		ID:        payment.ID,
+		VehicleID: payment.VehicleID,
+		UserID:    payment.UserID,
+		Price:     payment.Price,
+		Amount:    payment.Amount,
+		Status:    payment.Status,
+		IntentID:  payment.IntentID,
+		SaleID:    payment.SaleID,
+		ExpiresAt: payment.ExpiresAt,
+		CreatedAt: payment.CreatedAt,
+		UpdatedAt: payment.UpdatedAt,
+	}
+
+	if payment.TradeIn != nil {
+		response.TradeIn = &TradeIn{
+			Brand:     payment.TradeIn.Brand,
+			Model:     payment.TradeIn.Model,
+			Year:      payment.TradeIn.Year,
+			Color:     payment.TradeIn.Color,
+			Valuation: payment.TradeIn.Valuation,
+		}
+	}
+
+	return response
+}
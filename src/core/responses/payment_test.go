@@ -0,0 +1,63 @@
+package responses
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPaymentFromDomain(t *testing.T) {
+	paymentID := primitive.NewObjectID().Hex()
+	vehicleID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+
+	now := time.Now()
+
+	payment := entity.Payment{
+		ID:        paymentID,
+		VehicleID: vehicleID,
+		UserID:    userID,
+		Price:     80000,
+		TradeIn: &entity.TradeIn{
+			Brand:     "Some Brand",
+			Model:     "Some Model",
+			Year:      2015,
+			Color:     "Black",
+			Valuation: 30000,
+		},
+		Amount:       50000,
+		Status:       entity.PaymentStatusPending,
+		IntentID:     "pi_123",
+		ClientSecret: "secret_123",
+		ExpiresAt:    now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	expected := Payment{
+		ID:        paymentID,
+		VehicleID: vehicleID,
+		UserID:    userID,
+		Price:     80000,
+		TradeIn: &TradeIn{
+			Brand:     "Some Brand",
+			Model:     "Some Model",
+			Year:      2015,
+			Color:     "Black",
+			Valuation: 30000,
+		},
+		Amount:    50000,
+		Status:    entity.PaymentStatusPending,
+		IntentID:  "pi_123",
+		ExpiresAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	actual := PaymentFromDomain(payment)
+
+	assert.Equal(t, expected, actual)
+}
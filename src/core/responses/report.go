@@ -0,0 +1,111 @@
+package responses
+
+import "github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+
+type SalesReport struct {
+	GroupBy string             `json:"group_by"`
+	Totals  SalesReportGroup   `json:"totals"`
+	Groups  []SalesReportGroup `json:"groups"`
+}
+
+type SalesReportGroup struct {
+	Key                 string  `json:"key"`
+	Count               int     `json:"count"`
+	Revenue             float64 `json:"revenue"`
+	AverageTicket       float64 `json:"average_ticket"`
+	AverageDaysOnMarket float64 `json:"average_days_on_market"`
+}
+
+func SalesReportFromDomain(report entity.SalesReport) SalesReport {
+	groups := make([]SalesReportGroup, len(report.Groups))
+
+	for i, group := range report.Groups {
+		groups[i] = salesReportGroupFromDomain(group)
+	}
+
+	return SalesReport{
+		GroupBy: report.GroupBy,
+		Totals:  salesReportGroupFromDomain(report.Totals),
+		Groups:  groups,
+	}
+}
+
+func salesReportGroupFromDomain(group entity.SalesReportGroup) SalesReportGroup {
+	return SalesReportGroup{
+		Key:                 group.Key,
+		Count:               group.Count,
+		Revenue:             group.Revenue,
+		AverageTicket:       group.AverageTicket,
+		AverageDaysOnMarket: group.AverageDaysOnMarket,
+	}
+}
+
+type InventoryAgingReport struct {
+	Buckets []InventoryAgingBucket  `json:"buckets"`
+	Brands  []InventoryAgingBrand   `json:"brands"`
+	Oldest  []InventoryAgingVehicle `json:"oldest"`
+}
+
+type InventoryAgingBucket struct {
+	Label      string  `json:"label"`
+	MinDays    int     `json:"min_days"`
+	MaxDays    *int    `json:"max_days,omitempty"`
+	Count      int     `json:"count"`
+	TotalValue float64 `json:"total_value"`
+}
+
+type InventoryAgingBrand struct {
+	Brand      string                 `json:"brand"`
+	Count      int                    `json:"count"`
+	TotalValue float64                `json:"total_value"`
+	Buckets    []InventoryAgingBucket `json:"buckets"`
+}
+
+type InventoryAgingVehicle struct {
+	Vehicle
+	DaysListed int `json:"days_listed"`
+}
+
+func InventoryAgingReportFromDomain(report entity.InventoryAgingReport) InventoryAgingReport {
+	brands := make([]InventoryAgingBrand, len(report.Brands))
+
+	for i, brand := range report.Brands {
+		brands[i] = InventoryAgingBrand{
+			Brand:      brand.Brand,
+			Count:      brand.Count,
+			TotalValue: brand.TotalValue,
+			Buckets:    inventoryAgingBucketsFromDomain(brand.Buckets),
+		}
+	}
+
+	oldest := make([]InventoryAgingVehicle, len(report.Oldest))
+
+	for i, vehicle := range report.Oldest {
+		oldest[i] = InventoryAgingVehicle{
+			Vehicle:    VehicleFromDomain(vehicle.Vehicle),
+			DaysListed: vehicle.DaysListed,
+		}
+	}
+
+	return InventoryAgingReport{
+		Buckets: inventoryAgingBucketsFromDomain(report.Buckets),
+		Brands:  brands,
+		Oldest:  oldest,
+	}
+}
+
+func inventoryAgingBucketsFromDomain(buckets []entity.InventoryAgingBucket) []InventoryAgingBucket {
+	response := make([]InventoryAgingBucket, len(buckets))
+
+	for i, bucket := range buckets {
+		response[i] = InventoryAgingBucket{
+			Label:      bucket.Label,
+			MinDays:    bucket.MinDays,
+			MaxDays:    bucket.MaxDays,
+			Count:      bucket.Count,
+			TotalValue: bucket.TotalValue,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,118 @@
+package responses
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSalesReportFromDomain(t *testing.T) {
+	report := entity.SalesReport{
+		GroupBy: entity.SalesReportGroupByBrand,
+		Totals: entity.SalesReportGroup{
+			Key:                 "total",
+			Count:               3,
+			Revenue:             150000,
+			AverageTicket:       50000,
+			AverageDaysOnMarket: 20,
+		},
+		Groups: []entity.SalesReportGroup{
+			{
+				Key:                 "Ford",
+				Count:               3,
+				Revenue:             150000,
+				AverageTicket:       50000,
+				AverageDaysOnMarket: 20,
+			},
+		},
+	}
+
+	expected := SalesReport{
+		GroupBy: entity.SalesReportGroupByBrand,
+		Totals: SalesReportGroup{
+			Key:                 "total",
+			Count:               3,
+			Revenue:             150000,
+			AverageTicket:       50000,
+			AverageDaysOnMarket: 20,
+		},
+		Groups: []SalesReportGroup{
+			{
+				Key:                 "Ford",
+				Count:               3,
+				Revenue:             150000,
+				AverageTicket:       50000,
+				AverageDaysOnMarket: 20,
+			},
+		},
+	}
+
+	actual := SalesReportFromDomain(report)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestInventoryAgingReportFromDomain(t *testing.T) {
+	vehicleID := primitive.NewObjectID().Hex()
+	maxDays := 30
+	now := time.Now()
+
+	buckets := []entity.InventoryAgingBucket{
+		{Label: "0-30", MinDays: 0, MaxDays: &maxDays, Count: 1, TotalValue: 50000},
+	}
+
+	report := entity.InventoryAgingReport{
+		Buckets: buckets,
+		Brands: []entity.InventoryAgingBrand{
+			{Brand: "Ford", Count: 1, TotalValue: 50000, Buckets: buckets},
+		},
+		Oldest: []entity.InventoryAgingVehicle{
+			{
+				Vehicle: entity.Vehicle{
+					ID:        vehicleID,
+					Brand:     "Ford",
+					Model:     "Ka",
+					Year:      2022,
+					Color:     "Preto",
+					Price:     50000,
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				DaysListed: 12,
+			},
+		},
+	}
+
+	expectedBuckets := []InventoryAgingBucket{
+		{Label: "0-30", MinDays: 0, MaxDays: &maxDays, Count: 1, TotalValue: 50000},
+	}
+
+	expected := InventoryAgingReport{
+		Buckets: expectedBuckets,
+		Brands: []InventoryAgingBrand{
+			{Brand: "Ford", Count: 1, TotalValue: 50000, Buckets: expectedBuckets},
+		},
+		Oldest: []InventoryAgingVehicle{
+			{
+				Vehicle: Vehicle{
+					ID:        vehicleID,
+					Brand:     "Ford",
+					Model:     "Ka",
+					Year:      2022,
+					Color:     "Preto",
+					Price:     50000,
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				DaysListed: 12,
+			},
+		},
+	}
+
+	actual := InventoryAgingReportFromDomain(report)
+
+	assert.Equal(t, expected, actual)
+}
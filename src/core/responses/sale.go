@@ -7,19 +7,25 @@ import (
 )
 
 type Sale struct {
-	ID        string    `json:"id,omitempty"`
-	VehicleID string    `json:"vehicle_id"`
-	UserID    string    `json:"user_id"`
-	Price     float64   `json:"price"`
-	SoldAt    time.Time `json:"sold_at"`
+	ID               string    `json:"id,omitempty"`
+	VehicleID        string    `json:"vehicle_id"`
+	UserID           string    `json:"user_id"`
+	Price            float64   `json:"price"`
+	TradeInVehicleID string    `json:"trade_in_vehicle_id,omitempty"`
+	TradeInCredit    float64   `json:"trade_in_credit"`
+	NetPrice         float64   `json:"net_price"`
+	SoldAt           time.Time `json:"sold_at"`
 }
 
 func SaleFromDomain(sale entity.Sale) Sale {
 	return Sale{
-		ID:        sale.ID,
-		VehicleID: sale.VehicleID,
-		UserID:    sale.UserID,
-		Price:     sale.Price,
-		SoldAt:    sale.SoldAt,
+		ID:               sale.ID,
+		VehicleID:        sale.VehicleID,
+		UserID:           sale.UserID,
+		Price:            sale.Price,
+		TradeInVehicleID: sale.TradeInVehicleID,
+		TradeInCredit:    sale.TradeInCredit,
+		NetPrice:         sale.NetPrice,
+		SoldAt:           sale.SoldAt,
 	}
 }
@@ -16,20 +16,28 @@ func TestSaleFromDomain(t *testing.T) {
 
 	now := time.Now()
 
+	tradeInVehicleID := primitive.NewObjectID().Hex()
+
 	sale := entity.Sale{
-		ID:        saleID,
-		VehicleID: vehicleID,
-		UserID:    userID,
-		Price:     80000,
-		SoldAt:    now,
+		ID:               saleID,
+		VehicleID:        vehicleID,
+		UserID:           userID,
+		Price:            80000,
+		TradeInVehicleID: tradeInVehicleID,
+		TradeInCredit:    30000,
+		NetPrice:         50000,
+		SoldAt:           now,
 	}
 
 	expected := Sale{
-		ID:        saleID,
-		VehicleID: vehicleID,
-		UserID:    userID,
-		Price:     80000,
-		SoldAt:    now,
+		ID:               saleID,
+		VehicleID:        vehicleID,
+		UserID:           userID,
+		Price:            80000,
+		TradeInVehicleID: tradeInVehicleID,
+		TradeInCredit:    30000,
+		NetPrice:         50000,
+		SoldAt:           now,
 	}
 
 	actual := SaleFromDomain(sale)
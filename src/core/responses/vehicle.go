@@ -13,7 +13,12 @@ type Vehicle struct {
 	Year      int        `json:"year"`
 	Color     string     `json:"color"`
 	Price     float64    `json:"price"`
+	Status    string     `json:"status,omitempty"`
+	SellerID  string     `json:"seller_id,omitempty"`
 	SoldAt    *time.Time `json:"sold_at,omitempty"`
+	Version   int64      `json:"version,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy string     `json:"deleted_by,omitempty"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 }
@@ -26,8 +31,39 @@ func VehicleFromDomain(vehicle entity.Vehicle) Vehicle {
 		Year:      vehicle.Year,
 		Color:     vehicle.Color,
 		Price:     vehicle.Price,
+		Status:    vehicle.Status,
+		SellerID:  vehicle.SellerID,
 		SoldAt:    vehicle.SoldAt,
+		Version:   vehicle.Version,
+		DeletedAt: vehicle.DeletedAt,
+		DeletedBy: vehicle.DeletedBy,
 		CreatedAt: vehicle.CreatedAt,
 		UpdatedAt: vehicle.UpdatedAt,
 	}
 }
+
+type VehicleHistoryEntry struct {
+	Version    int64         `json:"version"`
+	RecordedAt time.Time     `json:"recorded_at"`
+	Changes    []AuditChange `json:"changes"`
+	Vehicle    Vehicle       `json:"vehicle"`
+}
+
+func VehicleHistoryEntryFromDomain(entry entity.VehicleHistoryEntry) VehicleHistoryEntry {
+	changes := make([]AuditChange, 0, len(entry.Changes))
+
+	for _, change := range entry.Changes {
+		changes = append(changes, AuditChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	return VehicleHistoryEntry{
+		Version:    entry.Vehicle.Version,
+		RecordedAt: entry.RecordedAt,
+		Changes:    changes,
+		Vehicle:    VehicleFromDomain(entry.Vehicle),
+	}
+}
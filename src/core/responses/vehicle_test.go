@@ -11,6 +11,7 @@ import (
 
 func TestVehicleFromDomain(t *testing.T) {
 	vehicleID := primitive.NewObjectID().Hex()
+	sellerID := primitive.NewObjectID().Hex()
 
 	now := time.Now()
 
@@ -21,7 +22,12 @@ func TestVehicleFromDomain(t *testing.T) {
 		Year:      2025,
 		Color:     "Gray",
 		Price:     80000,
+		Status:    entity.VehicleStatusAvailable,
+		SellerID:  sellerID,
 		SoldAt:    &now,
+		Version:   2,
+		DeletedAt: &now,
+		DeletedBy: sellerID,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -33,7 +39,12 @@ func TestVehicleFromDomain(t *testing.T) {
 		Year:      2025,
 		Color:     "Gray",
 		Price:     80000,
+		Status:    entity.VehicleStatusAvailable,
+		SellerID:  sellerID,
 		SoldAt:    &now,
+		Version:   2,
+		DeletedAt: &now,
+		DeletedBy: sellerID,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -42,3 +53,30 @@ func TestVehicleFromDomain(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestVehicleHistoryEntryFromDomain(t *testing.T) {
+	now := time.Now()
+
+	entry := entity.VehicleHistoryEntry{
+		VehicleVersion: entity.VehicleVersion{
+			Vehicle:    entity.Vehicle{ID: "some-id", Price: 75000, Version: 2},
+			RecordedAt: now,
+		},
+		Changes: []entity.AuditChange{
+			{Field: "price", Before: 80000.0, After: 75000.0},
+		},
+	}
+
+	expected := VehicleHistoryEntry{
+		Version:    2,
+		RecordedAt: now,
+		Changes: []AuditChange{
+			{Field: "price", Before: 80000.0, After: 75000.0},
+		},
+		Vehicle: Vehicle{ID: "some-id", Price: 75000, Version: 2},
+	}
+
+	actual := VehicleHistoryEntryFromDomain(entry)
+
+	assert.Equal(t, expected, actual)
+}
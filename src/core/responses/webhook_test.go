@@ -0,0 +1,80 @@
+package responses
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWebhookSubscriptionFromDomain(t *testing.T) {
+	subscriptionID := primitive.NewObjectID().Hex()
+
+	now := time.Now()
+
+	subscription := entity.WebhookSubscription{
+		ID:         subscriptionID,
+		URL:        "https://partner.example.com/webhooks",
+		EventTypes: []string{entity.EventVehicleCreated, entity.EventVehicleSold},
+		Secret:     "whsec_123",
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	expected := WebhookSubscription{
+		ID:         subscriptionID,
+		URL:        "https://partner.example.com/webhooks",
+		EventTypes: []string{entity.EventVehicleCreated, entity.EventVehicleSold},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	actual := WebhookSubscriptionFromDomain(subscription)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestWebhookDeliveryFromDomain(t *testing.T) {
+	deliveryID := primitive.NewObjectID().Hex()
+	subscriptionID := primitive.NewObjectID().Hex()
+	eventID := primitive.NewObjectID().Hex()
+
+	now := time.Now()
+
+	delivery := entity.WebhookDelivery{
+		ID:             deliveryID,
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		EventType:      entity.EventSaleCreated,
+		Payload:        []byte(`{"id":"event-123"}`),
+		Status:         entity.WebhookDeliveryStatusDelivered,
+		Attempts:       2,
+		LastStatusCode: 200,
+		NextAttemptAt:  now,
+		DeliveredAt:    &now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	expected := WebhookDelivery{
+		ID:             deliveryID,
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		EventType:      entity.EventSaleCreated,
+		Payload:        json.RawMessage(`{"id":"event-123"}`),
+		Status:         entity.WebhookDeliveryStatusDelivered,
+		Attempts:       2,
+		LastStatusCode: 200,
+		NextAttemptAt:  now,
+		DeliveredAt:    &now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	actual := WebhookDeliveryFromDomain(delivery)
+
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,190 @@
+package audit
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/actor"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/logging"
+)
+
+// ignoredFields change on every write and would add noise to every record.
+var ignoredFields = map[string]bool{
+	"updated_at": true,
+	"version":    true,
+}
+
+type auditService struct {
+	auditRepository interfaces.AuditRepository
+}
+
+func NewAuditService(auditRepository interfaces.AuditRepository) interfaces.AuditService {
+	return &auditService{
+		auditRepository: auditRepository,
+	}
+}
+
+// Record appends a record of the change from before to after, which are
+// entities or nil, attributed to the actor and request found in ctx. It is
+// called inside the transaction that makes the change, so that a change is
+// never committed without its record.
+func (ref *auditService) Record(ctx context.Context, entityType, entityID, action string, before, after any) error {
+	current := actor.FromContext(ctx)
+
+	record := entity.AuditRecord{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		ActorID:    current.UserID,
+		ActorRole:  current.Role,
+		RequestID:  logging.RequestID(ctx),
+		Changes:    Diff(before, after),
+		OccurredAt: time.Now(),
+	}
+
+	if record.ActorID == "" {
+		record.ActorID = entity.AuditActorSystem
+	}
+
+	return ref.auditRepository.Append(ctx, record)
+}
+
+func (ref *auditService) Search(ctx context.Context, entityID string) ([]entity.AuditRecord, error) {
+	return ref.auditRepository.Search(ctx, entityID)
+}
+
+// Diff compares the exported fields of two values of the same struct type,
+// either of which may be nil, and returns the fields that differ in
+// declaration order. Field names are converted to snake_case and times are
+// formatted as RFC 3339, so that records read the same from every store.
+func Diff(before, after any) []entity.AuditChange {
+	beforeFields := fields(before)
+	afterFields := fields(after)
+
+	names := beforeFields.names
+	if len(names) == 0 {
+		names = afterFields.names
+	}
+
+	changes := make([]entity.AuditChange, 0)
+
+	for _, name := range names {
+		if ignoredFields[name] {
+			continue
+		}
+
+		beforeValue := beforeFields.values[name]
+		afterValue := afterFields.values[name]
+
+		if reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+
+		changes = append(changes, entity.AuditChange{
+			Field:  name,
+			Before: beforeValue,
+			After:  afterValue,
+		})
+	}
+
+	return changes
+}
+
+type fieldSet struct {
+	names  []string
+	values map[string]any
+}
+
+func fields(value any) fieldSet {
+	set := fieldSet{values: map[string]any{}}
+
+	reflected := reflect.ValueOf(value)
+	for reflected.Kind() == reflect.Pointer {
+		if reflected.IsNil() {
+			return set
+		}
+		reflected = reflected.Elem()
+	}
+
+	if reflected.Kind() != reflect.Struct {
+		return set
+	}
+
+	for i := 0; i < reflected.NumField(); i++ {
+		field := reflected.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := snakeCase(field.Name)
+
+		set.names = append(set.names, name)
+		set.values[name] = normalize(reflected.Field(i))
+	}
+
+	return set
+}
+
+// normalize turns a field into a comparable scalar, with zero values as nil
+// so that "unset" and "empty" are not reported as a change.
+func normalize(value reflect.Value) any {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if value.IsZero() {
+		return nil
+	}
+
+	switch typed := value.Interface().(type) {
+	case time.Time:
+		return typed.UTC().Format(time.RFC3339Nano)
+	case string, bool, float64:
+		return typed
+	}
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(value.Uint())
+	case reflect.Float32:
+		return value.Float()
+	case reflect.String:
+		return value.String()
+	}
+
+	return value.Interface()
+}
+
+func snakeCase(name string) string {
+	var builder strings.Builder
+
+	runes := []rune(name)
+
+	for i, char := range runes {
+		if unicode.IsUpper(char) {
+			previousLower := i > 0 && unicode.IsLower(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])
+
+			if previousLower || nextLower {
+				builder.WriteByte('_')
+			}
+
+			builder.WriteRune(unicode.ToLower(char))
+			continue
+		}
+
+		builder.WriteRune(char)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mocks "github.com/caiiomp/vehicle-resale-api/src/core/_mocks"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/actor"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRecord(t *testing.T) {
+	vehicleID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+
+	before := entity.Vehicle{ID: vehicleID, Brand: "Ford", Price: 50000, UpdatedAt: time.Now()}
+	after := entity.Vehicle{ID: vehicleID, Brand: "Ford", Price: 45000, UpdatedAt: time.Now().Add(time.Minute)}
+
+	t.Run("should append record with actor and request", func(t *testing.T) {
+		auditRepositoryMocked := mocks.NewAuditRepository(t)
+
+		ctx := actor.NewContext(context.TODO(), actor.Actor{UserID: userID, Role: actor.RoleAdmin})
+		ctx = logging.WithRequestID(ctx, "request-id")
+
+		var appended entity.AuditRecord
+
+		auditRepositoryMocked.On("Append", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				appended = args.Get(1).(entity.AuditRecord)
+			}).
+			Return(nil)
+
+		service := NewAuditService(auditRepositoryMocked)
+
+		err := service.Record(ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionUpdate, before, &after)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.AuditEntityVehicle, appended.EntityType)
+		assert.Equal(t, vehicleID, appended.EntityID)
+		assert.Equal(t, entity.AuditActionUpdate, appended.Action)
+		assert.Equal(t, userID, appended.ActorID)
+		assert.Equal(t, actor.RoleAdmin, appended.ActorRole)
+		assert.Equal(t, "request-id", appended.RequestID)
+		assert.False(t, appended.OccurredAt.IsZero())
+		assert.Equal(t, []entity.AuditChange{
+			{Field: "price", Before: float64(50000), After: float64(45000)},
+		}, appended.Changes)
+	})
+
+	t.Run("should attribute change without actor to the system", func(t *testing.T) {
+		auditRepositoryMocked := mocks.NewAuditRepository(t)
+
+		auditRepositoryMocked.On("Append", mock.Anything, mock.MatchedBy(func(record entity.AuditRecord) bool {
+			return record.ActorID == entity.AuditActorSystem
+		})).
+			Return(nil)
+
+		service := NewAuditService(auditRepositoryMocked)
+
+		err := service.Record(context.TODO(), entity.AuditEntityVehicle, vehicleID, entity.AuditActionCancel, nil, nil)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when failed to append record", func(t *testing.T) {
+		auditRepositoryMocked := mocks.NewAuditRepository(t)
+
+		unexpectedError := errors.New("unexpected error")
+
+		auditRepositoryMocked.On("Append", mock.Anything, mock.Anything).
+			Return(unexpectedError)
+
+		service := NewAuditService(auditRepositoryMocked)
+
+		err := service.Record(context.TODO(), entity.AuditEntityVehicle, vehicleID, entity.AuditActionCreate, nil, after)
+
+		assert.Equal(t, unexpectedError, err)
+	})
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.TODO()
+	vehicleID := primitive.NewObjectID().Hex()
+
+	t.Run("should search records successfully", func(t *testing.T) {
+		auditRepositoryMocked := mocks.NewAuditRepository(t)
+
+		auditRepositoryMocked.On("Search", ctx, vehicleID).
+			Return([]entity.AuditRecord{{EntityID: vehicleID}}, nil)
+
+		service := NewAuditService(auditRepositoryMocked)
+
+		actual, err := service.Search(ctx, vehicleID)
+
+		assert.Equal(t, []entity.AuditRecord{{EntityID: vehicleID}}, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	soldAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should list every field set on creation", func(t *testing.T) {
+		actual := Diff(nil, entity.Vehicle{ID: "1", Brand: "Ford", Year: 2022})
+
+		assert.Equal(t, []entity.AuditChange{
+			{Field: "id", After: "1"},
+			{Field: "brand", After: "Ford"},
+			{Field: "year", After: int64(2022)},
+		}, actual)
+	})
+
+	t.Run("should list changed fields in declaration order", func(t *testing.T) {
+		before := entity.Vehicle{Status: entity.VehicleStatusReserved, SellerID: "seller"}
+		after := &entity.Vehicle{Status: entity.VehicleStatusSold, SellerID: "seller", SoldAt: &soldAt}
+
+		actual := Diff(before, after)
+
+		assert.Equal(t, []entity.AuditChange{
+			{Field: "status", Before: "reserved", After: "sold"},
+			{Field: "sold_at", After: "2025-06-01T12:00:00Z"},
+		}, actual)
+	})
+
+	t.Run("should return no changes for equal values", func(t *testing.T) {
+		assert.Empty(t, Diff(entity.Sale{ID: "1"}, entity.Sale{ID: "1"}))
+	})
+}
+
+func Test_snakeCase(t *testing.T) {
+	testCases := map[string]string{
+		"ID":               "id",
+		"SellerID":         "seller_id",
+		"SoldAt":           "sold_at",
+		"TradeInVehicleID": "trade_in_vehicle_id",
+		"HTTPStatus":       "http_status",
+	}
+
+	for input, expected := range testCases {
+		assert.Equal(t, expected, snakeCase(input), input)
+	}
+}
@@ -0,0 +1,81 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type healthService struct {
+	checkers     []interfaces.HealthChecker
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewHealthService(timeout time.Duration, checkers ...interfaces.HealthChecker) interfaces.HealthService {
+	return &healthService{
+		checkers: checkers,
+		timeout:  timeout,
+	}
+}
+
+// Readiness checks every dependency concurrently, each bounded by the
+// service timeout. The application is not ready once shutdown has started.
+func (ref *healthService) Readiness(ctx context.Context) entity.HealthReport {
+	ctx, cancel := context.WithTimeout(ctx, ref.timeout)
+	defer cancel()
+
+	report := entity.HealthReport{
+		Status:       entity.HealthStatusUp,
+		ShuttingDown: ref.shuttingDown.Load(),
+		Checks:       make([]entity.HealthCheck, len(ref.checkers)),
+	}
+
+	var wg sync.WaitGroup
+
+	for i, checker := range ref.checkers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			err := checker.Check(ctx)
+
+			check := entity.HealthCheck{
+				Name:    checker.Name(),
+				Status:  entity.HealthStatusUp,
+				Latency: time.Since(start),
+			}
+
+			if err != nil {
+				check.Status = entity.HealthStatusDown
+				check.Error = err.Error()
+			}
+
+			report.Checks[i] = check
+		}()
+	}
+
+	wg.Wait()
+
+	for _, check := range report.Checks {
+		if check.Status != entity.HealthStatusUp {
+			report.Status = entity.HealthStatusDown
+		}
+	}
+
+	if report.ShuttingDown {
+		report.Status = entity.HealthStatusDown
+	}
+
+	return report
+}
+
+func (ref *healthService) Shutdown() {
+	ref.shuttingDown.Store(true)
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mocks "github.com/caiiomp/vehicle-resale-api/src/core/_mocks"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadiness(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should be ready when every dependency is up", func(t *testing.T) {
+		checkerMocked := mocks.NewHealthChecker(t)
+
+		checkerMocked.On("Name").Return("mongodb")
+		checkerMocked.On("Check", mock.Anything).Return(nil)
+
+		service := NewHealthService(time.Second, checkerMocked)
+
+		actual := service.Readiness(ctx)
+
+		assert.Equal(t, entity.HealthStatusUp, actual.Status)
+		assert.False(t, actual.ShuttingDown)
+		require.Len(t, actual.Checks, 1)
+		assert.Equal(t, "mongodb", actual.Checks[0].Name)
+		assert.Equal(t, entity.HealthStatusUp, actual.Checks[0].Status)
+		assert.Empty(t, actual.Checks[0].Error)
+	})
+
+	t.Run("should not be ready when a dependency is down", func(t *testing.T) {
+		upCheckerMocked := mocks.NewHealthChecker(t)
+		downCheckerMocked := mocks.NewHealthChecker(t)
+
+		upCheckerMocked.On("Name").Return("cache")
+		upCheckerMocked.On("Check", mock.Anything).Return(nil)
+
+		downCheckerMocked.On("Name").Return("mongodb")
+		downCheckerMocked.On("Check", mock.Anything).Return(errors.New("connection refused"))
+
+		service := NewHealthService(time.Second, upCheckerMocked, downCheckerMocked)
+
+		actual := service.Readiness(ctx)
+
+		assert.Equal(t, entity.HealthStatusDown, actual.Status)
+		require.Len(t, actual.Checks, 2)
+		assert.Equal(t, entity.HealthStatusUp, actual.Checks[0].Status)
+		assert.Equal(t, entity.HealthStatusDown, actual.Checks[1].Status)
+		assert.Equal(t, "connection refused", actual.Checks[1].Error)
+	})
+
+	t.Run("should bound checks by timeout", func(t *testing.T) {
+		checkerMocked := mocks.NewHealthChecker(t)
+
+		checkerMocked.On("Name").Return("mongodb")
+		checkerMocked.On("Check", mock.Anything).
+			Return(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+
+		service := NewHealthService(10*time.Millisecond, checkerMocked)
+
+		actual := service.Readiness(ctx)
+
+		assert.Equal(t, entity.HealthStatusDown, actual.Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), actual.Checks[0].Error)
+		assert.GreaterOrEqual(t, actual.Checks[0].Latency, 10*time.Millisecond)
+	})
+
+	t.Run("should not be ready after shutdown", func(t *testing.T) {
+		checkerMocked := mocks.NewHealthChecker(t)
+
+		checkerMocked.On("Name").Return("mongodb")
+		checkerMocked.On("Check", mock.Anything).Return(nil)
+
+		service := NewHealthService(time.Second, checkerMocked)
+		service.Shutdown()
+
+		actual := service.Readiness(ctx)
+
+		assert.Equal(t, entity.HealthStatusDown, actual.Status)
+		assert.True(t, actual.ShuttingDown)
+	})
+}
@@ -0,0 +1,59 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+// lockTimeout bounds how long a key stays reserved by a request that never
+// completes, such as one whose process stopped, before a retry takes it over.
+const lockTimeout = time.Minute
+
+type idempotencyService struct {
+	idempotencyRepository interfaces.IdempotencyRepository
+	ttl                   time.Duration
+}
+
+func NewIdempotencyService(idempotencyRepository interfaces.IdempotencyRepository, ttl time.Duration) interfaces.IdempotencyService {
+	return &idempotencyService{
+		idempotencyRepository: idempotencyRepository,
+		ttl:                   ttl,
+	}
+}
+
+func (ref *idempotencyService) Begin(ctx context.Context, key, fingerprint string) (*entity.IdempotencyRecord, error) {
+	now := time.Now()
+
+	return ref.idempotencyRepository.Reserve(ctx, entity.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      entity.IdempotencyStatusInProgress,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(lockTimeout),
+	}, now)
+}
+
+func (ref *idempotencyService) Complete(ctx context.Context, key, fingerprint string, statusCode int, contentType string, body []byte) error {
+	now := time.Now()
+
+	return ref.idempotencyRepository.Complete(ctx, entity.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      entity.IdempotencyStatusCompleted,
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Body:        body,
+		ExpiresAt:   now.Add(ref.ttl),
+	})
+}
+
+func (ref *idempotencyService) Release(ctx context.Context, key string) error {
+	return ref.idempotencyRepository.Delete(ctx, key)
+}
+
+func (ref *idempotencyService) PurgeExpired(ctx context.Context) (int, error) {
+	return ref.idempotencyRepository.DeleteExpired(ctx, time.Now())
+}
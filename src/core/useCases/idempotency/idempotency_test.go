@@ -0,0 +1,113 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mocks "github.com/caiiomp/vehicle-resale-api/src/core/_mocks"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBegin(t *testing.T) {
+	ctx := context.TODO()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not begin when failed to reserve key", func(t *testing.T) {
+		idempotencyRepositoryMocked := mocks.NewIdempotencyRepository(t)
+
+		idempotencyRepositoryMocked.On("Reserve", ctx, mock.Anything, mock.AnythingOfType("time.Time")).
+			Return(nil, unexpectedError)
+
+		service := NewIdempotencyService(idempotencyRepositoryMocked, time.Hour)
+
+		actual, err := service.Begin(ctx, "user-123:key", "fingerprint")
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should reserve key for a short lease", func(t *testing.T) {
+		idempotencyRepositoryMocked := mocks.NewIdempotencyRepository(t)
+
+		idempotencyRepositoryMocked.On("Reserve", ctx, mock.MatchedBy(func(record entity.IdempotencyRecord) bool {
+			return record.Key == "user-123:key" &&
+				record.Fingerprint == "fingerprint" &&
+				record.Status == entity.IdempotencyStatusInProgress &&
+				record.ExpiresAt.Sub(record.CreatedAt) == lockTimeout
+		}), mock.AnythingOfType("time.Time")).
+			Return(nil, nil)
+
+		service := NewIdempotencyService(idempotencyRepositoryMocked, time.Hour)
+
+		actual, err := service.Begin(ctx, "user-123:key", "fingerprint")
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return record of the earlier request", func(t *testing.T) {
+		idempotencyRepositoryMocked := mocks.NewIdempotencyRepository(t)
+
+		existing := &entity.IdempotencyRecord{
+			Key:         "user-123:key",
+			Fingerprint: "fingerprint",
+			Status:      entity.IdempotencyStatusCompleted,
+			StatusCode:  201,
+		}
+
+		idempotencyRepositoryMocked.On("Reserve", ctx, mock.Anything, mock.AnythingOfType("time.Time")).
+			Return(existing, nil)
+
+		service := NewIdempotencyService(idempotencyRepositoryMocked, time.Hour)
+
+		actual, err := service.Begin(ctx, "user-123:key", "fingerprint")
+
+		assert.Equal(t, existing, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestComplete(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should keep response for the ttl", func(t *testing.T) {
+		idempotencyRepositoryMocked := mocks.NewIdempotencyRepository(t)
+
+		idempotencyRepositoryMocked.On("Complete", ctx, mock.MatchedBy(func(record entity.IdempotencyRecord) bool {
+			return record.Key == "user-123:key" &&
+				record.Fingerprint == "fingerprint" &&
+				record.Status == entity.IdempotencyStatusCompleted &&
+				record.StatusCode == 201 &&
+				record.ContentType == "application/json" &&
+				string(record.Body) == `{"id":"1"}` &&
+				time.Until(record.ExpiresAt) > 23*time.Hour
+		})).
+			Return(nil)
+
+		service := NewIdempotencyService(idempotencyRepositoryMocked, 24*time.Hour)
+
+		err := service.Complete(ctx, "user-123:key", "fingerprint", 201, "application/json", []byte(`{"id":"1"}`))
+
+		assert.Nil(t, err)
+	})
+}
+
+func TestPurgeExpired(t *testing.T) {
+	ctx := context.TODO()
+
+	idempotencyRepositoryMocked := mocks.NewIdempotencyRepository(t)
+
+	idempotencyRepositoryMocked.On("DeleteExpired", ctx, mock.AnythingOfType("time.Time")).
+		Return(3, nil)
+
+	service := NewIdempotencyService(idempotencyRepositoryMocked, time.Hour)
+
+	purged, err := service.PurgeExpired(ctx)
+
+	assert.Equal(t, 3, purged)
+	assert.Nil(t, err)
+}
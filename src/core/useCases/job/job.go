@@ -0,0 +1,83 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+var errJobFinished = errors.New("job is already finished")
+
+type jobService struct {
+	jobRepository interfaces.JobRepository
+	maxAttempts   int
+}
+
+func NewJobService(jobRepository interfaces.JobRepository, maxAttempts int) interfaces.JobService {
+	return &jobService{
+		jobRepository: jobRepository,
+		maxAttempts:   maxAttempts,
+	}
+}
+
+func (ref *jobService) Enqueue(ctx context.Context, jobType, userID string, payload any) (*entity.Job, error) {
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	job := entity.Job{
+		Type:        jobType,
+		UserID:      userID,
+		Payload:     rawPayload,
+		Status:      entity.JobStatusPending,
+		MaxAttempts: ref.maxAttempts,
+		RunAt:       time.Now(),
+	}
+
+	return ref.jobRepository.Create(ctx, job)
+}
+
+// GetByID reports jobs of other users as missing.
+func (ref *jobService) GetByID(ctx context.Context, id, userID string) (*entity.Job, error) {
+	job, err := ref.jobRepository.GetByID(ctx, id)
+	if err != nil || job == nil {
+		return nil, err
+	}
+
+	if job.UserID != userID {
+		return nil, nil
+	}
+
+	return job, nil
+}
+
+// Cancel marks the job as canceled. A pending job is never picked up, and
+// the worker running a job notices the cancellation on its next heartbeat
+// and cancels the job context. The job is only canceled while it is pending
+// or running, so a worker finishing it meanwhile is not overwritten.
+func (ref *jobService) Cancel(ctx context.Context, id, userID string) (*entity.Job, error) {
+	job, err := ref.GetByID(ctx, id, userID)
+	if err != nil || job == nil {
+		return nil, err
+	}
+
+	if job.IsFinished() {
+		return nil, errJobFinished
+	}
+
+	canceled, err := ref.jobRepository.Cancel(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if canceled == nil {
+		return nil, errJobFinished
+	}
+
+	return canceled, nil
+}
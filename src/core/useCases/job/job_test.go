@@ -0,0 +1,211 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mocks "github.com/caiiomp/vehicle-resale-api/src/core/_mocks"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEnqueue(t *testing.T) {
+	ctx := context.TODO()
+	userID := primitive.NewObjectID().Hex()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not enqueue job with invalid payload", func(t *testing.T) {
+		service := NewJobService(nil, 3)
+
+		actual, err := service.Enqueue(ctx, "test", userID, make(chan int))
+
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	t.Run("should not enqueue job when failed to create", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("Create", ctx, mock.Anything).
+			Return(nil, unexpectedError)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.Enqueue(ctx, "test", userID, map[string]string{"key": "value"})
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should enqueue job successfully", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("Create", ctx, mock.MatchedBy(func(job entity.Job) bool {
+			return job.Type == "test" &&
+				job.UserID == userID &&
+				job.Status == entity.JobStatusPending &&
+				job.MaxAttempts == 3 &&
+				string(job.Payload) == `{"key":"value"}` &&
+				!job.RunAt.IsZero()
+		})).
+			Return(&entity.Job{}, nil)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.Enqueue(ctx, "test", userID, map[string]string{"key": "value"})
+
+		assert.NotNil(t, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	ctx := context.TODO()
+	jobID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+
+	t.Run("should not get job of another user", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("GetByID", ctx, jobID).
+			Return(&entity.Job{ID: jobID, UserID: primitive.NewObjectID().Hex()}, nil)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.GetByID(ctx, jobID, userID)
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should get job successfully", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("GetByID", ctx, jobID).
+			Return(&entity.Job{ID: jobID, UserID: userID}, nil)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.GetByID(ctx, jobID, userID)
+
+		assert.Equal(t, jobID, actual.ID)
+		assert.Nil(t, err)
+	})
+}
+
+func TestCancel(t *testing.T) {
+	ctx := context.TODO()
+	jobID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not cancel job when failed to get job", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("GetByID", ctx, jobID).
+			Return(nil, unexpectedError)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.Cancel(ctx, jobID, userID)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should not cancel job that does not exist", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("GetByID", ctx, jobID).
+			Return(nil, nil)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.Cancel(ctx, jobID, userID)
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should not cancel job of another user", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("GetByID", ctx, jobID).
+			Return(&entity.Job{ID: jobID, UserID: primitive.NewObjectID().Hex(), Status: entity.JobStatusRunning}, nil)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.Cancel(ctx, jobID, userID)
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should not cancel finished job", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("GetByID", ctx, jobID).
+			Return(&entity.Job{ID: jobID, UserID: userID, Status: entity.JobStatusSucceeded}, nil)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.Cancel(ctx, jobID, userID)
+
+		assert.Nil(t, actual)
+		assert.EqualError(t, err, "job is already finished")
+	})
+
+	t.Run("should not cancel job finished meanwhile", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("GetByID", ctx, jobID).
+			Return(&entity.Job{ID: jobID, UserID: userID, Status: entity.JobStatusRunning}, nil)
+
+		jobRepositoryMocked.On("Cancel", ctx, jobID, mock.AnythingOfType("time.Time")).
+			Return(nil, nil)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.Cancel(ctx, jobID, userID)
+
+		assert.Nil(t, actual)
+		assert.EqualError(t, err, "job is already finished")
+	})
+
+	t.Run("should not cancel job when failed to cancel", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("GetByID", ctx, jobID).
+			Return(&entity.Job{ID: jobID, UserID: userID, Status: entity.JobStatusRunning}, nil)
+
+		jobRepositoryMocked.On("Cancel", ctx, jobID, mock.AnythingOfType("time.Time")).
+			Return(nil, unexpectedError)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.Cancel(ctx, jobID, userID)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should cancel running job successfully", func(t *testing.T) {
+		jobRepositoryMocked := mocks.NewJobRepository(t)
+
+		jobRepositoryMocked.On("GetByID", ctx, jobID).
+			Return(&entity.Job{ID: jobID, UserID: userID, Status: entity.JobStatusRunning}, nil)
+
+		jobRepositoryMocked.On("Cancel", ctx, jobID, mock.AnythingOfType("time.Time")).
+			Return(&entity.Job{ID: jobID, UserID: userID, Status: entity.JobStatusCanceled}, nil)
+
+		service := NewJobService(jobRepositoryMocked, 3)
+
+		actual, err := service.Cancel(ctx, jobID, userID)
+
+		assert.Equal(t, entity.JobStatusCanceled, actual.Status)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,124 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/logging"
+	"github.com/caiiomp/vehicle-resale-api/src/metrics"
+	"github.com/caiiomp/vehicle-resale-api/src/tracing"
+)
+
+const (
+	// lockTimeout bounds how long a claimed event may take to publish before
+	// another relay claims it again.
+	lockTimeout  = time.Minute
+	retryBackoff = time.Second
+	maxBackoff   = 5 * time.Minute
+)
+
+type outboxService struct {
+	outboxRepository interfaces.OutboxRepository
+	eventPublisher   interfaces.EventPublisher
+	batchSize        int
+}
+
+func NewOutboxService(outboxRepository interfaces.OutboxRepository, eventPublisher interfaces.EventPublisher, batchSize int) interfaces.OutboxService {
+	return &outboxService{
+		outboxRepository: outboxRepository,
+		eventPublisher:   eventPublisher,
+		batchSize:        batchSize,
+	}
+}
+
+func (ref *outboxService) Append(ctx context.Context, eventType, entityID string, data any) error {
+	payload, err := encode(data)
+	if err != nil {
+		return err
+	}
+
+	return ref.outboxRepository.Append(ctx, entity.Event{
+		Type:       eventType,
+		EntityID:   entityID,
+		Payload:    payload,
+		RequestID:  logging.RequestID(ctx),
+		OccurredAt: time.Now(),
+	})
+}
+
+// Relay publishes the events claimed in one batch. An event is marked as
+// published only after the publisher accepts it, so an event may be
+// published again when the relay stops in between; failed events are
+// retried with exponential backoff and never dropped.
+func (ref *outboxService) Relay(ctx context.Context) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "outboxService.Relay")
+	defer func() { span.End(err) }()
+
+	now := time.Now()
+
+	messages, err := ref.outboxRepository.ClaimPending(ctx, ref.batchSize, now, now.Add(lockTimeout))
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+
+	for _, message := range messages {
+		event := message.Event
+
+		if publishErr := ref.eventPublisher.Publish(ctx, event); publishErr != nil {
+			metrics.OutboxEvents.WithLabelValues(event.Type, "failed").Inc()
+
+			slog.WarnContext(ctx, "could not publish event",
+				"event_id", event.ID,
+				"event_type", event.Type,
+				"attempts", message.Attempts,
+				"error", publishErr,
+			)
+
+			if err = ref.outboxRepository.MarkFailed(ctx, event.ID, publishErr.Error(), time.Now().Add(backoff(message.Attempts))); err != nil {
+				return published, err
+			}
+
+			continue
+		}
+
+		metrics.OutboxEvents.WithLabelValues(event.Type, "published").Inc()
+
+		if err = ref.outboxRepository.MarkPublished(ctx, event.ID, time.Now()); err != nil {
+			return published, err
+		}
+
+		published++
+	}
+
+	return published, nil
+}
+
+// encode serializes entities in their API representation, so that consumers
+// parse events and responses alike.
+func encode(data any) ([]byte, error) {
+	switch value := data.(type) {
+	case entity.Vehicle:
+		return json.Marshal(responses.VehicleFromDomain(value))
+	case entity.Sale:
+		return json.Marshal(responses.SaleFromDomain(value))
+	}
+
+	return json.Marshal(data)
+}
+
+func backoff(attempts int) time.Duration {
+	delay := retryBackoff
+
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,122 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mocks "github.com/caiiomp/vehicle-resale-api/src/core/_mocks"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAppend(t *testing.T) {
+	ctx := context.TODO()
+	vehicleID := primitive.NewObjectID().Hex()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not append event with invalid data", func(t *testing.T) {
+		service := NewOutboxService(nil, nil, 10)
+
+		err := service.Append(ctx, entity.EventVehicleCreated, vehicleID, make(chan int))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should not append event when failed to append", func(t *testing.T) {
+		outboxRepositoryMocked := mocks.NewOutboxRepository(t)
+
+		outboxRepositoryMocked.On("Append", ctx, mock.Anything).
+			Return(unexpectedError)
+
+		service := NewOutboxService(outboxRepositoryMocked, nil, 10)
+
+		err := service.Append(ctx, entity.EventVehicleCreated, vehicleID, entity.Vehicle{ID: vehicleID})
+
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should append vehicle event in its api representation", func(t *testing.T) {
+		outboxRepositoryMocked := mocks.NewOutboxRepository(t)
+
+		outboxRepositoryMocked.On("Append", ctx, mock.MatchedBy(func(event entity.Event) bool {
+			return event.Type == entity.EventVehicleCreated &&
+				event.EntityID == vehicleID &&
+				string(event.Payload) == `{"id":"`+vehicleID+`","brand":"Ford","model":"Ka","year":2022,"color":"","price":50000,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` &&
+				!event.OccurredAt.IsZero()
+		})).
+			Return(nil)
+
+		service := NewOutboxService(outboxRepositoryMocked, nil, 10)
+
+		err := service.Append(ctx, entity.EventVehicleCreated, vehicleID, entity.Vehicle{
+			ID:    vehicleID,
+			Brand: "Ford",
+			Model: "Ka",
+			Year:  2022,
+			Price: 50000,
+		})
+
+		assert.Nil(t, err)
+	})
+}
+
+func TestRelay(t *testing.T) {
+	ctx := context.TODO()
+	unexpectedError := errors.New("unexpected error")
+
+	published := entity.Event{ID: primitive.NewObjectID().Hex(), Type: entity.EventVehicleCreated}
+	failed := entity.Event{ID: primitive.NewObjectID().Hex(), Type: entity.EventSaleCreated}
+
+	t.Run("should not relay events when failed to claim", func(t *testing.T) {
+		outboxRepositoryMocked := mocks.NewOutboxRepository(t)
+
+		outboxRepositoryMocked.On("ClaimPending", ctx, 10, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return(nil, unexpectedError)
+
+		service := NewOutboxService(outboxRepositoryMocked, nil, 10)
+
+		actual, err := service.Relay(ctx)
+
+		assert.Zero(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should mark events as published or retry them later", func(t *testing.T) {
+		outboxRepositoryMocked := mocks.NewOutboxRepository(t)
+		eventPublisherMocked := mocks.NewEventPublisher(t)
+
+		outboxRepositoryMocked.On("ClaimPending", ctx, 10, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return([]entity.OutboxMessage{
+				{Event: published, Attempts: 1},
+				{Event: failed, Attempts: 3},
+			}, nil)
+		outboxRepositoryMocked.On("MarkPublished", ctx, published.ID, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		outboxRepositoryMocked.On("MarkFailed", ctx, failed.ID, "unexpected error", mock.MatchedBy(func(availableAt time.Time) bool {
+			return availableAt.After(time.Now().Add(3 * time.Second))
+		})).
+			Return(nil)
+
+		eventPublisherMocked.On("Publish", ctx, published).
+			Return(nil)
+		eventPublisherMocked.On("Publish", ctx, failed).
+			Return(unexpectedError)
+
+		service := NewOutboxService(outboxRepositoryMocked, eventPublisherMocked, 10)
+
+		actual, err := service.Relay(ctx)
+
+		assert.Equal(t, 1, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, backoff(1))
+	assert.Equal(t, 4*time.Second, backoff(3))
+	assert.Equal(t, maxBackoff, backoff(100))
+}
@@ -0,0 +1,293 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/metrics"
+	"github.com/caiiomp/vehicle-resale-api/src/tracing"
+)
+
+type paymentService struct {
+	paymentRepository interfaces.PaymentRepository
+	vehicleRepository interfaces.VehicleRepository
+	saleRepository    interfaces.SaleRepository
+	paymentGateway    interfaces.PaymentGateway
+	auditService      interfaces.AuditService
+	transactor        interfaces.Transactor
+	outboxService     interfaces.OutboxService
+	// dealershipSellerID is the seller of the vehicles taken as trade-ins.
+	dealershipSellerID string
+}
+
+func NewPaymentService(
+	paymentRepository interfaces.PaymentRepository,
+	vehicleRepository interfaces.VehicleRepository,
+	saleRepository interfaces.SaleRepository,
+	paymentGateway interfaces.PaymentGateway,
+	auditService interfaces.AuditService,
+	transactor interfaces.Transactor,
+	outboxService interfaces.OutboxService,
+	dealershipSellerID string,
+) interfaces.PaymentService {
+	return &paymentService{
+		paymentRepository:  paymentRepository,
+		vehicleRepository:  vehicleRepository,
+		saleRepository:     saleRepository,
+		paymentGateway:     paymentGateway,
+		auditService:       auditService,
+		transactor:         transactor,
+		outboxService:      outboxService,
+		dealershipSellerID: dealershipSellerID,
+	}
+}
+
+// GetByID returns nil for payments of other users.
+func (ref *paymentService) GetByID(ctx context.Context, id, userID string) (*entity.Payment, error) {
+	payment, err := ref.paymentRepository.GetByID(ctx, id)
+	if err != nil || payment == nil {
+		return nil, err
+	}
+
+	if payment.UserID != userID {
+		return nil, nil
+	}
+
+	return payment, nil
+}
+
+func (ref *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) (_ *entity.Payment, err error) {
+	ctx, span := tracing.Start(ctx, "paymentService.HandleWebhook")
+	defer func() { span.End(err) }()
+
+	event, err := ref.paymentGateway.ParseWebhook(payload, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := ref.paymentRepository.GetByIntentID(ctx, event.IntentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment == nil {
+		return nil, entity.ErrPaymentNotFound
+	}
+
+	// The gateway may deliver the same event more than once; finalize and
+	// release complete only payments that are still pending.
+	if payment.Status != entity.PaymentStatusPending {
+		return payment, nil
+	}
+
+	if event.Status == entity.PaymentStatusSucceeded {
+		return ref.finalize(ctx, *payment)
+	}
+
+	return ref.release(ctx, *payment, event.Status)
+}
+
+// ReleaseExpired goes on with the other payments when one cannot be released,
+// which is left for the next run.
+func (ref *paymentService) ReleaseExpired(ctx context.Context) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "paymentService.ReleaseExpired")
+	defer func() { span.End(err) }()
+
+	payments, err := ref.paymentRepository.SearchExpired(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+
+	for _, payment := range payments {
+		if payment.IntentID != "" {
+			// Intents the gateway does not know are already canceled.
+			err := ref.paymentGateway.CancelIntent(ctx, payment.IntentID)
+			if err != nil && !errors.Is(err, entity.ErrPaymentIntentNotFound) {
+				slog.WarnContext(ctx, "could not cancel expired payment intent", "payment_id", payment.ID, "error", err)
+				continue
+			}
+		}
+
+		if _, err := ref.release(ctx, payment, entity.PaymentStatusCanceled); err != nil {
+			slog.WarnContext(ctx, "could not release expired payment", "payment_id", payment.ID, "error", err)
+			continue
+		}
+
+		released++
+	}
+
+	return released, nil
+}
+
+// finalize returns the payment as it is when another webhook completed it
+// meanwhile.
+func (ref *paymentService) finalize(ctx context.Context, payment entity.Payment) (*entity.Payment, error) {
+	soldTime := time.Now()
+
+	sale := entity.Sale{
+		VehicleID: payment.VehicleID,
+		UserID:    payment.UserID,
+		Price:     payment.Price,
+		NetPrice:  payment.Amount,
+		SoldAt:    soldTime,
+	}
+
+	soldVehicle := entity.Vehicle{
+		Status: entity.VehicleStatusSold,
+		SoldAt: &soldTime,
+	}
+
+	var (
+		createdSale *entity.Sale
+		updated     *entity.Payment
+	)
+
+	err := ref.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		updated, err = ref.paymentRepository.UpdatePending(ctx, payment.ID, entity.Payment{
+			Status: entity.PaymentStatusSucceeded,
+		})
+		if err != nil || updated == nil {
+			return err
+		}
+
+		if payment.TradeIn != nil {
+			tradeInVehicle, err := ref.vehicleRepository.Create(ctx, entity.Vehicle{
+				Brand:    payment.TradeIn.Brand,
+				Model:    payment.TradeIn.Model,
+				Year:     payment.TradeIn.Year,
+				Color:    payment.TradeIn.Color,
+				Price:    payment.TradeIn.Valuation,
+				Status:   entity.VehicleStatusDraft,
+				SellerID: ref.dealershipSellerID,
+			})
+			if err != nil {
+				return err
+			}
+
+			if tradeInVehicle == nil {
+				return errors.New("could not create trade-in vehicle")
+			}
+
+			if err = ref.outboxService.Append(ctx, entity.EventVehicleCreated, tradeInVehicle.ID, *tradeInVehicle); err != nil {
+				return err
+			}
+
+			sale.TradeInVehicleID = tradeInVehicle.ID
+			sale.TradeInCredit = payment.TradeIn.Valuation
+		}
+
+		createdSale, err = ref.saleRepository.Create(ctx, sale)
+		if err != nil {
+			return err
+		}
+
+		if createdSale != nil {
+			if err = ref.outboxService.Append(ctx, entity.EventSaleCreated, createdSale.ID, *createdSale); err != nil {
+				return err
+			}
+
+			if err = ref.auditService.Record(ctx, entity.AuditEntitySale, createdSale.ID, entity.AuditActionCreate, nil, createdSale); err != nil {
+				return err
+			}
+
+			updated, err = ref.paymentRepository.Update(ctx, payment.ID, entity.Payment{SaleID: createdSale.ID})
+			if err != nil {
+				return err
+			}
+		}
+
+		vehicle, err := ref.vehicleRepository.Update(ctx, payment.VehicleID, soldVehicle)
+		if err != nil {
+			return err
+		}
+
+		if vehicle != nil {
+			if err = ref.outboxService.Append(ctx, entity.EventVehicleSold, vehicle.ID, *vehicle); err != nil {
+				return err
+			}
+		}
+
+		return ref.auditService.Record(ctx, entity.AuditEntityVehicle, payment.VehicleID, entity.AuditActionSell,
+			entity.Vehicle{Status: entity.VehicleStatusReserved}, soldVehicle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if updated == nil {
+		return ref.paymentRepository.GetByID(ctx, payment.ID)
+	}
+
+	var saleID string
+	if createdSale != nil {
+		saleID = createdSale.ID
+	}
+
+	metrics.Purchases.WithLabelValues(entity.PaymentStatusSucceeded).Inc()
+	metrics.SalesRevenue.Add(sale.Price)
+
+	slog.InfoContext(ctx, "vehicle purchased",
+		"user_id", payment.UserID,
+		"vehicle_id", payment.VehicleID,
+		"payment_id", payment.ID,
+		"sale_id", saleID,
+		"price", sale.Price,
+	)
+
+	return updated, nil
+}
+
+// release returns the payment as it is when another webhook or run completed
+// it meanwhile.
+func (ref *paymentService) release(ctx context.Context, payment entity.Payment, status string) (*entity.Payment, error) {
+	var updated *entity.Payment
+
+	err := ref.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		updated, err = ref.paymentRepository.UpdatePending(ctx, payment.ID, entity.Payment{
+			Status: status,
+		})
+		if err != nil || updated == nil {
+			return err
+		}
+
+		vehicle, err := ref.vehicleRepository.Update(ctx, payment.VehicleID, entity.Vehicle{
+			Status: entity.VehicleStatusAvailable,
+		})
+		if err != nil {
+			return err
+		}
+
+		if vehicle != nil {
+			if err = ref.outboxService.Append(ctx, entity.EventVehicleUpdated, vehicle.ID, *vehicle); err != nil {
+				return err
+			}
+		}
+
+		return ref.auditService.Record(ctx, entity.AuditEntityVehicle, payment.VehicleID, entity.AuditActionCancel,
+			entity.Vehicle{Status: entity.VehicleStatusReserved}, entity.Vehicle{Status: entity.VehicleStatusAvailable})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if updated == nil {
+		return ref.paymentRepository.GetByID(ctx, payment.ID)
+	}
+
+	metrics.Purchases.WithLabelValues(status).Inc()
+
+	slog.InfoContext(ctx, "vehicle purchase released",
+		"user_id", payment.UserID,
+		"vehicle_id", payment.VehicleID,
+		"payment_id", payment.ID,
+		"status", status,
+	)
+
+	return updated, nil
+}
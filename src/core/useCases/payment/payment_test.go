@@ -0,0 +1,399 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mocks "github.com/caiiomp/vehicle-resale-api/src/core/_mocks"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetByID(t *testing.T) {
+	ctx := context.TODO()
+	paymentID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not get payment by id when failed to get", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+
+		paymentRepositoryMocked.On("GetByID", ctx, paymentID).
+			Return(nil, unexpectedError)
+
+		service := NewPaymentService(paymentRepositoryMocked, nil, nil, nil, nil, nil, nil, "")
+
+		actual, err := service.GetByID(ctx, paymentID, userID)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should not get payment of another user", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+
+		paymentRepositoryMocked.On("GetByID", ctx, paymentID).
+			Return(&entity.Payment{ID: paymentID, UserID: "another-user"}, nil)
+
+		service := NewPaymentService(paymentRepositoryMocked, nil, nil, nil, nil, nil, nil, "")
+
+		actual, err := service.GetByID(ctx, paymentID, userID)
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should get payment by id successfully", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+
+		paymentRepositoryMocked.On("GetByID", ctx, paymentID).
+			Return(&entity.Payment{ID: paymentID, UserID: userID}, nil)
+
+		service := NewPaymentService(paymentRepositoryMocked, nil, nil, nil, nil, nil, nil, "")
+
+		actual, err := service.GetByID(ctx, paymentID, userID)
+
+		assert.NotNil(t, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestHandleWebhook(t *testing.T) {
+	ctx := context.TODO()
+	paymentID := primitive.NewObjectID().Hex()
+	vehicleID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+	saleID := primitive.NewObjectID().Hex()
+	unexpectedError := errors.New("unexpected error")
+
+	payload := []byte(`{"intent_id":"pi_123","status":"succeeded"}`)
+	signature := "signature"
+
+	t.Run("should not handle webhook when signature is invalid", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		paymentGatewayMocked.On("ParseWebhook", payload, signature).
+			Return(nil, entity.ErrInvalidPaymentSignature)
+
+		service := NewPaymentService(paymentRepositoryMocked, nil, nil, paymentGatewayMocked, nil, nil, nil, "")
+
+		actual, err := service.HandleWebhook(ctx, payload, signature)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, entity.ErrInvalidPaymentSignature)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "GetByIntentID", 0)
+	})
+
+	t.Run("should not handle webhook when payment does not exist", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		paymentGatewayMocked.On("ParseWebhook", payload, signature).
+			Return(&entity.PaymentEvent{IntentID: "pi_123", Status: entity.PaymentStatusSucceeded}, nil)
+
+		paymentRepositoryMocked.On("GetByIntentID", ctx, "pi_123").
+			Return(nil, nil)
+
+		service := NewPaymentService(paymentRepositoryMocked, nil, nil, paymentGatewayMocked, nil, nil, nil, "")
+
+		actual, err := service.HandleWebhook(ctx, payload, signature)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, entity.ErrPaymentNotFound)
+	})
+
+	t.Run("should ignore webhook when payment was already processed", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		payment := &entity.Payment{
+			ID:     paymentID,
+			Status: entity.PaymentStatusSucceeded,
+		}
+
+		paymentGatewayMocked.On("ParseWebhook", payload, signature).
+			Return(&entity.PaymentEvent{IntentID: "pi_123", Status: entity.PaymentStatusSucceeded}, nil)
+
+		paymentRepositoryMocked.On("GetByIntentID", ctx, "pi_123").
+			Return(payment, nil)
+
+		service := NewPaymentService(paymentRepositoryMocked, nil, nil, paymentGatewayMocked, nil, nil, nil, "")
+
+		actual, err := service.HandleWebhook(ctx, payload, signature)
+
+		assert.Equal(t, payment, actual)
+		assert.Nil(t, err)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("should not finalize payment when failed to create sale", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		saleRepositoryMocked := mocks.NewSaleRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		payment := &entity.Payment{
+			ID:        paymentID,
+			VehicleID: vehicleID,
+			Status:    entity.PaymentStatusPending,
+		}
+
+		paymentGatewayMocked.On("ParseWebhook", payload, signature).
+			Return(&entity.PaymentEvent{IntentID: "pi_123", Status: entity.PaymentStatusSucceeded}, nil)
+
+		paymentRepositoryMocked.On("GetByIntentID", ctx, "pi_123").
+			Return(payment, nil)
+
+		paymentRepositoryMocked.On("UpdatePending", ctx, paymentID, entity.Payment{Status: entity.PaymentStatusSucceeded}).
+			Return(&entity.Payment{ID: paymentID, Status: entity.PaymentStatusSucceeded}, nil)
+
+		saleRepositoryMocked.On("Create", ctx, mock.AnythingOfType("entity.Sale")).
+			Return(nil, unexpectedError)
+
+		service := NewPaymentService(paymentRepositoryMocked, vehicleRepositoryMocked, saleRepositoryMocked, paymentGatewayMocked, nil, mocks.NewPassThroughTransactor(t), nil, "")
+
+		actual, err := service.HandleWebhook(ctx, payload, signature)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("should not finalize payment completed by another webhook", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		saleRepositoryMocked := mocks.NewSaleRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		completed := &entity.Payment{ID: paymentID, Status: entity.PaymentStatusSucceeded, SaleID: saleID}
+
+		paymentGatewayMocked.On("ParseWebhook", payload, signature).
+			Return(&entity.PaymentEvent{IntentID: "pi_123", Status: entity.PaymentStatusSucceeded}, nil)
+
+		paymentRepositoryMocked.On("GetByIntentID", ctx, "pi_123").
+			Return(&entity.Payment{ID: paymentID, VehicleID: vehicleID, Status: entity.PaymentStatusPending}, nil)
+		paymentRepositoryMocked.On("UpdatePending", ctx, paymentID, entity.Payment{Status: entity.PaymentStatusSucceeded}).
+			Return(nil, nil)
+		paymentRepositoryMocked.On("GetByID", ctx, paymentID).
+			Return(completed, nil)
+
+		service := NewPaymentService(paymentRepositoryMocked, nil, saleRepositoryMocked, paymentGatewayMocked, nil, mocks.NewPassThroughTransactor(t), nil, "")
+
+		actual, err := service.HandleWebhook(ctx, payload, signature)
+
+		assert.Equal(t, completed, actual)
+		assert.Nil(t, err)
+		saleRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should finalize payment with trade-in successfully", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		saleRepositoryMocked := mocks.NewSaleRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		tradeInVehicleID := primitive.NewObjectID().Hex()
+
+		payment := &entity.Payment{
+			ID:        paymentID,
+			VehicleID: vehicleID,
+			UserID:    userID,
+			Price:     80000,
+			Amount:    50000,
+			Status:    entity.PaymentStatusPending,
+			TradeIn: &entity.TradeIn{
+				Brand:     "Other Brand",
+				Model:     "Other Model",
+				Year:      2015,
+				Color:     "Black",
+				Valuation: 30000,
+			},
+		}
+
+		expectedTradeInVehicle := entity.Vehicle{
+			Brand:    "Other Brand",
+			Model:    "Other Model",
+			Year:     2015,
+			Color:    "Black",
+			Price:    30000,
+			Status:   entity.VehicleStatusDraft,
+			SellerID: "dealership",
+		}
+
+		paymentGatewayMocked.On("ParseWebhook", payload, signature).
+			Return(&entity.PaymentEvent{IntentID: "pi_123", Status: entity.PaymentStatusSucceeded}, nil)
+
+		paymentRepositoryMocked.On("GetByIntentID", ctx, "pi_123").
+			Return(payment, nil)
+		paymentRepositoryMocked.On("UpdatePending", ctx, paymentID, entity.Payment{Status: entity.PaymentStatusSucceeded}).
+			Return(&entity.Payment{ID: paymentID, Status: entity.PaymentStatusSucceeded}, nil)
+		paymentRepositoryMocked.On("Update", ctx, paymentID, entity.Payment{SaleID: saleID}).
+			Return(&entity.Payment{ID: paymentID, Status: entity.PaymentStatusSucceeded, SaleID: saleID}, nil)
+
+		vehicleRepositoryMocked.On("Create", ctx, expectedTradeInVehicle).
+			Return(&entity.Vehicle{ID: tradeInVehicleID}, nil)
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, mock.MatchedBy(func(vehicle entity.Vehicle) bool {
+			return vehicle.Status == entity.VehicleStatusSold && vehicle.SoldAt != nil
+		})).Return(&entity.Vehicle{ID: vehicleID, Status: entity.VehicleStatusSold}, nil)
+
+		saleRepositoryMocked.On("Create", ctx, mock.MatchedBy(func(sale entity.Sale) bool {
+			return sale.VehicleID == vehicleID &&
+				sale.UserID == userID &&
+				sale.Price == 80000 &&
+				sale.TradeInVehicleID == tradeInVehicleID &&
+				sale.TradeInCredit == 30000 &&
+				sale.NetPrice == 50000
+		})).Return(&entity.Sale{ID: saleID}, nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntitySale, saleID, entity.AuditActionCreate, nil, &entity.Sale{ID: saleID}).
+			Return(nil)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionSell, entity.Vehicle{Status: entity.VehicleStatusReserved}, mock.MatchedBy(func(vehicle entity.Vehicle) bool {
+			return vehicle.Status == entity.VehicleStatusSold && vehicle.SoldAt != nil
+		})).Return(nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleCreated, tradeInVehicleID, entity.Vehicle{ID: tradeInVehicleID}).
+			Return(nil)
+		outboxServiceMocked.On("Append", ctx, entity.EventSaleCreated, saleID, entity.Sale{ID: saleID}).
+			Return(nil)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleSold, vehicleID, entity.Vehicle{ID: vehicleID, Status: entity.VehicleStatusSold}).
+			Return(nil)
+
+		service := NewPaymentService(paymentRepositoryMocked, vehicleRepositoryMocked, saleRepositoryMocked, paymentGatewayMocked, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked, "dealership")
+
+		actual, err := service.HandleWebhook(ctx, payload, signature)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.PaymentStatusSucceeded, actual.Status)
+		assert.Equal(t, saleID, actual.SaleID)
+	})
+
+	t.Run("should release vehicle when payment failed", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		saleRepositoryMocked := mocks.NewSaleRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		payment := &entity.Payment{
+			ID:        paymentID,
+			VehicleID: vehicleID,
+			Status:    entity.PaymentStatusPending,
+		}
+
+		paymentGatewayMocked.On("ParseWebhook", payload, signature).
+			Return(&entity.PaymentEvent{IntentID: "pi_123", Status: entity.PaymentStatusFailed}, nil)
+
+		paymentRepositoryMocked.On("GetByIntentID", ctx, "pi_123").
+			Return(payment, nil)
+		paymentRepositoryMocked.On("UpdatePending", ctx, paymentID, entity.Payment{Status: entity.PaymentStatusFailed}).
+			Return(&entity.Payment{ID: paymentID, Status: entity.PaymentStatusFailed}, nil)
+
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, entity.Vehicle{Status: entity.VehicleStatusAvailable}).
+			Return(&entity.Vehicle{ID: vehicleID, Status: entity.VehicleStatusAvailable}, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleUpdated, vehicleID, entity.Vehicle{ID: vehicleID, Status: entity.VehicleStatusAvailable}).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionCancel, entity.Vehicle{Status: entity.VehicleStatusReserved}, entity.Vehicle{Status: entity.VehicleStatusAvailable}).
+			Return(nil)
+
+		service := NewPaymentService(paymentRepositoryMocked, vehicleRepositoryMocked, saleRepositoryMocked, paymentGatewayMocked, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked, "")
+
+		actual, err := service.HandleWebhook(ctx, payload, signature)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.PaymentStatusFailed, actual.Status)
+		saleRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+	})
+}
+
+func TestReleaseExpired(t *testing.T) {
+	ctx := context.TODO()
+	paymentID := primitive.NewObjectID().Hex()
+	vehicleID := primitive.NewObjectID().Hex()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not release expired payments when failed to search", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+
+		paymentRepositoryMocked.On("SearchExpired", ctx, mock.AnythingOfType("time.Time")).
+			Return(nil, unexpectedError)
+
+		service := NewPaymentService(paymentRepositoryMocked, nil, nil, nil, nil, nil, nil, "")
+
+		actual, err := service.ReleaseExpired(ctx)
+
+		assert.Zero(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should release expired payments and skip those that fail", func(t *testing.T) {
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		otherPaymentID := primitive.NewObjectID().Hex()
+		failingPaymentID := primitive.NewObjectID().Hex()
+
+		payments := []entity.Payment{
+			{
+				ID:        failingPaymentID,
+				VehicleID: primitive.NewObjectID().Hex(),
+				IntentID:  "pi_failing",
+				Status:    entity.PaymentStatusPending,
+				ExpiresAt: time.Now().Add(-time.Minute),
+			},
+			{
+				ID:        paymentID,
+				VehicleID: vehicleID,
+				IntentID:  "pi_123",
+				Status:    entity.PaymentStatusPending,
+				ExpiresAt: time.Now().Add(-time.Minute),
+			},
+			{
+				ID:        otherPaymentID,
+				VehicleID: vehicleID,
+				IntentID:  "pi_unknown",
+				Status:    entity.PaymentStatusPending,
+				ExpiresAt: time.Now().Add(-time.Minute),
+			},
+		}
+
+		paymentRepositoryMocked.On("SearchExpired", ctx, mock.AnythingOfType("time.Time")).
+			Return(payments, nil)
+		paymentRepositoryMocked.On("UpdatePending", ctx, paymentID, entity.Payment{Status: entity.PaymentStatusCanceled}).
+			Return(&entity.Payment{}, nil)
+		paymentRepositoryMocked.On("UpdatePending", ctx, otherPaymentID, entity.Payment{Status: entity.PaymentStatusCanceled}).
+			Return(&entity.Payment{}, nil)
+
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, entity.Vehicle{Status: entity.VehicleStatusAvailable}).
+			Return(nil, nil)
+
+		paymentGatewayMocked.On("CancelIntent", ctx, "pi_failing").
+			Return(unexpectedError)
+		paymentGatewayMocked.On("CancelIntent", ctx, "pi_123").
+			Return(nil)
+		paymentGatewayMocked.On("CancelIntent", ctx, "pi_unknown").
+			Return(entity.ErrPaymentIntentNotFound)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionCancel, entity.Vehicle{Status: entity.VehicleStatusReserved}, entity.Vehicle{Status: entity.VehicleStatusAvailable}).
+			Return(nil)
+
+		service := NewPaymentService(paymentRepositoryMocked, vehicleRepositoryMocked, nil, paymentGatewayMocked, auditServiceMocked, mocks.NewPassThroughTransactor(t), nil, "")
+
+		actual, err := service.ReleaseExpired(ctx)
+
+		assert.Equal(t, 2, actual)
+		assert.Nil(t, err)
+		paymentRepositoryMocked.AssertNotCalled(t, "UpdatePending", ctx, failingPaymentID, mock.Anything)
+	})
+}
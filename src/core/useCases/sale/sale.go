@@ -9,18 +9,81 @@ import (
 
 type saleService struct {
 	saleRepository interfaces.SaleRepository
+	auditService   interfaces.AuditService
+	transactor     interfaces.Transactor
+	outboxService  interfaces.OutboxService
 }
 
-func NewSaleService(saleRepository interfaces.SaleRepository) interfaces.SaleService {
+func NewSaleService(
+	saleRepository interfaces.SaleRepository,
+	auditService interfaces.AuditService,
+	transactor interfaces.Transactor,
+	outboxService interfaces.OutboxService,
+) interfaces.SaleService {
 	return &saleService{
 		saleRepository: saleRepository,
+		auditService:   auditService,
+		transactor:     transactor,
+		outboxService:  outboxService,
 	}
 }
 
 func (ref *saleService) Create(ctx context.Context, sale entity.Sale) (*entity.Sale, error) {
-	return ref.saleRepository.Create(ctx, sale)
+	var created *entity.Sale
+
+	err := ref.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		created, err = ref.saleRepository.Create(ctx, sale)
+		if err != nil || created == nil {
+			return err
+		}
+
+		if err = ref.outboxService.Append(ctx, entity.EventSaleCreated, created.ID, *created); err != nil {
+			return err
+		}
+
+		return ref.auditService.Record(ctx, entity.AuditEntitySale, created.ID, entity.AuditActionCreate, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 func (ref *saleService) Search(ctx context.Context) ([]entity.Sale, error) {
 	return ref.saleRepository.Search(ctx)
 }
+
+func (ref *saleService) Iterate(ctx context.Context, fn func(entity.Sale) error) error {
+	return ref.saleRepository.Iterate(ctx, fn)
+}
+
+func (ref *saleService) Report(ctx context.Context, filter entity.SalesReportFilter) (*entity.SalesReport, error) {
+	groups, err := ref.saleRepository.Report(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.SalesReport{
+		GroupBy: filter.GroupBy,
+		Totals: entity.SalesReportGroup{
+			Key: "total",
+		},
+		Groups: groups,
+	}
+
+	var daysOnMarket float64
+
+	for _, group := range groups {
+		report.Totals.Count += group.Count
+		report.Totals.Revenue += group.Revenue
+		daysOnMarket += group.AverageDaysOnMarket * float64(group.Count)
+	}
+
+	if report.Totals.Count > 0 {
+		report.Totals.AverageTicket = report.Totals.Revenue / float64(report.Totals.Count)
+		report.Totals.AverageDaysOnMarket = daysOnMarket / float64(report.Totals.Count)
+	}
+
+	return report, nil
+}
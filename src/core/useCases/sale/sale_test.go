@@ -9,6 +9,7 @@ import (
 	mocks "github.com/caiiomp/vehicle-resale-api/src/core/_mocks"
 	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -31,7 +32,7 @@ func TestCreate(t *testing.T) {
 		saleRepositoryMocked.On("Create", ctx, sale).
 			Return(nil, unexpectedError)
 
-		service := NewSaleService(saleRepositoryMocked)
+		service := NewSaleService(saleRepositoryMocked, nil, mocks.NewPassThroughTransactor(t), nil)
 
 		actual, err := service.Create(ctx, sale)
 
@@ -52,7 +53,15 @@ func TestCreate(t *testing.T) {
 		saleRepositoryMocked.On("Create", ctx, sale).
 			Return(&sale, nil)
 
-		service := NewSaleService(saleRepositoryMocked)
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventSaleCreated, sale.ID, sale).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntitySale, sale.ID, entity.AuditActionCreate, nil, &sale).
+			Return(nil)
+
+		service := NewSaleService(saleRepositoryMocked, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
 
 		actual, err := service.Create(ctx, sale)
 
@@ -73,7 +82,7 @@ func TestSearch(t *testing.T) {
 		saleRepositoryMocked.On("Search", ctx).
 			Return(nil, unexpectedError)
 
-		service := NewSaleService(saleRepositoryMocked)
+		service := NewSaleService(saleRepositoryMocked, nil, nil, nil)
 
 		actual, err := service.Search(ctx)
 
@@ -96,7 +105,7 @@ func TestSearch(t *testing.T) {
 		saleRepositoryMocked.On("Search", ctx).
 			Return(sales, nil)
 
-		service := NewSaleService(saleRepositoryMocked)
+		service := NewSaleService(saleRepositoryMocked, nil, nil, nil)
 
 		actual, err := service.Search(ctx)
 
@@ -104,3 +113,125 @@ func TestSearch(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestIterate(t *testing.T) {
+	ctx := context.TODO()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not iterate sales when failed to iterate", func(t *testing.T) {
+		saleRepositoryMocked := mocks.NewSaleRepository(t)
+
+		saleRepositoryMocked.On("Iterate", ctx, mock.Anything).
+			Return(unexpectedError)
+
+		service := NewSaleService(saleRepositoryMocked, nil, nil, nil)
+
+		err := service.Iterate(ctx, func(entity.Sale) error { return nil })
+
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should iterate sales successfully", func(t *testing.T) {
+		saleRepositoryMocked := mocks.NewSaleRepository(t)
+
+		saleRepositoryMocked.On("Iterate", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(func(entity.Sale) error)
+				_ = fn(entity.Sale{ID: "some-id"})
+			}).
+			Return(nil)
+
+		service := NewSaleService(saleRepositoryMocked, nil, nil, nil)
+
+		var iterated []string
+
+		err := service.Iterate(ctx, func(sale entity.Sale) error {
+			iterated = append(iterated, sale.ID)
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"some-id"}, iterated)
+	})
+}
+
+func TestReport(t *testing.T) {
+	ctx := context.TODO()
+	unexpectedError := errors.New("unexpected error")
+
+	filter := entity.SalesReportFilter{
+		GroupBy: entity.SalesReportGroupByBrand,
+	}
+
+	t.Run("should not build report when failed to aggregate sales", func(t *testing.T) {
+		saleRepositoryMocked := mocks.NewSaleRepository(t)
+
+		saleRepositoryMocked.On("Report", ctx, filter).
+			Return(nil, unexpectedError)
+
+		service := NewSaleService(saleRepositoryMocked, nil, nil, nil)
+
+		actual, err := service.Report(ctx, filter)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should build empty report when there are no sales", func(t *testing.T) {
+		saleRepositoryMocked := mocks.NewSaleRepository(t)
+
+		saleRepositoryMocked.On("Report", ctx, filter).
+			Return([]entity.SalesReportGroup{}, nil)
+
+		service := NewSaleService(saleRepositoryMocked, nil, nil, nil)
+
+		actual, err := service.Report(ctx, filter)
+
+		assert.Nil(t, err)
+		assert.Zero(t, actual.Totals.Count)
+		assert.Zero(t, actual.Totals.AverageTicket)
+	})
+
+	t.Run("should build report with totals successfully", func(t *testing.T) {
+		saleRepositoryMocked := mocks.NewSaleRepository(t)
+
+		groups := []entity.SalesReportGroup{
+			{
+				Key:                 "Chevrolet",
+				Count:               1,
+				Revenue:             60000,
+				AverageTicket:       60000,
+				AverageDaysOnMarket: 40,
+			},
+			{
+				Key:                 "Ford",
+				Count:               3,
+				Revenue:             120000,
+				AverageTicket:       40000,
+				AverageDaysOnMarket: 20,
+			},
+		}
+
+		saleRepositoryMocked.On("Report", ctx, filter).
+			Return(groups, nil)
+
+		service := NewSaleService(saleRepositoryMocked, nil, nil, nil)
+
+		expected := &entity.SalesReport{
+			GroupBy: entity.SalesReportGroupByBrand,
+			Totals: entity.SalesReportGroup{
+				Key:                 "total",
+				Count:               4,
+				Revenue:             180000,
+				AverageTicket:       45000,
+				AverageDaysOnMarket: 25,
+			},
+			Groups: groups,
+		}
+
+		actual, err := service.Report(ctx, filter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, actual)
+	})
+}
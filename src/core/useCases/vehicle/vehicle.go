@@ -3,71 +3,554 @@ package vehicle
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"sort"
 	"time"
 
 	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
 	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/metrics"
+	"github.com/caiiomp/vehicle-resale-api/src/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const paymentExpiration = 15 * time.Minute
+
+var agingBuckets = []entity.InventoryAgingBucket{
+	{Label: "0-30", MinDays: 0, MaxDays: intPointer(30)},
+	{Label: "31-60", MinDays: 31, MaxDays: intPointer(60)},
+	{Label: "61-90", MinDays: 61, MaxDays: intPointer(90)},
+	{Label: "90+", MinDays: 91},
+}
+
 type vehicleService struct {
-	vehicleRepository interfaces.VehicleRepository
-	saleRepository    interfaces.SaleRepository
+	vehicleRepository        interfaces.VehicleRepository
+	vehicleVersionRepository interfaces.VehicleVersionRepository
+	paymentRepository        interfaces.PaymentRepository
+	paymentGateway           interfaces.PaymentGateway
+	auditService             interfaces.AuditService
+	transactor               interfaces.Transactor
+	outboxService            interfaces.OutboxService
 }
 
-func NewVehicleService(vehicleRepository interfaces.VehicleRepository, saleRepository interfaces.SaleRepository) interfaces.VehicleService {
+func NewVehicleService(
+	vehicleRepository interfaces.VehicleRepository,
+	vehicleVersionRepository interfaces.VehicleVersionRepository,
+	paymentRepository interfaces.PaymentRepository,
+	paymentGateway interfaces.PaymentGateway,
+	auditService interfaces.AuditService,
+	transactor interfaces.Transactor,
+	outboxService interfaces.OutboxService,
+) interfaces.VehicleService {
 	return &vehicleService{
-		vehicleRepository: vehicleRepository,
-		saleRepository:    saleRepository,
+		vehicleRepository:        vehicleRepository,
+		vehicleVersionRepository: vehicleVersionRepository,
+		paymentRepository:        paymentRepository,
+		paymentGateway:           paymentGateway,
+		auditService:             auditService,
+		transactor:               transactor,
+		outboxService:            outboxService,
 	}
 }
 
-func (ref *vehicleService) Create(ctx context.Context, vehicle entity.Vehicle) (*entity.Vehicle, error) {
-	return ref.vehicleRepository.Create(ctx, vehicle)
+func (ref *vehicleService) Create(ctx context.Context, vehicle entity.Vehicle) (_ *entity.Vehicle, err error) {
+	ctx, span := tracing.Start(ctx, "vehicleService.Create")
+	defer func() { span.End(err) }()
+
+	if vehicle.Status == "" {
+		vehicle.Status = entity.VehicleStatusAvailable
+	}
+
+	var created *entity.Vehicle
+
+	err = ref.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		created, err = ref.vehicleRepository.Create(ctx, vehicle)
+		if err != nil {
+			return err
+		}
+
+		if err = ref.outboxService.Append(ctx, entity.EventVehicleCreated, created.ID, *created); err != nil {
+			return err
+		}
+
+		return ref.auditService.Record(ctx, entity.AuditEntityVehicle, created.ID, entity.AuditActionCreate, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.VehiclesCreated.Inc()
+
+	slog.InfoContext(ctx, "vehicle created",
+		"user_id", created.SellerID,
+		"vehicle_id", created.ID,
+		"price", created.Price,
+	)
+
+	return created, nil
 }
 
 func (ref *vehicleService) GetByID(ctx context.Context, id string) (*entity.Vehicle, error) {
 	return ref.vehicleRepository.GetByID(ctx, id)
 }
 
-func (ref *vehicleService) Search(ctx context.Context, isSold *bool) ([]entity.Vehicle, error) {
-	return ref.vehicleRepository.Search(ctx, isSold)
+// GetAsOf falls back to the current vehicle when it has no version recorded
+// up to asOf but was not changed since, as for vehicles saved before versions
+// were recorded.
+func (ref *vehicleService) GetAsOf(ctx context.Context, id string, asOf time.Time) (*entity.Vehicle, error) {
+	version, err := ref.vehicleVersionRepository.GetAsOf(ctx, id, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != nil {
+		return &version.Vehicle, nil
+	}
+
+	vehicle, err := ref.vehicleRepository.GetByID(ctx, id)
+	if err != nil || vehicle == nil {
+		return nil, err
+	}
+
+	if vehicle.UpdatedAt.After(asOf) {
+		return nil, nil
+	}
+
+	return vehicle, nil
+}
+
+func (ref *vehicleService) History(ctx context.Context, id string) ([]entity.VehicleHistoryEntry, error) {
+	versions, err := ref.vehicleVersionRepository.Search(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]entity.VehicleHistoryEntry, len(versions))
+
+	var previous *entity.Vehicle
+
+	for i, version := range versions {
+		history[i] = entity.VehicleHistoryEntry{
+			VehicleVersion: version,
+			Changes:        audit.Diff(previous, version.Vehicle),
+		}
+
+		previous = &versions[i].Vehicle
+	}
+
+	return history, nil
+}
+
+func (ref *vehicleService) Search(ctx context.Context, filter entity.VehicleFilter) ([]entity.Vehicle, error) {
+	return ref.vehicleRepository.Search(ctx, filter)
+}
+
+func (ref *vehicleService) Iterate(ctx context.Context, filter entity.VehicleFilter, fn func(entity.Vehicle) error) error {
+	return ref.vehicleRepository.Iterate(ctx, filter, fn)
+}
+
+func (ref *vehicleService) Update(ctx context.Context, id string, vehicle entity.Vehicle) (_ *entity.Vehicle, err error) {
+	ctx, span := tracing.Start(ctx, "vehicleService.Update", trace.WithAttributes(attribute.String("vehicle.id", id)))
+	defer func() { span.End(err) }()
+
+	var before, updated *entity.Vehicle
+
+	err = ref.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		before, err = ref.vehicleRepository.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if before != nil && before.IsDeleted() {
+			return nil
+		}
+
+		updated, err = ref.vehicleRepository.Replace(ctx, id, vehicle)
+		if err != nil || updated == nil {
+			return err
+		}
+
+		if err = ref.outboxService.Append(ctx, entity.EventVehicleUpdated, id, *updated); err != nil {
+			return err
+		}
+
+		if before != nil && before.Price != updated.Price {
+			if err = ref.outboxService.Append(ctx, entity.EventVehiclePriceChanged, id, *updated); err != nil {
+				return err
+			}
+		}
+
+		return ref.auditService.Record(ctx, entity.AuditEntityVehicle, id, entity.AuditActionUpdate, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if updated != nil {
+		slog.InfoContext(ctx, "vehicle updated", "vehicle_id", id)
+	}
+
+	return updated, nil
+}
+
+func (ref *vehicleService) Delete(ctx context.Context, id, userID string) (_ *entity.Vehicle, err error) {
+	ctx, span := tracing.Start(ctx, "vehicleService.Delete", trace.WithAttributes(attribute.String("vehicle.id", id)))
+	defer func() { span.End(err) }()
+
+	var before, deleted *entity.Vehicle
+
+	err = ref.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		before, err = ref.vehicleRepository.GetByID(ctx, id)
+		if err != nil || before == nil || before.IsDeleted() {
+			return err
+		}
+
+		if before.SoldAt != nil || before.Status == entity.VehicleStatusSold || before.Status == entity.VehicleStatusReserved {
+			return entity.ErrVehicleNotDeletable
+		}
+
+		// A purchase reserving the vehicle meanwhile changes its version, so
+		// the vehicle is not deleted under it.
+		deleted, err = ref.vehicleRepository.Delete(ctx, id, before.Version, userID)
+		if err != nil || deleted == nil {
+			return err
+		}
+
+		if err = ref.outboxService.Append(ctx, entity.EventVehicleDeleted, id, *deleted); err != nil {
+			return err
+		}
+
+		return ref.auditService.Record(ctx, entity.AuditEntityVehicle, id, entity.AuditActionDelete, before, deleted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted != nil {
+		slog.InfoContext(ctx, "vehicle deleted", "user_id", userID, "vehicle_id", id)
+	}
+
+	return deleted, nil
+}
+
+// Restore returns vehicles that are not deleted as they are.
+func (ref *vehicleService) Restore(ctx context.Context, id string) (_ *entity.Vehicle, err error) {
+	ctx, span := tracing.Start(ctx, "vehicleService.Restore", trace.WithAttributes(attribute.String("vehicle.id", id)))
+	defer func() { span.End(err) }()
+
+	var before, restored *entity.Vehicle
+
+	err = ref.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		before, err = ref.vehicleRepository.GetByID(ctx, id)
+		if err != nil || before == nil {
+			return err
+		}
+
+		if !before.IsDeleted() {
+			restored = before
+			return nil
+		}
+
+		restored, err = ref.vehicleRepository.Restore(ctx, id, before.Version)
+		if err != nil || restored == nil {
+			return err
+		}
+
+		if err = ref.outboxService.Append(ctx, entity.EventVehicleRestored, id, *restored); err != nil {
+			return err
+		}
+
+		return ref.auditService.Record(ctx, entity.AuditEntityVehicle, id, entity.AuditActionRestore, before, restored)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if restored != nil && before.IsDeleted() {
+		slog.InfoContext(ctx, "vehicle restored", "vehicle_id", id)
+	}
+
+	return restored, nil
+}
+
+// Publish returns vehicles that are not drafts as they are.
+func (ref *vehicleService) Publish(ctx context.Context, id string) (_ *entity.Vehicle, err error) {
+	ctx, span := tracing.Start(ctx, "vehicleService.Publish", trace.WithAttributes(attribute.String("vehicle.id", id)))
+	defer func() { span.End(err) }()
+
+	var before, published *entity.Vehicle
+
+	err = ref.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		before, err = ref.vehicleRepository.GetByID(ctx, id)
+		if err != nil || before == nil || before.IsDeleted() {
+			return err
+		}
+
+		if before.Status != entity.VehicleStatusDraft {
+			published = before
+			return nil
+		}
+
+		published, err = ref.vehicleRepository.Update(ctx, id, entity.Vehicle{
+			Status:  entity.VehicleStatusAvailable,
+			Version: before.Version,
+		})
+		if err != nil || published == nil {
+			return err
+		}
+
+		if err = ref.outboxService.Append(ctx, entity.EventVehicleUpdated, id, *published); err != nil {
+			return err
+		}
+
+		return ref.auditService.Record(ctx, entity.AuditEntityVehicle, id, entity.AuditActionUpdate, before, published)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if published != nil && before.Status == entity.VehicleStatusDraft {
+		slog.InfoContext(ctx, "vehicle published", "vehicle_id", id)
+	}
+
+	return published, nil
 }
 
-func (ref *vehicleService) Update(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
-	return ref.vehicleRepository.Update(ctx, id, vehicle)
+func (ref *vehicleService) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	return ref.vehicleRepository.Purge(ctx, time.Now().Add(-retention))
 }
 
-func (ref *vehicleService) Buy(ctx context.Context, vehicleID, userID string) (*entity.Vehicle, error) {
+func (ref *vehicleService) InventoryAging(ctx context.Context, limit int) (_ *entity.InventoryAgingReport, err error) {
+	ctx, span := tracing.Start(ctx, "vehicleService.InventoryAging")
+	defer func() { span.End(err) }()
+
+	isSold := false
+
+	vehicles, err := ref.vehicleRepository.Search(ctx, entity.VehicleFilter{IsSold: &isSold})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	report := &entity.InventoryAgingReport{
+		Buckets: newAgingBuckets(),
+		Brands:  make([]entity.InventoryAgingBrand, 0),
+		Oldest:  make([]entity.InventoryAgingVehicle, 0),
+	}
+
+	brandIndexes := map[string]int{}
+	listed := make([]entity.InventoryAgingVehicle, 0, len(vehicles))
+
+	for _, vehicle := range vehicles {
+		if vehicle.SoldAt != nil {
+			continue
+		}
+
+		daysListed := int(now.Sub(vehicle.CreatedAt).Hours() / 24)
+		bucketIndex := agingBucketIndex(daysListed)
+
+		report.Buckets[bucketIndex].Count++
+		report.Buckets[bucketIndex].TotalValue += vehicle.Price
+
+		brandIndex, ok := brandIndexes[vehicle.Brand]
+		if !ok {
+			brandIndex = len(report.Brands)
+			brandIndexes[vehicle.Brand] = brandIndex
+
+			report.Brands = append(report.Brands, entity.InventoryAgingBrand{
+				Brand:   vehicle.Brand,
+				Buckets: newAgingBuckets(),
+			})
+		}
+
+		brand := &report.Brands[brandIndex]
+		brand.Count++
+		brand.TotalValue += vehicle.Price
+		brand.Buckets[bucketIndex].Count++
+		brand.Buckets[bucketIndex].TotalValue += vehicle.Price
+
+		listed = append(listed, entity.InventoryAgingVehicle{
+			Vehicle:    vehicle,
+			DaysListed: daysListed,
+		})
+	}
+
+	sort.Slice(report.Brands, func(i, j int) bool {
+		return report.Brands[i].TotalValue > report.Brands[j].TotalValue
+	})
+
+	sort.SliceStable(listed, func(i, j int) bool {
+		return listed[i].Vehicle.CreatedAt.Before(listed[j].Vehicle.CreatedAt)
+	})
+
+	if limit > 0 && len(listed) > limit {
+		listed = listed[:limit]
+	}
+
+	report.Oldest = listed
+
+	return report, nil
+}
+
+func (ref *vehicleService) Buy(ctx context.Context, vehicleID, userID string, tradeIn *entity.TradeIn) (_ *entity.Payment, err error) {
+	ctx, span := tracing.Start(ctx, "vehicleService.Buy", trace.WithAttributes(
+		attribute.String("vehicle.id", vehicleID),
+		attribute.Bool("trade_in", tradeIn != nil),
+	))
+	defer func() { span.End(err) }()
+
 	vehicle, err := ref.vehicleRepository.GetByID(ctx, vehicleID)
 	if err != nil {
 		return nil, err
 	}
 
-	if vehicle == nil {
-		return nil, errors.New("vehicle does not exist")
+	if vehicle == nil || vehicle.IsDeleted() {
+		return nil, entity.ErrVehicleNotFound
 	}
 
 	if vehicle.SoldAt != nil {
-		return nil, errors.New("vehicle already sold")
+		return nil, entity.ErrVehicleSold
 	}
 
-	soldTime := time.Now()
+	if vehicle.Status == entity.VehicleStatusDraft {
+		return nil, entity.ErrVehicleNotForSale
+	}
+
+	if vehicle.Status == entity.VehicleStatusReserved {
+		return nil, entity.ErrVehicleReserved
+	}
 
-	sale := entity.Sale{
+	payment := entity.Payment{
 		VehicleID: vehicleID,
 		UserID:    userID,
 		Price:     vehicle.Price,
-		SoldAt:    soldTime,
+		Amount:    vehicle.Price,
+		Status:    entity.PaymentStatusPending,
+		ExpiresAt: time.Now().Add(paymentExpiration),
+	}
+
+	if tradeIn != nil {
+		if tradeIn.Valuation <= 0 {
+			return nil, entity.ErrInvalidTradeInValuation
+		}
+
+		if tradeIn.Valuation > vehicle.Price {
+			return nil, entity.ErrTradeInExceedsPrice
+		}
+
+		payment.TradeIn = tradeIn
+		payment.Amount = vehicle.Price - tradeIn.Valuation
 	}
 
-	_, err = ref.saleRepository.Create(ctx, sale)
+	var (
+		reserved       *entity.Vehicle
+		createdPayment *entity.Payment
+	)
+
+	// The reservation is conditional on the version read above, so only one
+	// of concurrent purchases of the vehicle reserves it.
+	err = ref.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		reserved, err = ref.vehicleRepository.Update(ctx, vehicleID, entity.Vehicle{
+			Status:  entity.VehicleStatusReserved,
+			Version: vehicle.Version,
+		})
+		if err != nil {
+			return err
+		}
+
+		if reserved == nil {
+			return entity.ErrVehicleNotFound
+		}
+
+		createdPayment, err = ref.paymentRepository.Create(ctx, payment)
+		if err != nil {
+			return err
+		}
+
+		if err = ref.outboxService.Append(ctx, entity.EventVehicleUpdated, vehicleID, *reserved); err != nil {
+			return err
+		}
+
+		return ref.auditService.Record(ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionBuy, vehicle, reserved)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	soldVehicle := entity.Vehicle{
-		SoldAt: &soldTime,
+	intent, err := ref.paymentGateway.CreateIntent(ctx, *createdPayment)
+	if err != nil {
+		if releaseErr := ref.releaseVehicle(ctx, *reserved, createdPayment.ID); releaseErr != nil {
+			return nil, errors.Join(err, releaseErr)
+		}
+
+		return nil, err
 	}
 
-	return ref.vehicleRepository.Update(ctx, vehicleID, soldVehicle)
+	updatedPayment, err := ref.paymentRepository.Update(ctx, createdPayment.ID, entity.Payment{
+		IntentID:     intent.ID,
+		ClientSecret: intent.ClientSecret,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "vehicle reserved for purchase",
+		"user_id", userID,
+		"vehicle_id", vehicleID,
+		"payment_id", createdPayment.ID,
+		"amount", payment.Amount,
+	)
+
+	return updatedPayment, nil
+}
+
+// releaseVehicle undoes the reservation of a purchase whose payment could not
+// be started, unless the vehicle changed since.
+func (ref *vehicleService) releaseVehicle(ctx context.Context, reserved entity.Vehicle, paymentID string) error {
+	return ref.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		released, err := ref.vehicleRepository.Update(ctx, reserved.ID, entity.Vehicle{
+			Status:  entity.VehicleStatusAvailable,
+			Version: reserved.Version,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err = ref.paymentRepository.Update(ctx, paymentID, entity.Payment{Status: entity.PaymentStatusFailed}); err != nil {
+			return err
+		}
+
+		if released == nil {
+			return nil
+		}
+
+		if err = ref.outboxService.Append(ctx, entity.EventVehicleUpdated, released.ID, *released); err != nil {
+			return err
+		}
+
+		return ref.auditService.Record(ctx, entity.AuditEntityVehicle, released.ID, entity.AuditActionCancel, reserved, released)
+	})
+}
+
+func newAgingBuckets() []entity.InventoryAgingBucket {
+	buckets := make([]entity.InventoryAgingBucket, len(agingBuckets))
+	copy(buckets, agingBuckets)
+	return buckets
+}
+
+func agingBucketIndex(daysListed int) int {
+	for i, bucket := range agingBuckets {
+		if bucket.MaxDays == nil || daysListed <= *bucket.MaxDays {
+			return i
+		}
+	}
+
+	return len(agingBuckets) - 1
+}
+
+func intPointer(value int) *int {
+	return &value
 }
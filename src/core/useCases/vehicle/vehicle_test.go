@@ -15,6 +15,57 @@ import (
 
 func TestCreate(t *testing.T) {
 	ctx := context.TODO()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not create vehicle when failed to append event", func(t *testing.T) {
+		vehicle := entity.Vehicle{
+			Brand:  "Some Brand",
+			Status: entity.VehicleStatusAvailable,
+		}
+
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		vehicleRepositoryMocked.On("Create", ctx, vehicle).
+			Return(&vehicle, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleCreated, vehicle.ID, vehicle).
+			Return(unexpectedError)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Create(ctx, vehicle)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should not create vehicle when failed to record audit", func(t *testing.T) {
+		vehicle := entity.Vehicle{
+			Brand:  "Some Brand",
+			Status: entity.VehicleStatusAvailable,
+		}
+
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		vehicleRepositoryMocked.On("Create", ctx, vehicle).
+			Return(&vehicle, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleCreated, vehicle.ID, vehicle).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicle.ID, entity.AuditActionCreate, nil, &vehicle).
+			Return(unexpectedError)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Create(ctx, vehicle)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
 
 	t.Run("should create vehicle successfully", func(t *testing.T) {
 		vehicle := entity.Vehicle{
@@ -27,14 +78,56 @@ func TestCreate(t *testing.T) {
 
 		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
 
+		expected := vehicle
+		expected.Status = entity.VehicleStatusAvailable
+
+		vehicleRepositoryMocked.On("Create", ctx, expected).
+			Return(&expected, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleCreated, expected.ID, expected).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, expected.ID, entity.AuditActionCreate, nil, &expected).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Create(ctx, vehicle)
+
+		assert.NotNil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should keep vehicle status when provided", func(t *testing.T) {
+		vehicle := entity.Vehicle{
+			Brand:  "Some Brand",
+			Model:  "Some Model",
+			Year:   2025,
+			Color:  "Gray",
+			Price:  80000,
+			Status: entity.VehicleStatusDraft,
+		}
+
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
 		vehicleRepositoryMocked.On("Create", ctx, vehicle).
 			Return(&vehicle, nil)
 
-		service := NewVehicleService(vehicleRepositoryMocked, nil)
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleCreated, vehicle.ID, vehicle).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicle.ID, entity.AuditActionCreate, nil, &vehicle).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
 
 		actual, err := service.Create(ctx, vehicle)
 
-		assert.NotNil(t, actual)
+		assert.Equal(t, entity.VehicleStatusDraft, actual.Status)
 		assert.Nil(t, err)
 	})
 }
@@ -50,7 +143,7 @@ func TestGetByID(t *testing.T) {
 		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
 			Return(nil, unexpectedError)
 
-		service := NewVehicleService(vehicleRepositoryMocked, nil)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, nil, nil)
 
 		actual, err := service.GetByID(ctx, vehicleID)
 
@@ -77,7 +170,7 @@ func TestGetByID(t *testing.T) {
 		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
 			Return(vehicle, nil)
 
-		service := NewVehicleService(vehicleRepositoryMocked, nil)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, nil, nil)
 
 		actual, err := service.GetByID(ctx, vehicleID)
 
@@ -86,6 +179,118 @@ func TestGetByID(t *testing.T) {
 	})
 }
 
+func TestGetAsOf(t *testing.T) {
+	ctx := context.TODO()
+	vehicleID := primitive.NewObjectID().Hex()
+	asOf := time.Now().Add(-time.Hour)
+
+	t.Run("should get vehicle as it was at the time", func(t *testing.T) {
+		vehicleVersionRepositoryMocked := mocks.NewVehicleVersionRepository(t)
+
+		version := &entity.VehicleVersion{
+			Vehicle:    entity.Vehicle{ID: vehicleID, Price: 80000, Version: 2},
+			RecordedAt: asOf.Add(-time.Minute),
+		}
+
+		vehicleVersionRepositoryMocked.On("GetAsOf", ctx, vehicleID, asOf).
+			Return(version, nil)
+
+		service := NewVehicleService(nil, vehicleVersionRepositoryMocked, nil, nil, nil, nil, nil)
+
+		actual, err := service.GetAsOf(ctx, vehicleID, asOf)
+
+		assert.Equal(t, &version.Vehicle, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should get current vehicle without versions when it was not changed since", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		vehicleVersionRepositoryMocked := mocks.NewVehicleVersionRepository(t)
+
+		vehicle := &entity.Vehicle{ID: vehicleID, UpdatedAt: asOf.Add(-time.Minute)}
+
+		vehicleVersionRepositoryMocked.On("GetAsOf", ctx, vehicleID, asOf).
+			Return(nil, nil)
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(vehicle, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, vehicleVersionRepositoryMocked, nil, nil, nil, nil, nil)
+
+		actual, err := service.GetAsOf(ctx, vehicleID, asOf)
+
+		assert.Equal(t, vehicle, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should not get vehicle changed since without versions", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		vehicleVersionRepositoryMocked := mocks.NewVehicleVersionRepository(t)
+
+		vehicleVersionRepositoryMocked.On("GetAsOf", ctx, vehicleID, asOf).
+			Return(nil, nil)
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(&entity.Vehicle{ID: vehicleID, UpdatedAt: asOf.Add(time.Minute)}, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, vehicleVersionRepositoryMocked, nil, nil, nil, nil, nil)
+
+		actual, err := service.GetAsOf(ctx, vehicleID, asOf)
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestHistory(t *testing.T) {
+	ctx := context.TODO()
+	vehicleID := primitive.NewObjectID().Hex()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not get history when failed to search versions", func(t *testing.T) {
+		vehicleVersionRepositoryMocked := mocks.NewVehicleVersionRepository(t)
+
+		vehicleVersionRepositoryMocked.On("Search", ctx, vehicleID).
+			Return(nil, unexpectedError)
+
+		service := NewVehicleService(nil, vehicleVersionRepositoryMocked, nil, nil, nil, nil, nil)
+
+		actual, err := service.History(ctx, vehicleID)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should diff every version with the previous one", func(t *testing.T) {
+		vehicleVersionRepositoryMocked := mocks.NewVehicleVersionRepository(t)
+
+		versions := []entity.VehicleVersion{
+			{Vehicle: entity.Vehicle{Brand: "Ford", Price: 80000, Version: 1}},
+			{Vehicle: entity.Vehicle{Brand: "Ford", Price: 75000, Version: 2}},
+			{Vehicle: entity.Vehicle{Brand: "Ford", Color: "Preto", Price: 75000, Version: 3}},
+		}
+
+		vehicleVersionRepositoryMocked.On("Search", ctx, vehicleID).
+			Return(versions, nil)
+
+		service := NewVehicleService(nil, vehicleVersionRepositoryMocked, nil, nil, nil, nil, nil)
+
+		actual, err := service.History(ctx, vehicleID)
+
+		assert.Nil(t, err)
+		assert.Len(t, actual, 3)
+		assert.Equal(t, []entity.AuditChange{
+			{Field: "brand", Before: nil, After: "Ford"},
+			{Field: "price", Before: nil, After: 80000.0},
+		}, actual[0].Changes)
+		assert.Equal(t, []entity.AuditChange{
+			{Field: "price", Before: 80000.0, After: 75000.0},
+		}, actual[1].Changes)
+		assert.Equal(t, []entity.AuditChange{
+			{Field: "color", Before: nil, After: "Preto"},
+		}, actual[2].Changes)
+		assert.Equal(t, int64(3), actual[2].Vehicle.Version)
+	})
+}
+
 func TestSearch(t *testing.T) {
 	ctx := context.TODO()
 	unexpectedError := errors.New("unexpected error")
@@ -94,13 +299,14 @@ func TestSearch(t *testing.T) {
 		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
 
 		isSold := true
+		filter := entity.VehicleFilter{IsSold: &isSold}
 
-		vehicleRepositoryMocked.On("Search", ctx, &isSold).
+		vehicleRepositoryMocked.On("Search", ctx, filter).
 			Return(nil, unexpectedError)
 
-		service := NewVehicleService(vehicleRepositoryMocked, nil)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, nil, nil)
 
-		actual, err := service.Search(ctx, &isSold)
+		actual, err := service.Search(ctx, filter)
 
 		assert.Nil(t, actual)
 		assert.Equal(t, unexpectedError, err)
@@ -110,31 +316,79 @@ func TestSearch(t *testing.T) {
 		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
 
 		isSold := true
+		filter := entity.VehicleFilter{IsSold: &isSold}
 
-		vehicleRepositoryMocked.On("Search", ctx, &isSold).
+		vehicleRepositoryMocked.On("Search", ctx, filter).
 			Return([]entity.Vehicle{}, nil)
 
-		service := NewVehicleService(vehicleRepositoryMocked, nil)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, nil, nil)
 
-		actual, err := service.Search(ctx, &isSold)
+		actual, err := service.Search(ctx, filter)
 
 		assert.NotNil(t, actual)
 		assert.Nil(t, err)
 	})
 }
 
+func TestIterate(t *testing.T) {
+	ctx := context.TODO()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not iterate vehicles when failed to iterate", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		isSold := false
+		filter := entity.VehicleFilter{IsSold: &isSold}
+
+		vehicleRepositoryMocked.On("Iterate", ctx, filter, mock.Anything).
+			Return(unexpectedError)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, nil, nil)
+
+		err := service.Iterate(ctx, filter, func(entity.Vehicle) error { return nil })
+
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should iterate vehicles successfully", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		isSold := false
+		filter := entity.VehicleFilter{IsSold: &isSold}
+
+		vehicleRepositoryMocked.On("Iterate", ctx, filter, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(2).(func(entity.Vehicle) error)
+				_ = fn(entity.Vehicle{ID: "some-id"})
+			}).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, nil, nil)
+
+		var iterated []string
+
+		err := service.Iterate(ctx, filter, func(vehicle entity.Vehicle) error {
+			iterated = append(iterated, vehicle.ID)
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"some-id"}, iterated)
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	ctx := context.TODO()
 	vehicleID := primitive.NewObjectID().Hex()
 	unexpectedError := errors.New("unexpected error")
 
-	t.Run("should not update vehicle when failed to update", func(t *testing.T) {
+	t.Run("should not update vehicle when failed to get vehicle by id", func(t *testing.T) {
 		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
 
-		vehicleRepositoryMocked.On("Update", ctx, vehicleID, entity.Vehicle{}).
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
 			Return(nil, unexpectedError)
 
-		service := NewVehicleService(vehicleRepositoryMocked, nil)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, mocks.NewPassThroughTransactor(t), nil)
 
 		actual, err := service.Update(ctx, vehicleID, entity.Vehicle{})
 
@@ -142,64 +396,456 @@ func TestUpdate(t *testing.T) {
 		assert.Equal(t, unexpectedError, err)
 	})
 
-	t.Run("should update vehicle successfully", func(t *testing.T) {
+	t.Run("should not update vehicle when failed to update", func(t *testing.T) {
 		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
 
-		vehicleRepositoryMocked.On("Update", ctx, vehicleID, entity.Vehicle{}).
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
 			Return(&entity.Vehicle{}, nil)
+		vehicleRepositoryMocked.On("Replace", ctx, vehicleID, entity.Vehicle{}).
+			Return(nil, unexpectedError)
 
-		service := NewVehicleService(vehicleRepositoryMocked, nil)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, mocks.NewPassThroughTransactor(t), nil)
 
 		actual, err := service.Update(ctx, vehicleID, entity.Vehicle{})
 
-		assert.NotNil(t, actual)
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should not update deleted vehicle", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		deletedAt := time.Now()
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(&entity.Vehicle{DeletedAt: &deletedAt}, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, mocks.NewPassThroughTransactor(t), nil)
+
+		actual, err := service.Update(ctx, vehicleID, entity.Vehicle{Price: 75000})
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Replace", 0)
+	})
+
+	t.Run("should update vehicle successfully", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		before := &entity.Vehicle{Price: 80000}
+		after := &entity.Vehicle{Price: 75000}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(before, nil)
+		vehicleRepositoryMocked.On("Replace", ctx, vehicleID, entity.Vehicle{Price: 75000}).
+			Return(after, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleUpdated, vehicleID, *after).
+			Return(nil)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehiclePriceChanged, vehicleID, *after).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionUpdate, before, after).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Update(ctx, vehicleID, entity.Vehicle{Price: 75000})
+
+		assert.Equal(t, after, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should not announce price change when price is kept", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		before := &entity.Vehicle{Color: "Preto", Price: 80000}
+		after := &entity.Vehicle{Color: "Branco", Price: 80000}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(before, nil)
+		vehicleRepositoryMocked.On("Replace", ctx, vehicleID, entity.Vehicle{Color: "Branco"}).
+			Return(after, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleUpdated, vehicleID, *after).
+			Return(nil).
+			Once()
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionUpdate, before, after).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Update(ctx, vehicleID, entity.Vehicle{Color: "Branco"})
+
+		assert.Equal(t, after, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.TODO()
+	vehicleID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+	unexpectedError := errors.New("unexpected error")
+
+	t.Run("should not delete vehicle when failed to get vehicle by id", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(nil, unexpectedError)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, mocks.NewPassThroughTransactor(t), nil)
+
+		actual, err := service.Delete(ctx, vehicleID, userID)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should not delete vehicle that is already deleted", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		deletedAt := time.Now()
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(&entity.Vehicle{DeletedAt: &deletedAt}, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, mocks.NewPassThroughTransactor(t), nil)
+
+		actual, err := service.Delete(ctx, vehicleID, userID)
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("should not delete sold or reserved vehicle", func(t *testing.T) {
+		soldAt := time.Now()
+
+		vehicles := []entity.Vehicle{
+			{Status: entity.VehicleStatusSold, SoldAt: &soldAt},
+			{Status: entity.VehicleStatusReserved},
+		}
+
+		for _, vehicle := range vehicles {
+			vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+			vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+				Return(&vehicle, nil)
+
+			service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, mocks.NewPassThroughTransactor(t), nil)
+
+			actual, err := service.Delete(ctx, vehicleID, userID)
+
+			assert.Nil(t, actual)
+			assert.ErrorIs(t, err, entity.ErrVehicleNotDeletable)
+			vehicleRepositoryMocked.AssertNumberOfCalls(t, "Delete", 0)
+		}
+	})
+
+	t.Run("should not delete vehicle when failed to delete", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(&entity.Vehicle{Status: entity.VehicleStatusAvailable, Version: 2}, nil)
+		vehicleRepositoryMocked.On("Delete", ctx, vehicleID, int64(2), userID).
+			Return(nil, entity.ErrVehicleVersionMismatch)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, mocks.NewPassThroughTransactor(t), nil)
+
+		actual, err := service.Delete(ctx, vehicleID, userID)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, entity.ErrVehicleVersionMismatch)
+	})
+
+	t.Run("should delete vehicle successfully", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		deletedAt := time.Now()
+
+		before := &entity.Vehicle{Status: entity.VehicleStatusAvailable, Version: 2}
+		after := &entity.Vehicle{Status: entity.VehicleStatusAvailable, Version: 3, DeletedAt: &deletedAt, DeletedBy: userID}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(before, nil)
+		vehicleRepositoryMocked.On("Delete", ctx, vehicleID, int64(2), userID).
+			Return(after, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleDeleted, vehicleID, *after).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionDelete, before, after).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Delete(ctx, vehicleID, userID)
+
+		assert.Equal(t, after, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.TODO()
+	vehicleID := primitive.NewObjectID().Hex()
+
+	t.Run("should return vehicle that is not deleted as it is", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		vehicle := &entity.Vehicle{Status: entity.VehicleStatusAvailable}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(vehicle, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, mocks.NewPassThroughTransactor(t), nil)
+
+		actual, err := service.Restore(ctx, vehicleID)
+
+		assert.Equal(t, vehicle, actual)
+		assert.Nil(t, err)
+		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Restore", 0)
+	})
+
+	t.Run("should restore vehicle successfully", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		deletedAt := time.Now()
+
+		before := &entity.Vehicle{Version: 3, DeletedAt: &deletedAt, DeletedBy: "some-user"}
+		after := &entity.Vehicle{Version: 4}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(before, nil)
+		vehicleRepositoryMocked.On("Restore", ctx, vehicleID, int64(3)).
+			Return(after, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleRestored, vehicleID, *after).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionRestore, before, after).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Restore(ctx, vehicleID)
+
+		assert.Equal(t, after, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.TODO()
+	vehicleID := primitive.NewObjectID().Hex()
+
+	t.Run("should return vehicle that is not a draft as it is", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		vehicle := &entity.Vehicle{Status: entity.VehicleStatusReserved}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(vehicle, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, mocks.NewPassThroughTransactor(t), nil)
+
+		actual, err := service.Publish(ctx, vehicleID)
+
+		assert.Equal(t, vehicle, actual)
+		assert.Nil(t, err)
+		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("should publish draft vehicle successfully", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		before := &entity.Vehicle{Version: 2, Status: entity.VehicleStatusDraft}
+		after := &entity.Vehicle{Version: 3, Status: entity.VehicleStatusAvailable}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(before, nil)
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, entity.Vehicle{Status: entity.VehicleStatusAvailable, Version: 2}).
+			Return(after, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleUpdated, vehicleID, *after).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionUpdate, before, after).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Publish(ctx, vehicleID)
+
+		assert.Equal(t, after, actual)
 		assert.Nil(t, err)
 	})
 }
 
+func TestPurgeDeleted(t *testing.T) {
+	ctx := context.TODO()
+
+	vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+	vehicleRepositoryMocked.On("Purge", ctx, mock.MatchedBy(func(deletedBefore time.Time) bool {
+		return time.Since(deletedBefore) >= 30*24*time.Hour
+	})).
+		Return(2, nil)
+
+	service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, nil, nil)
+
+	purged, err := service.PurgeDeleted(ctx, 30*24*time.Hour)
+
+	assert.Equal(t, 2, purged)
+	assert.Nil(t, err)
+}
+
+func TestInventoryAging(t *testing.T) {
+	ctx := context.TODO()
+	unexpectedError := errors.New("unexpected error")
+	isSold := false
+
+	t.Run("should not build inventory aging when failed to search", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		vehicleRepositoryMocked.On("Search", ctx, entity.VehicleFilter{IsSold: &isSold}).
+			Return(nil, unexpectedError)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, nil, nil)
+
+		actual, err := service.InventoryAging(ctx, 10)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should build inventory aging successfully", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+
+		now := time.Now()
+		daysAgo := func(days int) time.Time {
+			return now.Add(-time.Duration(days)*24*time.Hour - time.Hour)
+		}
+
+		vehicles := []entity.Vehicle{
+			{ID: "1", Brand: "Ford", Price: 50000, CreatedAt: daysAgo(10)},
+			{ID: "2", Brand: "Ford", Price: 40000, CreatedAt: daysAgo(45)},
+			{ID: "3", Brand: "Chevrolet", Price: 60000, CreatedAt: daysAgo(75)},
+			{ID: "4", Brand: "Chevrolet", Price: 70000, CreatedAt: daysAgo(120)},
+			{ID: "5", Brand: "Fiat", Price: 30000, CreatedAt: daysAgo(200), SoldAt: &now},
+		}
+
+		vehicleRepositoryMocked.On("Search", ctx, entity.VehicleFilter{IsSold: &isSold}).
+			Return(vehicles, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, nil, nil, nil, nil, nil)
+
+		actual, err := service.InventoryAging(ctx, 2)
+
+		assert.Nil(t, err)
+
+		assert.Len(t, actual.Buckets, 4)
+		for i, label := range []string{"0-30", "31-60", "61-90", "90+"} {
+			assert.Equal(t, label, actual.Buckets[i].Label)
+			assert.Equal(t, 1, actual.Buckets[i].Count)
+		}
+		assert.Equal(t, float64(70000), actual.Buckets[3].TotalValue)
+
+		assert.Len(t, actual.Brands, 2)
+		assert.Equal(t, "Chevrolet", actual.Brands[0].Brand)
+		assert.Equal(t, 2, actual.Brands[0].Count)
+		assert.Equal(t, float64(130000), actual.Brands[0].TotalValue)
+		assert.Equal(t, "Ford", actual.Brands[1].Brand)
+		assert.Equal(t, float64(90000), actual.Brands[1].TotalValue)
+		assert.Equal(t, 1, actual.Brands[1].Buckets[0].Count)
+		assert.Equal(t, 1, actual.Brands[1].Buckets[1].Count)
+
+		assert.Len(t, actual.Oldest, 2)
+		assert.Equal(t, "4", actual.Oldest[0].Vehicle.ID)
+		assert.Equal(t, 120, actual.Oldest[0].DaysListed)
+		assert.Equal(t, "3", actual.Oldest[1].Vehicle.ID)
+	})
+}
+
 func TestBuy(t *testing.T) {
 	ctx := context.TODO()
 	vehicleID := primitive.NewObjectID().Hex()
 	userID := primitive.NewObjectID().Hex()
+	paymentID := primitive.NewObjectID().Hex()
 	unexpectedError := errors.New("unexpected error")
 
+	reserveVehicle := entity.Vehicle{Status: entity.VehicleStatusReserved, Version: 3}
+	releaseVehicle := entity.Vehicle{Status: entity.VehicleStatusAvailable, Version: 4}
+	reservedVehicle := &entity.Vehicle{ID: vehicleID, Price: 80000, Status: entity.VehicleStatusReserved, Version: 4}
+
 	t.Run("should not buy vehicle when failed to get vehicle by id", func(t *testing.T) {
 		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
-		saleRepositoryMocked := mocks.NewSaleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
 
 		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
 			Return(nil, unexpectedError)
 
-		service := NewVehicleService(vehicleRepositoryMocked, nil)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, nil, nil, nil, nil)
 
-		actual, err := service.Buy(ctx, vehicleID, userID)
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
 
 		assert.Nil(t, actual)
 		assert.Equal(t, unexpectedError, err)
 		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
-		saleRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
 	})
 
 	t.Run("should not buy vehicle when vehicle does not exist", func(t *testing.T) {
 		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
-		saleRepositoryMocked := mocks.NewSaleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
 
 		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
 			Return(nil, nil)
 
-		service := NewVehicleService(vehicleRepositoryMocked, nil)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, nil, nil, nil, nil)
 
-		actual, err := service.Buy(ctx, vehicleID, userID)
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
 
 		assert.Nil(t, actual)
-		assert.ErrorContains(t, err, "vehicle does not exist")
+		assert.ErrorIs(t, err, entity.ErrVehicleNotFound)
 		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
-		saleRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should not buy deleted vehicle", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+
+		deletedAt := time.Now()
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(&entity.Vehicle{Status: entity.VehicleStatusAvailable, DeletedAt: &deletedAt}, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, nil, nil, nil, nil)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, entity.ErrVehicleNotFound)
+		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
 	})
 
 	t.Run("should not buy vehicle when vehicle already sold", func(t *testing.T) {
 		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
-		saleRepositoryMocked := mocks.NewSaleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
 
 		now := time.Now()
 
@@ -210,54 +856,348 @@ func TestBuy(t *testing.T) {
 		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
 			Return(vehicleAlreadySold, nil)
 
-		service := NewVehicleService(vehicleRepositoryMocked, nil)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, nil, nil, nil, nil)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, entity.ErrVehicleSold)
+		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should not buy vehicle when vehicle is a draft", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+
+		draftVehicle := &entity.Vehicle{
+			Status: entity.VehicleStatusDraft,
+		}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(draftVehicle, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, nil, nil, nil, nil)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
 
-		actual, err := service.Buy(ctx, vehicleID, userID)
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, entity.ErrVehicleNotForSale)
+		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should not buy vehicle when vehicle is reserved", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+
+		reservedVehicle := &entity.Vehicle{
+			Status: entity.VehicleStatusReserved,
+		}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(reservedVehicle, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, nil, nil, nil, nil)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, entity.ErrVehicleReserved)
+		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should not buy vehicle when trade-in valuation is not positive", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+
+		vehicle := &entity.Vehicle{
+			Price: 80000,
+		}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(vehicle, nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, nil, nil, nil, nil)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, &entity.TradeIn{})
 
 		assert.Nil(t, actual)
-		assert.ErrorContains(t, err, "vehicle already sold")
+		assert.ErrorIs(t, err, entity.ErrInvalidTradeInValuation)
 		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
-		saleRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
 	})
 
-	t.Run("should not buy vehicle when failed to create sale", func(t *testing.T) {
+	t.Run("should not buy vehicle when trade-in valuation exceeds vehicle price", func(t *testing.T) {
 		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
-		saleRepositoryMocked := mocks.NewSaleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
 
-		vehicle := &entity.Vehicle{}
+		vehicle := &entity.Vehicle{
+			Price: 80000,
+		}
 
 		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
 			Return(vehicle, nil)
 
-		saleRepositoryMocked.On("Create", ctx, mock.AnythingOfType("entity.Sale")).
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, nil, nil, nil, nil)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, &entity.TradeIn{Valuation: 90000})
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, entity.ErrTradeInExceedsPrice)
+		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should not buy vehicle reserved by a concurrent purchase", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(&entity.Vehicle{Version: 3}, nil)
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, reserveVehicle).
+			Return(nil, entity.ErrVehicleVersionMismatch)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, nil, nil, mocks.NewPassThroughTransactor(t), nil)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, entity.ErrVehicleVersionMismatch)
+		paymentRepositoryMocked.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should not buy vehicle when failed to create payment", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+		outboxServiceMocked := mocks.NewOutboxService(t)
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(&entity.Vehicle{Version: 3}, nil)
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, reserveVehicle).
+			Return(reservedVehicle, nil)
+
+		paymentRepositoryMocked.On("Create", ctx, mock.AnythingOfType("entity.Payment")).
 			Return(nil, unexpectedError)
 
-		service := NewVehicleService(vehicleRepositoryMocked, saleRepositoryMocked)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, paymentGatewayMocked, nil, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
 
-		actual, err := service.Buy(ctx, vehicleID, userID)
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
 
 		assert.Nil(t, actual)
 		assert.Equal(t, unexpectedError, err)
-		vehicleRepositoryMocked.AssertNumberOfCalls(t, "Update", 0)
+		paymentGatewayMocked.AssertNumberOfCalls(t, "CreateIntent", 0)
+		outboxServiceMocked.AssertNumberOfCalls(t, "Append", 0)
+	})
+
+	t.Run("should not buy vehicle when failed to append event", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(&entity.Vehicle{Version: 3}, nil)
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, reserveVehicle).
+			Return(reservedVehicle, nil)
+
+		paymentRepositoryMocked.On("Create", ctx, mock.AnythingOfType("entity.Payment")).
+			Return(&entity.Payment{ID: paymentID}, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleUpdated, vehicleID, *reservedVehicle).
+			Return(unexpectedError)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, paymentGatewayMocked, nil, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+		paymentGatewayMocked.AssertNumberOfCalls(t, "CreateIntent", 0)
+	})
+
+	t.Run("should release vehicle and fail payment when failed to create payment intent", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		payment := &entity.Payment{ID: paymentID}
+		releasedVehicle := &entity.Vehicle{ID: vehicleID, Status: entity.VehicleStatusAvailable, Version: 5}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(&entity.Vehicle{Version: 3}, nil)
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, reserveVehicle).
+			Return(reservedVehicle, nil)
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, releaseVehicle).
+			Return(releasedVehicle, nil)
+
+		paymentRepositoryMocked.On("Create", ctx, mock.AnythingOfType("entity.Payment")).
+			Return(payment, nil)
+		paymentRepositoryMocked.On("Update", ctx, paymentID, entity.Payment{Status: entity.PaymentStatusFailed}).
+			Return(payment, nil)
+
+		paymentGatewayMocked.On("CreateIntent", ctx, *payment).
+			Return(nil, unexpectedError)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleUpdated, vehicleID, *reservedVehicle).
+			Return(nil)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleUpdated, vehicleID, *releasedVehicle).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionBuy, &entity.Vehicle{Version: 3}, reservedVehicle).
+			Return(nil)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionCancel, *reservedVehicle, releasedVehicle).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, paymentGatewayMocked, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should report failure to release vehicle with the payment intent error", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		payment := &entity.Payment{ID: paymentID}
+		releaseError := errors.New("release error")
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
+			Return(&entity.Vehicle{Version: 3}, nil)
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, reserveVehicle).
+			Return(reservedVehicle, nil)
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, releaseVehicle).
+			Return(nil, releaseError)
+
+		paymentRepositoryMocked.On("Create", ctx, mock.AnythingOfType("entity.Payment")).
+			Return(payment, nil)
+
+		paymentGatewayMocked.On("CreateIntent", ctx, *payment).
+			Return(nil, unexpectedError)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleUpdated, vehicleID, *reservedVehicle).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionBuy, &entity.Vehicle{Version: 3}, reservedVehicle).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, paymentGatewayMocked, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, unexpectedError)
+		assert.ErrorIs(t, err, releaseError)
 	})
 
 	t.Run("should buy vehicle successfully", func(t *testing.T) {
 		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
-		saleRepositoryMocked := mocks.NewSaleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
 
-		vehicle := &entity.Vehicle{}
+		vehicle := &entity.Vehicle{
+			Price:   80000,
+			Version: 3,
+		}
+
+		payment := &entity.Payment{ID: paymentID}
+
+		intent := &entity.PaymentIntent{
+			ID:           "pi_123",
+			ClientSecret: "secret_123",
+		}
 
 		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
 			Return(vehicle, nil)
-		vehicleRepositoryMocked.On("Update", ctx, vehicleID, mock.AnythingOfType("entity.Vehicle")).
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, reserveVehicle).
+			Return(reservedVehicle, nil)
+
+		paymentRepositoryMocked.On("Create", ctx, mock.MatchedBy(func(payment entity.Payment) bool {
+			return payment.VehicleID == vehicleID &&
+				payment.UserID == userID &&
+				payment.Price == 80000 &&
+				payment.Amount == 80000 &&
+				payment.Status == entity.PaymentStatusPending &&
+				payment.ExpiresAt.After(time.Now())
+		})).Return(payment, nil)
+		paymentRepositoryMocked.On("Update", ctx, paymentID, entity.Payment{IntentID: "pi_123", ClientSecret: "secret_123"}).
+			Return(payment, nil)
+
+		paymentGatewayMocked.On("CreateIntent", ctx, *payment).
+			Return(intent, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleUpdated, vehicleID, *reservedVehicle).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionBuy, vehicle, reservedVehicle).
+			Return(nil)
+
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, paymentGatewayMocked, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
+
+		actual, err := service.Buy(ctx, vehicleID, userID, nil)
+
+		assert.NotNil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should charge net amount when buying with trade-in", func(t *testing.T) {
+		vehicleRepositoryMocked := mocks.NewVehicleRepository(t)
+		paymentRepositoryMocked := mocks.NewPaymentRepository(t)
+		paymentGatewayMocked := mocks.NewPaymentGateway(t)
+
+		vehicle := &entity.Vehicle{
+			Price:   80000,
+			Version: 3,
+		}
+
+		tradeIn := &entity.TradeIn{
+			Brand:     "Other Brand",
+			Model:     "Other Model",
+			Year:      2015,
+			Color:     "Black",
+			Valuation: 30000,
+		}
+
+		payment := &entity.Payment{ID: paymentID}
+
+		vehicleRepositoryMocked.On("GetByID", ctx, vehicleID).
 			Return(vehicle, nil)
+		vehicleRepositoryMocked.On("Update", ctx, vehicleID, reserveVehicle).
+			Return(reservedVehicle, nil)
 
-		saleRepositoryMocked.On("Create", ctx, mock.AnythingOfType("entity.Sale")).
-			Return(nil, nil)
+		paymentRepositoryMocked.On("Create", ctx, mock.MatchedBy(func(payment entity.Payment) bool {
+			return payment.Price == 80000 &&
+				payment.Amount == 50000 &&
+				payment.TradeIn == tradeIn
+		})).Return(payment, nil)
+		paymentRepositoryMocked.On("Update", ctx, paymentID, mock.AnythingOfType("entity.Payment")).
+			Return(payment, nil)
+
+		paymentGatewayMocked.On("CreateIntent", ctx, *payment).
+			Return(&entity.PaymentIntent{ID: "pi_123"}, nil)
+
+		outboxServiceMocked := mocks.NewOutboxService(t)
+		outboxServiceMocked.On("Append", ctx, entity.EventVehicleUpdated, vehicleID, *reservedVehicle).
+			Return(nil)
+
+		auditServiceMocked := mocks.NewAuditService(t)
+		auditServiceMocked.On("Record", ctx, entity.AuditEntityVehicle, vehicleID, entity.AuditActionBuy, vehicle, reservedVehicle).
+			Return(nil)
 
-		service := NewVehicleService(vehicleRepositoryMocked, saleRepositoryMocked)
+		service := NewVehicleService(vehicleRepositoryMocked, nil, paymentRepositoryMocked, paymentGatewayMocked, auditServiceMocked, mocks.NewPassThroughTransactor(t), outboxServiceMocked)
 
-		actual, err := service.Buy(ctx, vehicleID, userID)
+		actual, err := service.Buy(ctx, vehicleID, userID, tradeIn)
 
 		assert.NotNil(t, actual)
 		assert.Nil(t, err)
@@ -0,0 +1,188 @@
+package vehicleImport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+const JobType = "vehicle_import"
+
+type importPayload struct {
+	ImportJobID string       `json:"import_job_id"`
+	Rows        []importItem `json:"rows"`
+}
+
+type importItem struct {
+	Row   int     `json:"row"`
+	Brand string  `json:"brand"`
+	Model string  `json:"model"`
+	Year  int     `json:"year"`
+	Color string  `json:"color"`
+	Price float64 `json:"price"`
+}
+
+type vehicleImportService struct {
+	importJobRepository interfaces.ImportJobRepository
+	jobService          interfaces.JobService
+	vehicleService      interfaces.VehicleService
+}
+
+func NewVehicleImportService(importJobRepository interfaces.ImportJobRepository, jobService interfaces.JobService, vehicleService interfaces.VehicleService) interfaces.VehicleImportService {
+	return &vehicleImportService{
+		importJobRepository: importJobRepository,
+		jobService:          jobService,
+		vehicleService:      vehicleService,
+	}
+}
+
+func (ref *vehicleImportService) Import(ctx context.Context, userID string, rows []entity.ImportRow, dryRun bool) (*entity.ImportJob, error) {
+	if len(rows) == 0 {
+		return nil, errors.New("import file has no rows")
+	}
+
+	importJob := entity.ImportJob{
+		UserID: userID,
+		DryRun: dryRun,
+		Status: entity.ImportJobStatusPending,
+		Total:  len(rows),
+		Errors: []entity.ImportRowError{},
+	}
+
+	items := make([]importItem, 0, len(rows))
+
+	for _, row := range rows {
+		if row.Vehicle == nil {
+			importJob.Errors = append(importJob.Errors, entity.ImportRowError{
+				Row:   row.Row,
+				Error: row.Error,
+			})
+			continue
+		}
+
+		items = append(items, importItem{
+			Row:   row.Row,
+			Brand: row.Vehicle.Brand,
+			Model: row.Vehicle.Model,
+			Year:  row.Vehicle.Year,
+			Color: row.Vehicle.Color,
+			Price: row.Vehicle.Price,
+		})
+	}
+
+	importJob.Valid = len(items)
+
+	created, err := ref.importJobRepository.Create(ctx, importJob)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := ref.jobService.Enqueue(ctx, JobType, userID, importPayload{
+		ImportJobID: created.ID,
+		Rows:        items,
+	})
+	if err != nil {
+		now := time.Now()
+		created.Status = entity.ImportJobStatusFailed
+		created.FinishedAt = &now
+
+		if _, updateErr := ref.importJobRepository.Update(ctx, created.ID, *created); updateErr != nil {
+			return nil, updateErr
+		}
+
+		return nil, err
+	}
+
+	created.JobID = job.ID
+
+	return ref.importJobRepository.Update(ctx, created.ID, *created)
+}
+
+// GetByID reports imports of other users as missing.
+func (ref *vehicleImportService) GetByID(ctx context.Context, id, userID string) (*entity.ImportJob, error) {
+	importJob, err := ref.importJobRepository.GetByID(ctx, id)
+	if err != nil || importJob == nil {
+		return nil, err
+	}
+
+	if importJob.UserID != userID {
+		return nil, nil
+	}
+
+	return importJob, nil
+}
+
+// Process runs a vehicle import job. Progress is saved after every row, so a
+// retried job resumes after the last processed row.
+func (ref *vehicleImportService) Process(ctx context.Context, job entity.Job) error {
+	var payload importPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return err
+	}
+
+	importJob, err := ref.importJobRepository.GetByID(ctx, payload.ImportJobID)
+	if err != nil {
+		return err
+	}
+
+	if importJob == nil {
+		return errors.New("import job does not exist")
+	}
+
+	importJob.Status = entity.ImportJobStatusRunning
+
+	if _, err = ref.importJobRepository.Update(ctx, importJob.ID, *importJob); err != nil {
+		return err
+	}
+
+	for _, item := range payload.Rows[min(importJob.Processed, len(payload.Rows)):] {
+		if importJob.DryRun {
+			importJob.Processed++
+			continue
+		}
+
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		vehicle := entity.Vehicle{
+			Brand:    item.Brand,
+			Model:    item.Model,
+			Year:     item.Year,
+			Color:    item.Color,
+			Price:    item.Price,
+			SellerID: importJob.UserID,
+		}
+
+		if _, err = ref.vehicleService.Create(ctx, vehicle); err != nil {
+			importJob.Errors = append(importJob.Errors, entity.ImportRowError{
+				Row:   item.Row,
+				Error: err.Error(),
+			})
+		} else {
+			importJob.Imported++
+		}
+
+		importJob.Processed++
+
+		if _, err = ref.importJobRepository.Update(ctx, importJob.ID, *importJob); err != nil {
+			return err
+		}
+	}
+
+	sort.SliceStable(importJob.Errors, func(i, j int) bool {
+		return importJob.Errors[i].Row < importJob.Errors[j].Row
+	})
+
+	now := time.Now()
+	importJob.Status = entity.ImportJobStatusCompleted
+	importJob.FinishedAt = &now
+
+	_, err = ref.importJobRepository.Update(ctx, importJob.ID, *importJob)
+	return err
+}
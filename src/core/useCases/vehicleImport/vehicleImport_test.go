@@ -0,0 +1,307 @@
+package vehicleImport
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mocks "github.com/caiiomp/vehicle-resale-api/src/core/_mocks"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestImport(t *testing.T) {
+	ctx := context.TODO()
+	userID := primitive.NewObjectID().Hex()
+	importJobID := primitive.NewObjectID().Hex()
+	jobID := primitive.NewObjectID().Hex()
+	unexpectedError := errors.New("unexpected error")
+
+	rows := []entity.ImportRow{
+		{
+			Row: 1,
+			Vehicle: &entity.Vehicle{
+				Brand: "Some Brand",
+				Model: "Some Model",
+				Year:  2025,
+				Color: "Gray",
+				Price: 80000,
+			},
+		},
+		{Row: 2, Error: "invalid year"},
+	}
+
+	expectedImportJob := entity.ImportJob{
+		UserID: userID,
+		Status: entity.ImportJobStatusPending,
+		Total:  2,
+		Valid:  1,
+		Errors: []entity.ImportRowError{
+			{Row: 2, Error: "invalid year"},
+		},
+	}
+
+	expectedPayload := importPayload{
+		ImportJobID: importJobID,
+		Rows: []importItem{
+			{Row: 1, Brand: "Some Brand", Model: "Some Model", Year: 2025, Color: "Gray", Price: 80000},
+		},
+	}
+
+	t.Run("should not import empty file", func(t *testing.T) {
+		service := NewVehicleImportService(nil, nil, nil)
+
+		actual, err := service.Import(ctx, userID, []entity.ImportRow{}, false)
+
+		assert.Nil(t, actual)
+		assert.EqualError(t, err, "import file has no rows")
+	})
+
+	t.Run("should not import when failed to create import job", func(t *testing.T) {
+		importJobRepositoryMocked := mocks.NewImportJobRepository(t)
+
+		importJobRepositoryMocked.On("Create", ctx, expectedImportJob).
+			Return(nil, unexpectedError)
+
+		service := NewVehicleImportService(importJobRepositoryMocked, nil, nil)
+
+		actual, err := service.Import(ctx, userID, rows, false)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should mark import job as failed when failed to enqueue", func(t *testing.T) {
+		importJobRepositoryMocked := mocks.NewImportJobRepository(t)
+		jobServiceMocked := mocks.NewJobService(t)
+
+		createdImportJob := expectedImportJob
+		createdImportJob.ID = importJobID
+
+		importJobRepositoryMocked.On("Create", ctx, expectedImportJob).
+			Return(&createdImportJob, nil)
+
+		jobServiceMocked.On("Enqueue", ctx, JobType, userID, expectedPayload).
+			Return(nil, unexpectedError)
+
+		importJobRepositoryMocked.On("Update", ctx, importJobID, mock.MatchedBy(func(importJob entity.ImportJob) bool {
+			return importJob.Status == entity.ImportJobStatusFailed && importJob.FinishedAt != nil
+		})).
+			Return(&createdImportJob, nil)
+
+		service := NewVehicleImportService(importJobRepositoryMocked, jobServiceMocked, nil)
+
+		actual, err := service.Import(ctx, userID, rows, false)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should enqueue import job successfully", func(t *testing.T) {
+		importJobRepositoryMocked := mocks.NewImportJobRepository(t)
+		jobServiceMocked := mocks.NewJobService(t)
+
+		createdImportJob := expectedImportJob
+		createdImportJob.ID = importJobID
+
+		importJobRepositoryMocked.On("Create", ctx, expectedImportJob).
+			Return(&createdImportJob, nil)
+
+		jobServiceMocked.On("Enqueue", ctx, JobType, userID, expectedPayload).
+			Return(&entity.Job{ID: jobID}, nil)
+
+		updatedImportJob := createdImportJob
+		updatedImportJob.JobID = jobID
+
+		importJobRepositoryMocked.On("Update", ctx, importJobID, updatedImportJob).
+			Return(&updatedImportJob, nil)
+
+		service := NewVehicleImportService(importJobRepositoryMocked, jobServiceMocked, nil)
+
+		actual, err := service.Import(ctx, userID, rows, false)
+
+		assert.Equal(t, jobID, actual.JobID)
+		assert.Nil(t, err)
+	})
+}
+
+func TestProcess(t *testing.T) {
+	ctx := context.TODO()
+	userID := primitive.NewObjectID().Hex()
+	importJobID := primitive.NewObjectID().Hex()
+	unexpectedError := errors.New("unexpected error")
+
+	job := entity.Job{
+		Type:    JobType,
+		Payload: []byte(`{"import_job_id":"` + importJobID + `","rows":[{"row":1,"brand":"Some Brand"},{"row":3,"brand":"Other Brand"}]}`),
+	}
+
+	t.Run("should not process job with invalid payload", func(t *testing.T) {
+		service := NewVehicleImportService(nil, nil, nil)
+
+		err := service.Process(ctx, entity.Job{Payload: []byte("invalid")})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should not process import job that does not exist", func(t *testing.T) {
+		importJobRepositoryMocked := mocks.NewImportJobRepository(t)
+
+		importJobRepositoryMocked.On("GetByID", ctx, importJobID).
+			Return(nil, nil)
+
+		service := NewVehicleImportService(importJobRepositoryMocked, nil, nil)
+
+		err := service.Process(ctx, job)
+
+		assert.EqualError(t, err, "import job does not exist")
+	})
+
+	t.Run("should not create vehicles on dry run", func(t *testing.T) {
+		importJobRepositoryMocked := mocks.NewImportJobRepository(t)
+
+		importJobRepositoryMocked.On("GetByID", ctx, importJobID).
+			Return(&entity.ImportJob{ID: importJobID, UserID: userID, DryRun: true}, nil)
+
+		var updated entity.ImportJob
+
+		importJobRepositoryMocked.On("Update", ctx, importJobID, mock.Anything).
+			Run(func(args mock.Arguments) {
+				updated = args.Get(2).(entity.ImportJob)
+			}).
+			Return(nil, nil)
+
+		service := NewVehicleImportService(importJobRepositoryMocked, nil, nil)
+
+		err := service.Process(ctx, job)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.ImportJobStatusCompleted, updated.Status)
+		assert.Equal(t, 2, updated.Processed)
+		assert.Equal(t, 0, updated.Imported)
+		assert.NotNil(t, updated.FinishedAt)
+	})
+
+	t.Run("should report rows that failed to be created", func(t *testing.T) {
+		importJobRepositoryMocked := mocks.NewImportJobRepository(t)
+		vehicleServiceMocked := mocks.NewVehicleService(t)
+
+		importJobRepositoryMocked.On("GetByID", ctx, importJobID).
+			Return(&entity.ImportJob{
+				ID:     importJobID,
+				UserID: userID,
+				Errors: []entity.ImportRowError{
+					{Row: 2, Error: "invalid year"},
+				},
+			}, nil)
+
+		var updated entity.ImportJob
+
+		importJobRepositoryMocked.On("Update", ctx, importJobID, mock.Anything).
+			Run(func(args mock.Arguments) {
+				updated = args.Get(2).(entity.ImportJob)
+			}).
+			Return(nil, nil)
+
+		vehicleServiceMocked.On("Create", ctx, entity.Vehicle{Brand: "Some Brand", SellerID: userID}).
+			Return(nil, unexpectedError)
+
+		vehicleServiceMocked.On("Create", ctx, entity.Vehicle{Brand: "Other Brand", SellerID: userID}).
+			Return(&entity.Vehicle{}, nil)
+
+		service := NewVehicleImportService(importJobRepositoryMocked, nil, vehicleServiceMocked)
+
+		err := service.Process(ctx, job)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.ImportJobStatusCompleted, updated.Status)
+		assert.Equal(t, 2, updated.Processed)
+		assert.Equal(t, 1, updated.Imported)
+		assert.Equal(t, []entity.ImportRowError{
+			{Row: 1, Error: "unexpected error"},
+			{Row: 2, Error: "invalid year"},
+		}, updated.Errors)
+	})
+
+	t.Run("should resume after the last processed row", func(t *testing.T) {
+		importJobRepositoryMocked := mocks.NewImportJobRepository(t)
+		vehicleServiceMocked := mocks.NewVehicleService(t)
+
+		importJobRepositoryMocked.On("GetByID", ctx, importJobID).
+			Return(&entity.ImportJob{ID: importJobID, UserID: userID, Processed: 1, Imported: 1}, nil)
+
+		var updated entity.ImportJob
+
+		importJobRepositoryMocked.On("Update", ctx, importJobID, mock.Anything).
+			Run(func(args mock.Arguments) {
+				updated = args.Get(2).(entity.ImportJob)
+			}).
+			Return(nil, nil)
+
+		vehicleServiceMocked.On("Create", ctx, entity.Vehicle{Brand: "Other Brand", SellerID: userID}).
+			Return(&entity.Vehicle{}, nil)
+
+		service := NewVehicleImportService(importJobRepositoryMocked, nil, vehicleServiceMocked)
+
+		err := service.Process(ctx, job)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, updated.Processed)
+		assert.Equal(t, 2, updated.Imported)
+	})
+
+	t.Run("should stop when context is canceled", func(t *testing.T) {
+		importJobRepositoryMocked := mocks.NewImportJobRepository(t)
+
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		importJobRepositoryMocked.On("GetByID", canceledCtx, importJobID).
+			Return(&entity.ImportJob{ID: importJobID, UserID: userID}, nil)
+
+		importJobRepositoryMocked.On("Update", canceledCtx, importJobID, mock.Anything).
+			Return(nil, nil)
+
+		service := NewVehicleImportService(importJobRepositoryMocked, nil, nil)
+
+		err := service.Process(canceledCtx, job)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	ctx := context.TODO()
+	importJobID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+
+	t.Run("should not get import job of another user", func(t *testing.T) {
+		importJobRepositoryMocked := mocks.NewImportJobRepository(t)
+
+		importJobRepositoryMocked.On("GetByID", ctx, importJobID).
+			Return(&entity.ImportJob{ID: importJobID, UserID: primitive.NewObjectID().Hex()}, nil)
+
+		service := NewVehicleImportService(importJobRepositoryMocked, nil, nil)
+
+		actual, err := service.GetByID(ctx, importJobID, userID)
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should get import job successfully", func(t *testing.T) {
+		importJobRepositoryMocked := mocks.NewImportJobRepository(t)
+
+		importJobRepositoryMocked.On("GetByID", ctx, importJobID).
+			Return(&entity.ImportJob{ID: importJobID, UserID: userID}, nil)
+
+		service := NewVehicleImportService(importJobRepositoryMocked, nil, nil)
+
+		actual, err := service.GetByID(ctx, importJobID, userID)
+
+		assert.Equal(t, importJobID, actual.ID)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,188 @@
+package vehicleStream
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"sync"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+)
+
+type subscriber struct {
+	isSold *bool
+	events chan entity.VehicleStreamEvent
+}
+
+func (ref *subscriber) matches(event entity.VehicleStreamEvent) bool {
+	return ref.isSold == nil || *ref.isSold == event.Sold
+}
+
+// vehicleStreamService keeps the last bufferSize events in memory. Events
+// relayed again by the outbox are recognized by their ID while buffered and
+// dropped.
+type vehicleStreamService struct {
+	mutex            sync.Mutex
+	bufferSize       int
+	subscriberBuffer int
+	events           []entity.VehicleStreamEvent
+	buffered         map[string]struct{}
+	lastID           uint64
+	subscribers      map[*subscriber]struct{}
+	closed           bool
+}
+
+func NewVehicleStreamService(bufferSize, subscriberBuffer int) interfaces.VehicleStreamService {
+	return &vehicleStreamService{
+		bufferSize:       bufferSize,
+		subscriberBuffer: subscriberBuffer,
+		events:           make([]entity.VehicleStreamEvent, 0, bufferSize),
+		buffered:         map[string]struct{}{},
+		subscribers:      map[*subscriber]struct{}{},
+	}
+}
+
+// Publish never fails, so that the outbox relay is not held back by the
+// stream: events that cannot be decoded are logged and skipped, and
+// subscribers whose buffer is full are dropped.
+func (ref *vehicleStreamService) Publish(ctx context.Context, event entity.Event) error {
+	switch event.Type {
+	case entity.EventVehicleCreated, entity.EventVehiclePriceChanged, entity.EventVehicleSold,
+		entity.EventVehicleDeleted, entity.EventVehicleRestored:
+	default:
+		return nil
+	}
+
+	var vehicle responses.Vehicle
+	if err := json.Unmarshal(event.Payload, &vehicle); err != nil {
+		slog.WarnContext(ctx, "could not decode vehicle event for stream", "event_id", event.ID, "error", err)
+		return nil
+	}
+
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	if _, ok := ref.buffered[event.ID]; ok {
+		return nil
+	}
+
+	ref.lastID++
+
+	streamEvent := entity.VehicleStreamEvent{
+		ID:        ref.lastID,
+		EventID:   event.ID,
+		Type:      event.Type,
+		VehicleID: event.EntityID,
+		Sold:      vehicle.SoldAt != nil,
+		Data:      event.Payload,
+	}
+
+	if len(ref.events) == ref.bufferSize {
+		delete(ref.buffered, ref.events[0].EventID)
+		ref.events = append(ref.events[:0], ref.events[1:]...)
+	}
+
+	ref.events = append(ref.events, streamEvent)
+	ref.buffered[event.ID] = struct{}{}
+
+	for subscriber := range ref.subscribers {
+		if !subscriber.matches(streamEvent) {
+			continue
+		}
+
+		select {
+		case subscriber.events <- streamEvent:
+		default:
+			delete(ref.subscribers, subscriber)
+			close(subscriber.events)
+		}
+	}
+
+	return nil
+}
+
+func (ref *vehicleStreamService) Subscribe(lastEventID string, isSold *bool) *entity.VehicleStreamSubscription {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	subscriber := &subscriber{
+		isSold: isSold,
+		events: make(chan entity.VehicleStreamEvent, ref.subscriberBuffer),
+	}
+
+	subscription := &entity.VehicleStreamSubscription{
+		Events: subscriber.events,
+	}
+
+	if lastEventID != "" {
+		subscription.Replay, subscription.Reset = ref.replay(lastEventID, subscriber)
+	}
+
+	if ref.closed {
+		close(subscriber.events)
+		subscription.Cancel = func() {}
+		return subscription
+	}
+
+	ref.subscribers[subscriber] = struct{}{}
+
+	var once sync.Once
+
+	subscription.Cancel = func() {
+		once.Do(func() {
+			ref.mutex.Lock()
+			defer ref.mutex.Unlock()
+
+			if _, ok := ref.subscribers[subscriber]; ok {
+				delete(ref.subscribers, subscriber)
+				close(subscriber.events)
+			}
+		})
+	}
+
+	return subscription
+}
+
+func (ref *vehicleStreamService) Close() {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	ref.closed = true
+
+	for subscriber := range ref.subscribers {
+		delete(ref.subscribers, subscriber)
+		close(subscriber.events)
+	}
+}
+
+// replay returns the buffered events after lastEventID, or reports a reset
+// when some of them were already evicted or the ID was not issued by this
+// process.
+func (ref *vehicleStreamService) replay(lastEventID string, subscriber *subscriber) ([]entity.VehicleStreamEvent, bool) {
+	id, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil || id > ref.lastID {
+		return nil, true
+	}
+
+	firstID := ref.lastID + 1
+	if len(ref.events) > 0 {
+		firstID = ref.events[0].ID
+	}
+
+	if id+1 < firstID {
+		return nil, true
+	}
+
+	events := make([]entity.VehicleStreamEvent, 0)
+
+	for _, event := range ref.events {
+		if event.ID > id && subscriber.matches(event) {
+			events = append(events, event)
+		}
+	}
+
+	return events, false
+}
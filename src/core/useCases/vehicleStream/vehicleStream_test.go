@@ -0,0 +1,201 @@
+package vehicleStream
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEvent(id, eventType string, sold bool) entity.Event {
+	payload := `{"id":"vehicle-` + id + `"}`
+	if sold {
+		payload = `{"id":"vehicle-` + id + `","sold_at":"2025-01-01T00:00:00Z"}`
+	}
+
+	return entity.Event{
+		ID:       "event-" + id,
+		Type:     eventType,
+		EntityID: "vehicle-" + id,
+		Payload:  []byte(payload),
+	}
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should stream vehicle events only", func(t *testing.T) {
+		service := NewVehicleStreamService(10, 10)
+
+		subscription := service.Subscribe("", nil)
+		defer subscription.Cancel()
+
+		require.NoError(t, service.Publish(ctx, newEvent("1", entity.EventVehicleUpdated, false)))
+		require.NoError(t, service.Publish(ctx, newEvent("2", entity.EventSaleCreated, false)))
+		require.NoError(t, service.Publish(ctx, newEvent("3", entity.EventVehicleCreated, false)))
+
+		event := <-subscription.Events
+		assert.Equal(t, uint64(1), event.ID)
+		assert.Equal(t, "event-3", event.EventID)
+		assert.Equal(t, entity.EventVehicleCreated, event.Type)
+		assert.Equal(t, "vehicle-3", event.VehicleID)
+		assert.Equal(t, `{"id":"vehicle-3"}`, string(event.Data))
+		assert.Empty(t, subscription.Events)
+	})
+
+	t.Run("should stream deleted and restored vehicles", func(t *testing.T) {
+		service := NewVehicleStreamService(10, 10)
+
+		subscription := service.Subscribe("", nil)
+		defer subscription.Cancel()
+
+		require.NoError(t, service.Publish(ctx, newEvent("1", entity.EventVehicleDeleted, false)))
+		require.NoError(t, service.Publish(ctx, newEvent("2", entity.EventVehicleRestored, false)))
+
+		assert.Equal(t, entity.EventVehicleDeleted, (<-subscription.Events).Type)
+		assert.Equal(t, entity.EventVehicleRestored, (<-subscription.Events).Type)
+	})
+
+	t.Run("should drop events relayed again", func(t *testing.T) {
+		service := NewVehicleStreamService(10, 10)
+
+		subscription := service.Subscribe("", nil)
+		defer subscription.Cancel()
+
+		require.NoError(t, service.Publish(ctx, newEvent("1", entity.EventVehicleCreated, false)))
+		require.NoError(t, service.Publish(ctx, newEvent("1", entity.EventVehicleCreated, false)))
+
+		<-subscription.Events
+		assert.Empty(t, subscription.Events)
+	})
+
+	t.Run("should filter events by sold status", func(t *testing.T) {
+		service := NewVehicleStreamService(10, 10)
+
+		isSold := true
+
+		subscription := service.Subscribe("", &isSold)
+		defer subscription.Cancel()
+
+		require.NoError(t, service.Publish(ctx, newEvent("1", entity.EventVehicleCreated, false)))
+		require.NoError(t, service.Publish(ctx, newEvent("2", entity.EventVehicleSold, true)))
+
+		event := <-subscription.Events
+		assert.Equal(t, entity.EventVehicleSold, event.Type)
+		assert.True(t, event.Sold)
+		assert.Empty(t, subscription.Events)
+	})
+
+	t.Run("should drop subscriber that falls behind without blocking", func(t *testing.T) {
+		service := NewVehicleStreamService(10, 1)
+
+		slow := service.Subscribe("", nil)
+		defer slow.Cancel()
+
+		fast := service.Subscribe("", nil)
+		defer fast.Cancel()
+
+		require.NoError(t, service.Publish(ctx, newEvent("1", entity.EventVehicleCreated, false)))
+		<-fast.Events
+
+		require.NoError(t, service.Publish(ctx, newEvent("2", entity.EventVehicleCreated, false)))
+		<-fast.Events
+
+		event, ok := <-slow.Events
+		assert.True(t, ok)
+		assert.Equal(t, uint64(1), event.ID)
+
+		_, ok = <-slow.Events
+		assert.False(t, ok, "slow subscriber must be closed")
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx := context.TODO()
+
+	service := NewVehicleStreamService(3, 10)
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, service.Publish(ctx, newEvent(strconv.Itoa(i), entity.EventVehicleCreated, i == 5)))
+	}
+
+	t.Run("should replay buffered events after last event id", func(t *testing.T) {
+		subscription := service.Subscribe("3", nil)
+		defer subscription.Cancel()
+
+		assert.False(t, subscription.Reset)
+		require.Len(t, subscription.Replay, 2)
+		assert.Equal(t, uint64(4), subscription.Replay[0].ID)
+		assert.Equal(t, uint64(5), subscription.Replay[1].ID)
+	})
+
+	t.Run("should replay matching events only", func(t *testing.T) {
+		isSold := false
+
+		subscription := service.Subscribe("2", &isSold)
+		defer subscription.Cancel()
+
+		assert.False(t, subscription.Reset)
+		require.Len(t, subscription.Replay, 2)
+		assert.Equal(t, uint64(3), subscription.Replay[0].ID)
+		assert.Equal(t, uint64(4), subscription.Replay[1].ID)
+	})
+
+	t.Run("should replay nothing when up to date", func(t *testing.T) {
+		subscription := service.Subscribe("5", nil)
+		defer subscription.Cancel()
+
+		assert.False(t, subscription.Reset)
+		assert.Empty(t, subscription.Replay)
+	})
+
+	t.Run("should reset when events were evicted", func(t *testing.T) {
+		subscription := service.Subscribe("1", nil)
+		defer subscription.Cancel()
+
+		assert.True(t, subscription.Reset)
+		assert.Empty(t, subscription.Replay)
+	})
+
+	t.Run("should reset on unknown event id", func(t *testing.T) {
+		for _, lastEventID := range []string{"6", "abc"} {
+			subscription := service.Subscribe(lastEventID, nil)
+			subscription.Cancel()
+
+			assert.True(t, subscription.Reset)
+		}
+	})
+
+	t.Run("should close events on cancel", func(t *testing.T) {
+		subscription := service.Subscribe("", nil)
+		subscription.Cancel()
+		subscription.Cancel()
+
+		_, ok := <-subscription.Events
+		assert.False(t, ok)
+	})
+}
+
+func TestClose(t *testing.T) {
+	service := NewVehicleStreamService(10, 10)
+
+	subscription := service.Subscribe("", nil)
+
+	service.Close()
+
+	_, ok := <-subscription.Events
+	assert.False(t, ok)
+
+	subscription.Cancel()
+
+	t.Run("should close events of subscriptions made after close", func(t *testing.T) {
+		subscription := service.Subscribe("", nil)
+		subscription.Cancel()
+
+		_, ok := <-subscription.Events
+		assert.False(t, ok)
+	})
+}
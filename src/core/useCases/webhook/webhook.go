@@ -0,0 +1,275 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/metrics"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher"
+	"github.com/caiiomp/vehicle-resale-api/src/tracing"
+)
+
+const (
+	// lockTimeout bounds how long a claimed delivery may take to be sent
+	// before another worker claims it again.
+	lockTimeout  = time.Minute
+	retryBackoff = time.Second
+	maxBackoff   = time.Hour
+)
+
+type webhookService struct {
+	subscriptionRepository interfaces.WebhookSubscriptionRepository
+	deliveryRepository     interfaces.WebhookDeliveryRepository
+	webhookSender          interfaces.WebhookSender
+	maxAttempts            int
+	batchSize              int
+}
+
+func NewWebhookService(
+	subscriptionRepository interfaces.WebhookSubscriptionRepository,
+	deliveryRepository interfaces.WebhookDeliveryRepository,
+	webhookSender interfaces.WebhookSender,
+	maxAttempts int,
+	batchSize int,
+) interfaces.WebhookService {
+	return &webhookService{
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository,
+		webhookSender:          webhookSender,
+		maxAttempts:            maxAttempts,
+		batchSize:              batchSize,
+	}
+}
+
+func (ref *webhookService) CreateSubscription(ctx context.Context, subscription entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Secret = secret
+
+	return ref.subscriptionRepository.Create(ctx, subscription)
+}
+
+func (ref *webhookService) GetSubscription(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	return ref.subscriptionRepository.GetByID(ctx, id)
+}
+
+func (ref *webhookService) SearchSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	return ref.subscriptionRepository.Search(ctx)
+}
+
+// DeleteSubscription stops new deliveries to the subscription. Pending
+// deliveries are dead-lettered when they come up, and the delivery log is
+// kept.
+func (ref *webhookService) DeleteSubscription(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	subscription, err := ref.subscriptionRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription == nil {
+		return nil, nil
+	}
+
+	if err = ref.subscriptionRepository.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (ref *webhookService) SearchDeliveries(ctx context.Context, subscriptionID string) ([]entity.WebhookDelivery, error) {
+	return ref.deliveryRepository.SearchBySubscriptionID(ctx, subscriptionID)
+}
+
+// Replay sends a delivered or dead-lettered delivery again, with a fresh
+// budget of attempts.
+func (ref *webhookService) Replay(ctx context.Context, subscriptionID, deliveryID string) (*entity.WebhookDelivery, error) {
+	delivery, err := ref.deliveryRepository.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery == nil || delivery.SubscriptionID != subscriptionID {
+		return nil, nil
+	}
+
+	if delivery.Status == entity.WebhookDeliveryStatusPending {
+		return nil, entity.ErrWebhookDeliveryPending
+	}
+
+	delivery.Status = entity.WebhookDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.DeliveredAt = nil
+
+	return ref.deliveryRepository.Update(ctx, deliveryID, *delivery)
+}
+
+func (ref *webhookService) Publish(ctx context.Context, event entity.Event) error {
+	subscriptions, err := ref.subscriptionRepository.SearchByEventType(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := publisher.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, subscription := range subscriptions {
+		delivery := entity.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         entity.WebhookDeliveryStatusPending,
+			NextAttemptAt:  now,
+		}
+
+		if err = ref.deliveryRepository.Enqueue(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Deliver sends the deliveries claimed in one batch and returns how many
+// were delivered. Failed deliveries are retried with exponential backoff
+// until they run out of attempts and are dead-lettered.
+func (ref *webhookService) Deliver(ctx context.Context) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "webhookService.Deliver")
+	defer func() { span.End(err) }()
+
+	now := time.Now()
+
+	deliveries, err := ref.deliveryRepository.ClaimPending(ctx, ref.batchSize, now, now.Add(lockTimeout))
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+
+	for _, delivery := range deliveries {
+		subscription, err := ref.subscriptionRepository.GetByID(ctx, delivery.SubscriptionID)
+		if err != nil {
+			return delivered, err
+		}
+
+		if subscription == nil {
+			delivery.LastError = "subscription was deleted"
+			delivery.Status = entity.WebhookDeliveryStatusDead
+		} else {
+			ref.send(ctx, *subscription, &delivery)
+		}
+
+		metrics.WebhookDeliveries.WithLabelValues(delivery.EventType, delivery.Status).Inc()
+
+		if delivery.Status == entity.WebhookDeliveryStatusDead {
+			slog.WarnContext(ctx, "webhook delivery dead-lettered",
+				"delivery_id", delivery.ID,
+				"subscription_id", delivery.SubscriptionID,
+				"event_id", delivery.EventID,
+				"attempts", delivery.Attempts,
+				"error", delivery.LastError,
+			)
+		}
+
+		if _, err = ref.deliveryRepository.Update(ctx, delivery.ID, delivery); err != nil {
+			return delivered, err
+		}
+
+		if delivery.Status == entity.WebhookDeliveryStatusDelivered {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// send posts the delivery and records the outcome on it.
+func (ref *webhookService) send(ctx context.Context, subscription entity.WebhookSubscription, delivery *entity.WebhookDelivery) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	header := map[string]string{
+		"Content-Type":        "application/json",
+		"X-Webhook-ID":        delivery.ID,
+		"X-Event-ID":          delivery.EventID,
+		"X-Event-Type":        delivery.EventType,
+		"X-Webhook-Timestamp": timestamp,
+		"X-Webhook-Signature": "sha256=" + Sign(subscription.Secret, timestamp, delivery.Payload),
+	}
+
+	statusCode, err := ref.webhookSender.Send(ctx, subscription.URL, header, delivery.Payload)
+	if err == nil && (statusCode < 200 || statusCode > 299) {
+		err = fmt.Errorf("webhook responded with status %d", statusCode)
+	}
+
+	delivery.LastStatusCode = statusCode
+
+	if err == nil {
+		now := time.Now()
+		delivery.Status = entity.WebhookDeliveryStatusDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+
+	if delivery.Attempts >= ref.maxAttempts {
+		delivery.Status = entity.WebhookDeliveryStatusDead
+		return
+	}
+
+	delivery.NextAttemptAt = time.Now().Add(backoff(delivery.Attempts))
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp and body joined
+// by a dot. Receivers recompute it with the subscription secret and reject
+// stale timestamps to rule out replayed requests.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("could not generate webhook secret: %w", err)
+	}
+
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+func backoff(attempts int) time.Duration {
+	delay := retryBackoff
+
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,391 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	mocks "github.com/caiiomp/vehicle-resale-api/src/core/_mocks"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateSubscription(t *testing.T) {
+	ctx := context.TODO()
+	unexpectedError := errors.New("unexpected error")
+
+	subscription := entity.WebhookSubscription{
+		URL:        "https://partner.example.com/webhooks",
+		EventTypes: []string{entity.EventVehicleCreated},
+	}
+
+	t.Run("should not create subscription when failed to create", func(t *testing.T) {
+		subscriptionRepositoryMocked := mocks.NewWebhookSubscriptionRepository(t)
+
+		subscriptionRepositoryMocked.On("Create", ctx, mock.Anything).
+			Return(nil, unexpectedError)
+
+		service := NewWebhookService(subscriptionRepositoryMocked, nil, nil, 3, 10)
+
+		actual, err := service.CreateSubscription(ctx, subscription)
+
+		assert.Nil(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should create subscription with generated secret", func(t *testing.T) {
+		subscriptionRepositoryMocked := mocks.NewWebhookSubscriptionRepository(t)
+
+		subscriptionRepositoryMocked.On("Create", ctx, mock.MatchedBy(func(created entity.WebhookSubscription) bool {
+			return created.URL == subscription.URL &&
+				strings.HasPrefix(created.Secret, "whsec_") &&
+				len(created.Secret) == len("whsec_")+64
+		})).
+			Return(&subscription, nil)
+
+		service := NewWebhookService(subscriptionRepositoryMocked, nil, nil, 3, 10)
+
+		actual, err := service.CreateSubscription(ctx, subscription)
+
+		assert.Equal(t, &subscription, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestDeleteSubscription(t *testing.T) {
+	ctx := context.TODO()
+	subscriptionID := primitive.NewObjectID().Hex()
+
+	subscription := &entity.WebhookSubscription{
+		ID:  subscriptionID,
+		URL: "https://partner.example.com/webhooks",
+	}
+
+	t.Run("should not delete subscription that does not exist", func(t *testing.T) {
+		subscriptionRepositoryMocked := mocks.NewWebhookSubscriptionRepository(t)
+
+		subscriptionRepositoryMocked.On("GetByID", ctx, subscriptionID).
+			Return(nil, nil)
+
+		service := NewWebhookService(subscriptionRepositoryMocked, nil, nil, 3, 10)
+
+		actual, err := service.DeleteSubscription(ctx, subscriptionID)
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should delete subscription", func(t *testing.T) {
+		subscriptionRepositoryMocked := mocks.NewWebhookSubscriptionRepository(t)
+
+		subscriptionRepositoryMocked.On("GetByID", ctx, subscriptionID).
+			Return(subscription, nil)
+		subscriptionRepositoryMocked.On("Delete", ctx, subscriptionID).
+			Return(nil)
+
+		service := NewWebhookService(subscriptionRepositoryMocked, nil, nil, 3, 10)
+
+		actual, err := service.DeleteSubscription(ctx, subscriptionID)
+
+		assert.Equal(t, subscription, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestReplay(t *testing.T) {
+	ctx := context.TODO()
+	subscriptionID := primitive.NewObjectID().Hex()
+	deliveryID := primitive.NewObjectID().Hex()
+
+	t.Run("should not replay delivery of another subscription", func(t *testing.T) {
+		deliveryRepositoryMocked := mocks.NewWebhookDeliveryRepository(t)
+
+		deliveryRepositoryMocked.On("GetByID", ctx, deliveryID).
+			Return(&entity.WebhookDelivery{
+				ID:             deliveryID,
+				SubscriptionID: primitive.NewObjectID().Hex(),
+				Status:         entity.WebhookDeliveryStatusDead,
+			}, nil)
+
+		service := NewWebhookService(nil, deliveryRepositoryMocked, nil, 3, 10)
+
+		actual, err := service.Replay(ctx, subscriptionID, deliveryID)
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should not replay pending delivery", func(t *testing.T) {
+		deliveryRepositoryMocked := mocks.NewWebhookDeliveryRepository(t)
+
+		deliveryRepositoryMocked.On("GetByID", ctx, deliveryID).
+			Return(&entity.WebhookDelivery{
+				ID:             deliveryID,
+				SubscriptionID: subscriptionID,
+				Status:         entity.WebhookDeliveryStatusPending,
+			}, nil)
+
+		service := NewWebhookService(nil, deliveryRepositoryMocked, nil, 3, 10)
+
+		actual, err := service.Replay(ctx, subscriptionID, deliveryID)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, entity.ErrWebhookDeliveryPending)
+	})
+
+	t.Run("should schedule dead-lettered delivery again", func(t *testing.T) {
+		deliveryRepositoryMocked := mocks.NewWebhookDeliveryRepository(t)
+
+		deliveryRepositoryMocked.On("GetByID", ctx, deliveryID).
+			Return(&entity.WebhookDelivery{
+				ID:             deliveryID,
+				SubscriptionID: subscriptionID,
+				Status:         entity.WebhookDeliveryStatusDead,
+				Attempts:       3,
+				LastError:      "webhook responded with status 503",
+			}, nil)
+
+		expected := &entity.WebhookDelivery{
+			ID:             deliveryID,
+			SubscriptionID: subscriptionID,
+			Status:         entity.WebhookDeliveryStatusPending,
+		}
+
+		deliveryRepositoryMocked.On("Update", ctx, deliveryID, mock.MatchedBy(func(delivery entity.WebhookDelivery) bool {
+			return delivery.Status == entity.WebhookDeliveryStatusPending &&
+				delivery.Attempts == 0 &&
+				delivery.LastError == "webhook responded with status 503" &&
+				!delivery.NextAttemptAt.After(time.Now())
+		})).
+			Return(expected, nil)
+
+		service := NewWebhookService(nil, deliveryRepositoryMocked, nil, 3, 10)
+
+		actual, err := service.Replay(ctx, subscriptionID, deliveryID)
+
+		assert.Equal(t, expected, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.TODO()
+	unexpectedError := errors.New("unexpected error")
+
+	event := entity.Event{
+		ID:       primitive.NewObjectID().Hex(),
+		Type:     entity.EventVehicleSold,
+		EntityID: primitive.NewObjectID().Hex(),
+		Payload:  []byte(`{"id":"vehicle-123"}`),
+	}
+
+	first := entity.WebhookSubscription{ID: primitive.NewObjectID().Hex()}
+	second := entity.WebhookSubscription{ID: primitive.NewObjectID().Hex()}
+
+	t.Run("should not publish event when failed to search subscriptions", func(t *testing.T) {
+		subscriptionRepositoryMocked := mocks.NewWebhookSubscriptionRepository(t)
+
+		subscriptionRepositoryMocked.On("SearchByEventType", ctx, entity.EventVehicleSold).
+			Return(nil, unexpectedError)
+
+		service := NewWebhookService(subscriptionRepositoryMocked, nil, nil, 3, 10)
+
+		err := service.Publish(ctx, event)
+
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should enqueue one delivery per subscription", func(t *testing.T) {
+		subscriptionRepositoryMocked := mocks.NewWebhookSubscriptionRepository(t)
+		deliveryRepositoryMocked := mocks.NewWebhookDeliveryRepository(t)
+
+		subscriptionRepositoryMocked.On("SearchByEventType", ctx, entity.EventVehicleSold).
+			Return([]entity.WebhookSubscription{first, second}, nil)
+
+		for _, subscription := range []entity.WebhookSubscription{first, second} {
+			deliveryRepositoryMocked.On("Enqueue", ctx, mock.MatchedBy(func(delivery entity.WebhookDelivery) bool {
+				return delivery.SubscriptionID == subscription.ID &&
+					delivery.EventID == event.ID &&
+					delivery.EventType == entity.EventVehicleSold &&
+					delivery.Status == entity.WebhookDeliveryStatusPending &&
+					strings.Contains(string(delivery.Payload), `"data":{"id":"vehicle-123"}`)
+			})).
+				Return(nil).
+				Once()
+		}
+
+		service := NewWebhookService(subscriptionRepositoryMocked, deliveryRepositoryMocked, nil, 3, 10)
+
+		err := service.Publish(ctx, event)
+
+		assert.Nil(t, err)
+	})
+}
+
+func TestDeliver(t *testing.T) {
+	ctx := context.TODO()
+	unexpectedError := errors.New("unexpected error")
+
+	subscription := &entity.WebhookSubscription{
+		ID:     primitive.NewObjectID().Hex(),
+		URL:    "https://partner.example.com/webhooks",
+		Secret: "whsec_123",
+	}
+
+	newDelivery := func(attempts int) entity.WebhookDelivery {
+		return entity.WebhookDelivery{
+			ID:             primitive.NewObjectID().Hex(),
+			SubscriptionID: subscription.ID,
+			EventID:        primitive.NewObjectID().Hex(),
+			EventType:      entity.EventVehicleCreated,
+			Payload:        []byte(`{"id":"event-123"}`),
+			Status:         entity.WebhookDeliveryStatusPending,
+			Attempts:       attempts,
+		}
+	}
+
+	t.Run("should not deliver when failed to claim", func(t *testing.T) {
+		deliveryRepositoryMocked := mocks.NewWebhookDeliveryRepository(t)
+
+		deliveryRepositoryMocked.On("ClaimPending", ctx, 10, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return(nil, unexpectedError)
+
+		service := NewWebhookService(nil, deliveryRepositoryMocked, nil, 3, 10)
+
+		actual, err := service.Deliver(ctx)
+
+		assert.Zero(t, actual)
+		assert.Equal(t, unexpectedError, err)
+	})
+
+	t.Run("should deliver signed payload", func(t *testing.T) {
+		subscriptionRepositoryMocked := mocks.NewWebhookSubscriptionRepository(t)
+		deliveryRepositoryMocked := mocks.NewWebhookDeliveryRepository(t)
+		webhookSenderMocked := mocks.NewWebhookSender(t)
+
+		delivery := newDelivery(1)
+
+		deliveryRepositoryMocked.On("ClaimPending", ctx, 10, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return([]entity.WebhookDelivery{delivery}, nil)
+		subscriptionRepositoryMocked.On("GetByID", ctx, subscription.ID).
+			Return(subscription, nil)
+		webhookSenderMocked.On("Send", ctx, subscription.URL, mock.MatchedBy(func(header map[string]string) bool {
+			return header["X-Webhook-ID"] == delivery.ID &&
+				header["X-Event-ID"] == delivery.EventID &&
+				header["X-Webhook-Signature"] == "sha256="+Sign(subscription.Secret, header["X-Webhook-Timestamp"], delivery.Payload)
+		}), delivery.Payload).
+			Return(200, nil)
+		deliveryRepositoryMocked.On("Update", ctx, delivery.ID, mock.MatchedBy(func(updated entity.WebhookDelivery) bool {
+			return updated.Status == entity.WebhookDeliveryStatusDelivered &&
+				updated.LastStatusCode == 200 &&
+				updated.DeliveredAt != nil
+		})).
+			Return(&delivery, nil)
+
+		service := NewWebhookService(subscriptionRepositoryMocked, deliveryRepositoryMocked, webhookSenderMocked, 3, 10)
+
+		actual, err := service.Deliver(ctx)
+
+		assert.Equal(t, 1, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should retry rejected delivery later", func(t *testing.T) {
+		subscriptionRepositoryMocked := mocks.NewWebhookSubscriptionRepository(t)
+		deliveryRepositoryMocked := mocks.NewWebhookDeliveryRepository(t)
+		webhookSenderMocked := mocks.NewWebhookSender(t)
+
+		delivery := newDelivery(2)
+
+		deliveryRepositoryMocked.On("ClaimPending", ctx, 10, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return([]entity.WebhookDelivery{delivery}, nil)
+		subscriptionRepositoryMocked.On("GetByID", ctx, subscription.ID).
+			Return(subscription, nil)
+		webhookSenderMocked.On("Send", ctx, subscription.URL, mock.Anything, delivery.Payload).
+			Return(503, nil)
+		deliveryRepositoryMocked.On("Update", ctx, delivery.ID, mock.MatchedBy(func(updated entity.WebhookDelivery) bool {
+			return updated.Status == entity.WebhookDeliveryStatusPending &&
+				updated.LastStatusCode == 503 &&
+				updated.LastError == "webhook responded with status 503" &&
+				updated.NextAttemptAt.After(time.Now().Add(time.Second))
+		})).
+			Return(&delivery, nil)
+
+		service := NewWebhookService(subscriptionRepositoryMocked, deliveryRepositoryMocked, webhookSenderMocked, 3, 10)
+
+		actual, err := service.Deliver(ctx)
+
+		assert.Zero(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should dead-letter delivery out of attempts", func(t *testing.T) {
+		subscriptionRepositoryMocked := mocks.NewWebhookSubscriptionRepository(t)
+		deliveryRepositoryMocked := mocks.NewWebhookDeliveryRepository(t)
+		webhookSenderMocked := mocks.NewWebhookSender(t)
+
+		delivery := newDelivery(3)
+
+		deliveryRepositoryMocked.On("ClaimPending", ctx, 10, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return([]entity.WebhookDelivery{delivery}, nil)
+		subscriptionRepositoryMocked.On("GetByID", ctx, subscription.ID).
+			Return(subscription, nil)
+		webhookSenderMocked.On("Send", ctx, subscription.URL, mock.Anything, delivery.Payload).
+			Return(0, unexpectedError)
+		deliveryRepositoryMocked.On("Update", ctx, delivery.ID, mock.MatchedBy(func(updated entity.WebhookDelivery) bool {
+			return updated.Status == entity.WebhookDeliveryStatusDead &&
+				updated.LastError == "unexpected error"
+		})).
+			Return(&delivery, nil)
+
+		service := NewWebhookService(subscriptionRepositoryMocked, deliveryRepositoryMocked, webhookSenderMocked, 3, 10)
+
+		actual, err := service.Deliver(ctx)
+
+		assert.Zero(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should dead-letter delivery of deleted subscription", func(t *testing.T) {
+		subscriptionRepositoryMocked := mocks.NewWebhookSubscriptionRepository(t)
+		deliveryRepositoryMocked := mocks.NewWebhookDeliveryRepository(t)
+
+		delivery := newDelivery(1)
+
+		deliveryRepositoryMocked.On("ClaimPending", ctx, 10, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return([]entity.WebhookDelivery{delivery}, nil)
+		subscriptionRepositoryMocked.On("GetByID", ctx, subscription.ID).
+			Return(nil, nil)
+		deliveryRepositoryMocked.On("Update", ctx, delivery.ID, mock.MatchedBy(func(updated entity.WebhookDelivery) bool {
+			return updated.Status == entity.WebhookDeliveryStatusDead &&
+				updated.LastError == "subscription was deleted"
+		})).
+			Return(&delivery, nil)
+
+		service := NewWebhookService(subscriptionRepositoryMocked, deliveryRepositoryMocked, nil, 3, 10)
+
+		actual, err := service.Deliver(ctx)
+
+		assert.Zero(t, actual)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSign(t *testing.T) {
+	actual := Sign("whsec_123", "1700000000", []byte(`{"id":"event-123"}`))
+
+	assert.Len(t, actual, 64)
+	assert.Equal(t, actual, Sign("whsec_123", "1700000000", []byte(`{"id":"event-123"}`)))
+	assert.NotEqual(t, actual, Sign("whsec_123", "1700000001", []byte(`{"id":"event-123"}`)))
+	assert.NotEqual(t, actual, Sign("whsec_456", "1700000000", []byte(`{"id":"event-123"}`)))
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, backoff(1))
+	assert.Equal(t, 4*time.Second, backoff(3))
+	assert.Equal(t, maxBackoff, backoff(100))
+}
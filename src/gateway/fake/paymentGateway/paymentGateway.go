@@ -0,0 +1,93 @@
+package paymentGateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/google/uuid"
+)
+
+type webhookPayload struct {
+	IntentID string `json:"intent_id"`
+	Status   string `json:"status"`
+}
+
+type paymentGateway struct {
+	webhookSecret string
+	mutex         sync.Mutex
+	intents       map[string]entity.PaymentIntent
+}
+
+func NewPaymentGateway(webhookSecret string) interfaces.PaymentGateway {
+	return &paymentGateway{
+		webhookSecret: webhookSecret,
+		intents:       map[string]entity.PaymentIntent{},
+	}
+}
+
+func (ref *paymentGateway) CreateIntent(ctx context.Context, payment entity.Payment) (*entity.PaymentIntent, error) {
+	intent := entity.PaymentIntent{
+		ID:           "pi_" + uuid.NewString(),
+		ClientSecret: "secret_" + uuid.NewString(),
+		Amount:       payment.Amount,
+	}
+
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	ref.intents[intent.ID] = intent
+
+	return &intent, nil
+}
+
+func (ref *paymentGateway) CancelIntent(ctx context.Context, intentID string) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	if _, ok := ref.intents[intentID]; !ok {
+		return entity.ErrPaymentIntentNotFound
+	}
+
+	delete(ref.intents, intentID)
+
+	return nil
+}
+
+func (ref *paymentGateway) ParseWebhook(payload []byte, signature string) (*entity.PaymentEvent, error) {
+	expected := Sign(payload, ref.webhookSecret)
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, entity.ErrInvalidPaymentSignature
+	}
+
+	var event webhookPayload
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+
+	switch event.Status {
+	case entity.PaymentStatusSucceeded, entity.PaymentStatusFailed, entity.PaymentStatusCanceled:
+	default:
+		return nil, errors.New("unsupported payment status")
+	}
+
+	return &entity.PaymentEvent{
+		IntentID: event.IntentID,
+		Status:   event.Status,
+	}, nil
+}
+
+// Sign returns the signature the fake gateway expects in webhook calls, so
+// tests and local tooling can simulate the provider confirming a payment.
+func Sign(payload []byte, webhookSecret string) string {
+	mac := hmac.New(sha256.New, []byte(webhookSecret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,45 @@
+package webhookSender
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+)
+
+// maxResponseBody bounds how much of a response is drained, so that the
+// connection can be reused without reading an arbitrary body.
+const maxResponseBody = 64 << 10
+
+type webhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(client *http.Client) interfaces.WebhookSender {
+	return &webhookSender{
+		client: client,
+	}
+}
+
+func (ref *webhookSender) Send(ctx context.Context, url string, header map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := ref.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,61 @@
+package webhookSender
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should post body with headers", func(t *testing.T) {
+		var (
+			headers http.Header
+			body    []byte
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = r.Header
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		statusCode, err := NewWebhookSender(server.Client()).Send(ctx, server.URL, map[string]string{
+			"X-Webhook-Signature": "sha256=abc",
+		}, []byte(`{"id":"event-123"}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusAccepted, statusCode)
+		assert.Equal(t, "sha256=abc", headers.Get("X-Webhook-Signature"))
+		assert.Equal(t, `{"id":"event-123"}`, string(body))
+	})
+
+	t.Run("should return status of rejected request without error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		statusCode, err := NewWebhookSender(server.Client()).Send(ctx, server.URL, nil, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+	})
+
+	t.Run("should fail when webhook is unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		statusCode, err := NewWebhookSender(server.Client()).Send(ctx, server.URL, nil, nil)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, statusCode)
+	})
+}
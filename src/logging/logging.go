@@ -0,0 +1,106 @@
+// Package logging configures the structured logger and carries the request
+// ID through the context, so that every log line written with a request
+// context can be correlated.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/actor"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	RequestIDKey = "request_id"
+	UserIDKey    = "user_id"
+	TraceIDKey   = "trace_id"
+	SpanIDKey    = "span_id"
+)
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// New builds a logger writing JSON or text lines at the given level (debug,
+// info, warn or error).
+func New(writer io.Writer, format, level string) (*slog.Logger, error) {
+	var slogLevel slog.Level
+	if err := slogLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+
+	options := &slog.HandlerOptions{Level: slogLevel}
+
+	var handler slog.Handler
+
+	switch strings.ToLower(format) {
+	case "json":
+		handler = slog.NewJSONHandler(writer, options)
+	case "text":
+		handler = slog.NewTextHandler(writer, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+
+	return slog.New(contextHandler{next: handler}), nil
+}
+
+// contextHandler adds the request ID, user ID and trace IDs found in the
+// context to every record. A user_id set explicitly on the record wins over
+// the one in the context.
+type contextHandler struct {
+	next slog.Handler
+}
+
+func (ref contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return ref.next.Enabled(ctx, level)
+}
+
+func (ref contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String(RequestIDKey, requestID))
+	}
+
+	if userID := actor.FromContext(ctx).UserID; userID != "" && !hasAttr(record, UserIDKey) {
+		record.AddAttrs(slog.String(UserIDKey, userID))
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String(TraceIDKey, spanContext.TraceID().String()),
+			slog.String(SpanIDKey, spanContext.SpanID().String()),
+		)
+	}
+
+	return ref.next.Handle(ctx, record)
+}
+
+func (ref contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{next: ref.next.WithAttrs(attrs)}
+}
+
+func (ref contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{next: ref.next.WithGroup(name)}
+}
+
+func hasAttr(record slog.Record, key string) bool {
+	found := false
+
+	record.Attrs(func(attr slog.Attr) bool {
+		found = attr.Key == key
+		return !found
+	})
+
+	return found
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/actor"
+	"github.com/caiiomp/vehicle-resale-api/src/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("should not accept invalid level", func(t *testing.T) {
+		logger, err := New(&bytes.Buffer{}, "json", "verbose")
+
+		assert.Nil(t, logger)
+		assert.EqualError(t, err, `invalid log level "verbose"`)
+	})
+
+	t.Run("should not accept invalid format", func(t *testing.T) {
+		logger, err := New(&bytes.Buffer{}, "xml", "info")
+
+		assert.Nil(t, logger)
+		assert.EqualError(t, err, `invalid log format "xml"`)
+	})
+
+	t.Run("should skip records below level", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		logger, err := New(&buffer, "text", "warn")
+		require.NoError(t, err)
+
+		logger.Info("vehicle created")
+
+		assert.Empty(t, buffer.String())
+	})
+
+	t.Run("should add context attributes", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		logger, err := New(&buffer, "json", "info")
+		require.NoError(t, err)
+
+		header := http.Header{}
+		header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		ctx := tracing.Extract(context.TODO(), header)
+		ctx = WithRequestID(ctx, "request-id")
+		ctx = actor.NewContext(ctx, actor.Actor{UserID: "user-id"})
+
+		logger.InfoContext(ctx, "vehicle created", "vehicle_id", "vehicle-id")
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &line))
+
+		assert.Equal(t, "INFO", line["level"])
+		assert.Equal(t, "vehicle created", line["msg"])
+		assert.Equal(t, "vehicle-id", line["vehicle_id"])
+		assert.Equal(t, "request-id", line["request_id"])
+		assert.Equal(t, "user-id", line["user_id"])
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", line["trace_id"])
+		assert.Equal(t, "00f067aa0ba902b7", line["span_id"])
+	})
+
+	t.Run("should keep user set on the record", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		logger, err := New(&buffer, "json", "info")
+		require.NoError(t, err)
+
+		ctx := actor.NewContext(context.TODO(), actor.Actor{UserID: "seller-id"})
+
+		logger.InfoContext(ctx, "vehicle purchased", "user_id", "buyer-id")
+
+		assert.Equal(t, 1, bytes.Count(buffer.Bytes(), []byte(`"user_id"`)))
+		assert.Contains(t, buffer.String(), `"user_id":"buyer-id"`)
+	})
+}
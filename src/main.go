@@ -2,26 +2,104 @@ package main
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 	"time"
 
-	_ "github.com/joho/godotenv/autoload"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
+	"github.com/caiiomp/vehicle-resale-api/src/config"
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/health"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/idempotency"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/job"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/payment"
 	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/sale"
 	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicleImport"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicleStream"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/webhook"
 	"github.com/caiiomp/vehicle-resale-api/src/presentation"
 
 	_ "github.com/caiiomp/vehicle-resale-api/src/docs"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/webhookSender"
+	"github.com/caiiomp/vehicle-resale-api/src/logging"
 	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/auditApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/healthApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/jobApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/metricsApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/paymentApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/reportApi"
 	"github.com/caiiomp/vehicle-resale-api/src/presentation/saleApi"
 	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/webhookApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/natsPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/webhookPublisher"
+	instrumentedPaymentRepository "github.com/caiiomp/vehicle-resale-api/src/repository/instrumented/paymentRepository"
+	instrumentedSaleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/instrumented/saleRepository"
+	instrumentedVehicleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/instrumented/vehicleRepository"
+	memoryAuditRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	memoryIdempotencyRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/idempotencyRepository"
+	memoryImportJobRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/importJobRepository"
+	memoryJobRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/jobRepository"
+	memoryOutboxRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	memoryPaymentRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	memorySaleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/saleRepository"
+	memoryTransactor "github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	memoryVehicleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	memoryVehicleVersionRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleVersionRepository"
+	memoryWebhookDeliveryRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/webhookDeliveryRepository"
+	memoryWebhookSubscriptionRepository "github.com/caiiomp/vehicle-resale-api/src/repository/memory/webhookSubscriptionRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/commandMonitor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/healthChecker"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/idempotencyRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/importJobRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/indexes"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/jobRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/paymentRepository"
 	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/saleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/transactor"
 	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/vehicleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/vehicleVersionRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/webhookDeliveryRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/webhookSubscriptionRepository"
+	postgresAuditRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/connection"
+	postgresHealthChecker "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/healthChecker"
+	postgresIdempotencyRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/idempotencyRepository"
+	postgresImportJobRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/importJobRepository"
+	postgresJobRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/jobRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/migrations"
+	postgresOutboxRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/outboxRepository"
+	postgresPaymentRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/paymentRepository"
+	postgresSaleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/saleRepository"
+	postgresTransactor "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	postgresVehicleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/vehicleRepository"
+	postgresVehicleVersionRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/vehicleVersionRepository"
+	postgresWebhookDeliveryRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/webhookDeliveryRepository"
+	postgresWebhookSubscriptionRepository "github.com/caiiomp/vehicle-resale-api/src/repository/postgres/webhookSubscriptionRepository"
+	versionedVehicleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/versioned/vehicleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/tracing"
+	"github.com/caiiomp/vehicle-resale-api/src/worker"
 )
 
 // @securityDefinitions.apikey BearerAuth
@@ -29,46 +107,509 @@ import (
 // @name Authorization
 
 func main() {
-	var (
-		mongoURI      = os.Getenv("MONGO_URI")
-		mongoDatabase = os.Getenv("MONGO_DATABASE")
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("could not load configuration", err)
+	}
+
+	logger, err := logging.New(os.Stdout, cfg.Logging.Format, cfg.Logging.Level)
+	if err != nil {
+		fatal("could not configure logging", err)
+	}
+
+	slog.SetDefault(logger)
+
+	// Gin's debug mode prints plain text route listings among the JSON logs.
+	if os.Getenv(gin.EnvGinMode) == "" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	slog.Info("configuration loaded", "config", cfg)
+
+	tracerProvider, err := newTracerProvider(cfg.Tracing)
+	if err != nil {
+		fatal("could not initialize tracing", err)
+	}
+
+	if tracerProvider != nil {
+		tracing.SetProvider(tracerProvider)
+	}
+
+	repositories, err := newRepositories(cfg)
+	if err != nil {
+		fatal("could not initialize storage", err)
+	}
+
+	vehicleVersionRepository := repositories.vehicleVersion
+	vehicleRepository := instrumentedVehicleRepository.NewVehicleRepository(versionedVehicleRepository.NewVehicleRepository(repositories.vehicle, vehicleVersionRepository))
+	saleRepository := instrumentedSaleRepository.NewSaleRepository(repositories.sale)
+	paymentRepository := instrumentedPaymentRepository.NewPaymentRepository(repositories.payment)
+	importJobRepository := repositories.importJob
+	jobRepository := repositories.job
+	auditRepository := repositories.audit
+	outboxRepository := repositories.outbox
+	webhookSubscriptionRepository := repositories.webhookSubscription
+	webhookDeliveryRepository := repositories.webhookDelivery
+	idempotencyRepository := repositories.idempotency
+	transactor := repositories.transactor
+
+	paymentGateway, err := newPaymentGateway(cfg.Payment)
+	if err != nil {
+		fatal("could not configure payment gateway", err)
+	}
+
+	webhookSender := webhookSender.NewWebhookSender(&http.Client{Timeout: cfg.Webhooks.Timeout})
+
+	events, err := newEvents(cfg.Outbox)
+	if err != nil {
+		fatal("could not configure event publisher", err)
+	}
 
-		jwtSecretKey = os.Getenv("JWT_SECRET_KEY")
+	auditService := audit.NewAuditService(auditRepository)
+	webhookService := webhook.NewWebhookService(webhookSubscriptionRepository, webhookDeliveryRepository, webhookSender, cfg.Webhooks.MaxAttempts, cfg.Webhooks.BatchSize)
+	vehicleStreamService := vehicleStream.NewVehicleStreamService(cfg.Stream.BufferSize, cfg.Stream.SubscriberBuffer)
+	// Webhook subscriptions are fed by the outbox next to the configured
+	// publisher. Only the instance that claims an outbox message relays it, so
+	// the vehicle stream is fed by the publisher when it reaches every
+	// instance, and by the outbox of this instance otherwise.
+	outboxConsumers := []interfaces.EventPublisher{events.publisher, webhookService}
+	if events.subscribe != nil {
+		if err = events.subscribe(vehicleStreamService); err != nil {
+			fatal("could not subscribe to events", err)
+		}
+	} else {
+		outboxConsumers = append(outboxConsumers, vehicleStreamService)
+	}
+
+	outboxService := outbox.NewOutboxService(outboxRepository, publisher.Fanout(outboxConsumers...), cfg.Outbox.BatchSize)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, vehicleVersionRepository, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	saleService := sale.NewSaleService(saleRepository, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, cfg.Vehicles.DealershipSellerID)
+	jobService := job.NewJobService(jobRepository, cfg.Jobs.MaxAttempts)
+	vehicleImportService := vehicleImport.NewVehicleImportService(importJobRepository, jobService, vehicleService)
+	idempotencyService := idempotency.NewIdempotencyService(idempotencyRepository, cfg.Idempotency.TTL)
+	healthService := health.NewHealthService(cfg.Health.CheckTimeout, repositories.healthCheckers...)
+
+	workerConfig := worker.DefaultConfig
+	workerConfig.Concurrency = cfg.Jobs.Workers
+
+	workerPool := worker.NewPool(jobRepository, workerConfig)
+	workerPool.Handle(vehicleImport.JobType, vehicleImportService.Process)
+	workerPool.Every("release expired payments", time.Minute, releaseExpiredPayments(paymentService))
+	workerPool.Every("relay outbox events", cfg.Outbox.PollInterval, relayOutboxEvents(outboxService, cfg.Outbox.BatchSize))
+	workerPool.Every("deliver webhooks", cfg.Webhooks.DeliveryInterval, deliverWebhooks(webhookService, cfg.Webhooks.BatchSize))
+	workerPool.Every("purge expired idempotency keys", cfg.Idempotency.PurgeInterval, purgeExpiredIdempotencyKeys(idempotencyService))
+	workerPool.Every("purge deleted vehicles", cfg.Vehicles.PurgeInterval, purgeDeletedVehicles(vehicleService, cfg.Vehicles.DeletedRetention))
+	workerPool.Start()
+
+	authMiddleware := middleware.NewAuthMiddleware(cfg.Auth.JWTSecretKey)
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(idempotencyService)
+
+	app := presentation.SetupServer(middleware.Tracing, middleware.Metrics)
+
+	app.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	vehicleApi.RegisterVehicleRoutes(app, authMiddleware, idempotencyMiddleware, vehicleService)
+	vehicleApi.RegisterVehicleImportRoutes(app, authMiddleware, vehicleImportService)
+	vehicleApi.RegisterVehicleStreamRoutes(app, vehicleStreamService)
+	saleApi.RegisterSaleRoutes(app, saleService)
+	paymentApi.RegisterPaymentRoutes(app, authMiddleware, paymentService)
+	reportApi.RegisterReportRoutes(app, authMiddleware, saleService, vehicleService)
+	jobApi.RegisterJobRoutes(app, authMiddleware, jobService)
+	auditApi.RegisterAuditRoutes(app, authMiddleware, auditService)
+	webhookApi.RegisterWebhookRoutes(app, authMiddleware, webhookService)
+	healthApi.RegisterHealthRoutes(app, healthService)
+	metricsApi.RegisterMetricsRoutes(app)
+
+	server := &http.Server{
+		Addr:              cfg.Addr(),
+		Handler:           app,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+
+	// Shutdown waits for the handlers to return, and streams only return
+	// once they are closed.
+	server.RegisterOnShutdown(vehicleStreamService.Close)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	slog.Info("http server listening", "addr", server.Addr)
+
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	select {
+	case err = <-serverErr:
+		fatal("could not initialize http server", err)
+	case <-signalCtx.Done():
+		stop()
+	}
+
+	slog.Info("shutting down")
+
+	// Fail readiness first and give the load balancer time to notice before
+	// the listener is closed.
+	healthService.Shutdown()
+	time.Sleep(cfg.ShutdownDelay)
+
+	// Each stage gets a deadline of its own, so that a stage that times out
+	// does not leave the following ones without time. Requests are drained
+	// first, since they may enqueue jobs and use the database, then the
+	// workers, which relay the outbox, then the event publisher, and the
+	// storage last.
+	shutdown("drain http requests", cfg.ShutdownTimeout, server.Shutdown)
+	shutdown("wait for running jobs", cfg.ShutdownTimeout, workerPool.Shutdown)
+
+	if events.close != nil {
+		shutdown("close event publisher", cfg.ShutdownTimeout, events.close)
+	}
+
+	shutdown("close storage", cfg.ShutdownTimeout, repositories.close)
+
+	if tracerProvider != nil {
+		shutdown("export pending spans", cfg.ShutdownTimeout, tracerProvider.Shutdown)
+	}
+}
+
+// shutdown runs a stage of the shutdown, giving up on it after timeout.
+func shutdown(stage string, timeout time.Duration, fn func(ctx context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := fn(ctx); err != nil {
+		slog.Error("could not "+stage, "error", err)
+	}
+}
+
+func fatal(message string, err error) {
+	slog.Error(message, "error", err)
+	os.Exit(1)
+}
+
+func newTracerProvider(cfg config.Tracing) (*sdktrace.TracerProvider, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
 	)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	switch cfg.Exporter {
+	case "stdout":
+		exporter, err = tracing.NewStdoutExporter(os.Stdout)
+	case "otlp":
+		exporter, err = tracing.NewOTLPExporter(context.Background(), cfg.OTLPEndpoint)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tracing.NewProvider(exporter, cfg.ServiceName, cfg.SampleRatio), nil
+}
+
+// repositories hold the storage of the backend chosen in STORAGE_BACKEND.
+type repositories struct {
+	vehicle             interfaces.VehicleRepository
+	vehicleVersion      interfaces.VehicleVersionRepository
+	sale                interfaces.SaleRepository
+	payment             interfaces.PaymentRepository
+	importJob           interfaces.ImportJobRepository
+	job                 interfaces.JobRepository
+	audit               interfaces.AuditRepository
+	outbox              interfaces.OutboxRepository
+	webhookSubscription interfaces.WebhookSubscriptionRepository
+	webhookDelivery     interfaces.WebhookDeliveryRepository
+	idempotency         interfaces.IdempotencyRepository
+	transactor          interfaces.Transactor
+	healthCheckers      []interfaces.HealthChecker
+	// close releases the backend once nothing uses it anymore.
+	close func(ctx context.Context) error
+}
+
+func newRepositories(cfg *config.Config) (*repositories, error) {
+	switch cfg.Storage.Backend {
+	case "memory":
+		return newMemoryRepositories(cfg.Storage)
+	case "postgres":
+		return newPostgresRepositories(cfg.Postgres)
+	default:
+		return newMongoRepositories(cfg.Mongo)
+	}
+}
+
+func newMongoRepositories(cfg config.Mongo) (*repositories, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
 	defer cancel()
 
-	clientOptions := options.Client().ApplyURI(mongoURI)
+	clientOptions := options.Client().
+		ApplyURI(cfg.URI).
+		SetMaxPoolSize(cfg.MaxPoolSize).
+		SetMinPoolSize(cfg.MinPoolSize).
+		SetConnectTimeout(cfg.ConnectTimeout).
+		SetServerSelectionTimeout(cfg.ServerSelectionTimeout).
+		SetMonitor(commandMonitor.NewCommandMonitor())
 
 	mongoClient, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
-		log.Fatalf("could not initialize mongodb client: %v", err)
+		return nil, fmt.Errorf("could not initialize mongodb client: %w", err)
 	}
 
 	if err = mongoClient.Ping(ctx, nil); err != nil {
-		log.Fatalf("could not connect to database: %v", err)
+		return nil, fmt.Errorf("could not connect to mongodb: %w", err)
 	}
 
-	vehiclesCollection := mongoClient.Database(mongoDatabase).Collection("vehicles")
-	salesCollection := mongoClient.Database(mongoDatabase).Collection("sales")
+	database := mongoClient.Database(cfg.Database)
 
-	vehicleRepository := vehicleRepository.NewVehicleRepository(vehiclesCollection)
-	saleRepository := saleRepository.NewSaleRepository(salesCollection)
+	if err = indexes.Create(ctx, database); err != nil {
+		return nil, err
+	}
 
-	vehicleService := vehicle.NewVehicleService(vehicleRepository, saleRepository)
-	saleService := sale.NewSaleService(saleRepository)
+	vehiclesCollection := database.Collection("vehicles")
 
-	authMiddleware := middleware.NewAuthMiddleware(jwtSecretKey)
+	return &repositories{
+		vehicle:             vehicleRepository.NewVehicleRepository(vehiclesCollection),
+		vehicleVersion:      vehicleVersionRepository.NewVehicleVersionRepository(database.Collection("vehicle_versions")),
+		sale:                saleRepository.NewSaleRepository(database.Collection("sales"), vehiclesCollection),
+		payment:             paymentRepository.NewPaymentRepository(database.Collection("payments")),
+		importJob:           importJobRepository.NewImportJobRepository(database.Collection("imports")),
+		job:                 jobRepository.NewJobRepository(database.Collection("jobs")),
+		audit:               auditRepository.NewAuditRepository(database.Collection("audit")),
+		outbox:              outboxRepository.NewOutboxRepository(database.Collection("outbox")),
+		webhookSubscription: webhookSubscriptionRepository.NewWebhookSubscriptionRepository(database.Collection("webhook_subscriptions")),
+		webhookDelivery:     webhookDeliveryRepository.NewWebhookDeliveryRepository(database.Collection("webhook_deliveries")),
+		idempotency:         idempotencyRepository.NewIdempotencyRepository(database.Collection("idempotency_keys")),
+		transactor:          transactor.NewTransactor(mongoClient),
+		healthCheckers:      []interfaces.HealthChecker{healthChecker.NewHealthChecker(mongoClient)},
+		close:               mongoClient.Disconnect,
+	}, nil
+}
 
-	app := presentation.SetupServer()
+// newMemoryRepositories keeps everything in the process. Only vehicles,
+// sales and payments are saved to cfg.SnapshotDir, when it is set, and loaded
+// again at startup.
+func newMemoryRepositories(cfg config.Storage) (*repositories, error) {
+	var vehiclesPath, salesPath, paymentsPath string
 
-	app.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	if cfg.SnapshotDir != "" {
+		vehiclesPath = filepath.Join(cfg.SnapshotDir, "vehicles.json")
+		salesPath = filepath.Join(cfg.SnapshotDir, "sales.json")
+		paymentsPath = filepath.Join(cfg.SnapshotDir, "payments.json")
+	}
 
-	vehicleApi.RegisterVehicleRoutes(app, authMiddleware, vehicleService)
-	saleApi.RegisterSaleRoutes(app, saleService)
+	vehicleRepository, err := memoryVehicleRepository.LoadVehicleRepository(vehiclesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	saleRepository, err := memorySaleRepository.LoadSaleRepository(salesPath, vehicleRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentRepository, err := memoryPaymentRepository.LoadPaymentRepository(paymentsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories{
+		vehicle:             vehicleRepository,
+		vehicleVersion:      memoryVehicleVersionRepository.NewVehicleVersionRepository(),
+		sale:                saleRepository,
+		payment:             paymentRepository,
+		importJob:           memoryImportJobRepository.NewImportJobRepository(),
+		job:                 memoryJobRepository.NewJobRepository(),
+		audit:               memoryAuditRepository.NewAuditRepository(),
+		outbox:              memoryOutboxRepository.NewOutboxRepository(),
+		webhookSubscription: memoryWebhookSubscriptionRepository.NewWebhookSubscriptionRepository(),
+		webhookDelivery:     memoryWebhookDeliveryRepository.NewWebhookDeliveryRepository(),
+		idempotency:         memoryIdempotencyRepository.NewIdempotencyRepository(),
+		transactor:          memoryTransactor.NewTransactor(),
+		close: func(ctx context.Context) error {
+			return nil
+		},
+	}, nil
+}
+
+// newPostgresRepositories migrates the schema at startup. Every repository is
+// stored in Postgres, so that a purchase is a single transaction.
+func newPostgresRepositories(cfg config.Postgres) (*repositories, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
+	defer cancel()
+
+	poolConfig, err := pgxpool.ParseConfig(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse POSTGRES_URL: %w", err)
+	}
+
+	connection.Configure(poolConfig)
+
+	poolConfig.MaxConns = int32(cfg.MaxConns)
+	poolConfig.ConnConfig.ConnectTimeout = cfg.ConnectTimeout
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize postgres pool: %w", err)
+	}
+
+	if err = pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("could not connect to postgres: %w", err)
+	}
+
+	if err = migrations.Migrate(ctx, pool); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("could not migrate postgres: %w", err)
+	}
+
+	return &repositories{
+		vehicle:             postgresVehicleRepository.NewVehicleRepository(pool),
+		vehicleVersion:      postgresVehicleVersionRepository.NewVehicleVersionRepository(pool),
+		sale:                postgresSaleRepository.NewSaleRepository(pool),
+		payment:             postgresPaymentRepository.NewPaymentRepository(pool),
+		importJob:           postgresImportJobRepository.NewImportJobRepository(pool),
+		job:                 postgresJobRepository.NewJobRepository(pool),
+		audit:               postgresAuditRepository.NewAuditRepository(pool),
+		outbox:              postgresOutboxRepository.NewOutboxRepository(pool),
+		webhookSubscription: postgresWebhookSubscriptionRepository.NewWebhookSubscriptionRepository(pool),
+		webhookDelivery:     postgresWebhookDeliveryRepository.NewWebhookDeliveryRepository(pool),
+		idempotency:         postgresIdempotencyRepository.NewIdempotencyRepository(pool),
+		transactor:          postgresTransactor.NewTransactor(pool),
+		healthCheckers:      []interfaces.HealthChecker{postgresHealthChecker.NewHealthChecker(pool)},
+		close: func(ctx context.Context) error {
+			pool.Close()
+			return nil
+		},
+	}, nil
+}
+
+// newPaymentGateway only gets the fake gateway in development, as the
+// configuration rejects it elsewhere.
+func newPaymentGateway(cfg config.Payment) (interfaces.PaymentGateway, error) {
+	switch cfg.Gateway {
+	case "fake":
+		return paymentGateway.NewPaymentGateway(cfg.WebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment gateway %q", cfg.Gateway)
+	}
+}
+
+// events hold the publisher chosen in OUTBOX_PUBLISHER.
+type events struct {
+	publisher interfaces.EventPublisher
+	// subscribe passes the events published by every instance to consumer. It
+	// is nil for publishers that only reach other systems.
+	subscribe func(consumer interfaces.EventPublisher) error
+	// close releases the publisher once the outbox relay has stopped. It is
+	// nil for publishers that hold no connection.
+	close func(ctx context.Context) error
+}
+
+func newEvents(cfg config.Outbox) (*events, error) {
+	switch cfg.Publisher {
+	case "webhook":
+		return &events{
+			publisher: webhookPublisher.NewPublisher(cfg.WebhookURL, &http.Client{Timeout: 10 * time.Second}),
+		}, nil
+	case "nats":
+		eventPublisher, err := natsPublisher.NewPublisher(cfg.NATSURL, cfg.NATSSubjectPrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		return &events{
+			publisher: eventPublisher,
+			subscribe: eventPublisher.Subscribe,
+			close:     eventPublisher.Close,
+		}, nil
+	default:
+		return &events{
+			publisher: memoryPublisher.NewPublisher(),
+		}, nil
+	}
+}
+
+func releaseExpiredPayments(paymentService interfaces.PaymentService) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		released, err := paymentService.ReleaseExpired(ctx)
+		if err != nil {
+			return err
+		}
+
+		if released > 0 {
+			slog.InfoContext(ctx, "released expired payments", "payments", released)
+		}
+
+		return nil
+	}
+}
+
+func purgeExpiredIdempotencyKeys(idempotencyService interfaces.IdempotencyService) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		purged, err := idempotencyService.PurgeExpired(ctx)
+		if err != nil {
+			return err
+		}
+
+		if purged > 0 {
+			slog.InfoContext(ctx, "purged expired idempotency keys", "keys", purged)
+		}
+
+		return nil
+	}
+}
+
+func purgeDeletedVehicles(vehicleService interfaces.VehicleService, retention time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		purged, err := vehicleService.PurgeDeleted(ctx, retention)
+		if err != nil {
+			return err
+		}
+
+		if purged > 0 {
+			slog.InfoContext(ctx, "purged deleted vehicles", "vehicles", purged)
+		}
+
+		return nil
+	}
+}
+
+// relayOutboxEvents keeps relaying while full batches come back, so that a
+// backlog drains without waiting for the next tick.
+func relayOutboxEvents(outboxService interfaces.OutboxService, batchSize int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for {
+			published, err := outboxService.Relay(ctx)
+			if err != nil {
+				return err
+			}
+
+			if published < batchSize {
+				return nil
+			}
+		}
+	}
+}
+
+// deliverWebhooks keeps delivering while full batches come back, like
+// relayOutboxEvents.
+func deliverWebhooks(webhookService interfaces.WebhookService, batchSize int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for {
+			delivered, err := webhookService.Deliver(ctx)
+			if err != nil {
+				return err
+			}
 
-	if err = app.Run(":8080"); err != nil {
-		log.Fatalf("coult not initialize http server: %v", err)
+			if delivered < batchSize {
+				return nil
+			}
+		}
 	}
 }
@@ -0,0 +1,91 @@
+// Package metrics declares the Prometheus metrics of the API and serves them,
+// along with the Go runtime and process metrics, in the exposition format.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds the metrics declared by this package. It is not the global
+// registry of client_golang, so that libraries cannot add metrics to it.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	HTTPRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by method, route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	RepositoryOperationDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "repository_operation_duration_seconds",
+		Help:    "Latency of repository operations by repository, method and outcome.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"repository", "method", "outcome"})
+
+	VehiclesCreated = factory.NewCounter(prometheus.CounterOpts{
+		Name: "vehicles_created_total",
+		Help: "Number of vehicles listed for sale.",
+	})
+
+	Purchases = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "purchases_total",
+		Help: "Number of finished purchases by payment status (succeeded, failed or canceled).",
+	}, []string{"status"})
+
+	SalesRevenue = factory.NewCounter(prometheus.CounterOpts{
+		Name: "sales_revenue_total",
+		Help: "Sum of the prices of sold vehicles.",
+	})
+
+	OutboxEvents = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_events_total",
+		Help: "Number of outbox events relayed by type and outcome (published or failed).",
+	}, []string{"type", "outcome"})
+
+	WebhookDeliveries = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "webhook_deliveries_total",
+		Help: "Number of webhook delivery attempts by event type and resulting status (delivered, pending or dead).",
+	}, []string{"type", "status"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the metrics in Registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveSince records in histogram the seconds elapsed since start.
+func ObserveSince(histogram prometheus.Observer, start time.Time) {
+	histogram.Observe(time.Since(start).Seconds())
+}
+
+// ObserveRepository records the latency of a repository operation started at
+// start, labeled by whether it returned an error.
+func ObserveRepository(repository, method string, start time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	ObserveSince(RepositoryOperationDuration.WithLabelValues(repository, method, outcome), start)
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveRepository(t *testing.T) {
+	success := RepositoryOperationDuration.WithLabelValues("vehicle", "Observe", "success")
+	failure := RepositoryOperationDuration.WithLabelValues("vehicle", "Observe", "error")
+
+	ObserveRepository("vehicle", "Observe", time.Now(), nil)
+	ObserveRepository("vehicle", "Observe", time.Now(), nil)
+	ObserveRepository("vehicle", "Observe", time.Now(), errors.New("failed"))
+
+	assert.Equal(t, uint64(2), sampleCount(t, success))
+	assert.Equal(t, uint64(1), sampleCount(t, failure))
+}
+
+func sampleCount(t *testing.T, histogram prometheus.Observer) uint64 {
+	t.Helper()
+
+	var metric dto.Metric
+	if err := histogram.(prometheus.Metric).Write(&metric); err != nil {
+		t.Fatalf("failed to read histogram: %v", err)
+	}
+
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestHandler(t *testing.T) {
+	VehiclesCreated.Inc()
+
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	resp := httptest.NewRecorder()
+
+	Handler().ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+	assert.Contains(t, resp.Body.String(), "# TYPE vehicles_created_total counter\n")
+	assert.Contains(t, resp.Body.String(), "\ngo_goroutines ")
+}
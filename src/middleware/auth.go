@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/actor"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -56,6 +57,44 @@ func (ref *AuthMiddleware) Auth(ctx *gin.Context) {
 	claims := token.Claims.(jwt.MapClaims)
 
 	ctx.Set("user_id", claims["user_id"])
+	ctx.Set("role", claims["role"])
+
+	userID, _ := claims["user_id"].(string)
+	role, _ := claims["role"].(string)
+
+	ctx.Request = ctx.Request.WithContext(actor.NewContext(ctx.Request.Context(), actor.Actor{
+		UserID: userID,
+		Role:   role,
+	}))
+
+	ctx.Next()
+}
+
+// OptionalAuth lets through requests without a token as anonymous, and
+// otherwise authenticates them like Auth.
+func (ref *AuthMiddleware) OptionalAuth(ctx *gin.Context) {
+	if gin.Mode() == gin.TestMode {
+		return
+	}
+
+	if ctx.GetHeader("Authorization") == "" {
+		ctx.Next()
+		return
+	}
+
+	ref.Auth(ctx)
+}
+
+// Admin must run after Auth and only lets through tokens with the admin role.
+func (ref *AuthMiddleware) Admin(ctx *gin.Context) {
+	if gin.Mode() == gin.TestMode {
+		return
+	}
+
+	if !actor.FromContext(ctx.Request.Context()).IsAdmin() {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin role is required"})
+		return
+	}
 
 	ctx.Next()
 }
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/actor"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+type IdempotencyMiddleware struct {
+	idempotencyService interfaces.IdempotencyService
+}
+
+func NewIdempotencyMiddleware(idempotencyService interfaces.IdempotencyService) IdempotencyMiddleware {
+	return IdempotencyMiddleware{
+		idempotencyService: idempotencyService,
+	}
+}
+
+// Idempotent must run after Auth. Requests sent with an Idempotency-Key are
+// processed once per user and key: retries with the same method, path and body
+// get the first response back, and reusing the key for another request is
+// rejected with 422. Server errors are not kept, so that they can be retried.
+func (ref *IdempotencyMiddleware) Idempotent(ctx *gin.Context) {
+	key := ctx.GetHeader(IdempotencyKeyHeader)
+	if key == "" {
+		ctx.Next()
+		return
+	}
+
+	if !validHeaderToken(key, maxIdempotencyKeyLength) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: "idempotency key must be printable ASCII of at most 255 characters",
+		})
+		return
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Keys are scoped to the user, so that users cannot see each other's
+	// responses by guessing keys.
+	key = actor.FromContext(ctx.Request.Context()).UserID + ":" + key
+	fingerprint := requestFingerprint(ctx.Request.Method, ctx.Request.URL.Path, body)
+
+	existing, err := ref.idempotencyService.Begin(ctx, key, fingerprint)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if existing != nil {
+		switch {
+		case existing.Fingerprint != fingerprint:
+			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, responses.ErrorResponse{
+				Error: "idempotency key was already used with a different request",
+			})
+		case !existing.IsCompleted():
+			ctx.AbortWithStatusJSON(http.StatusConflict, responses.ErrorResponse{
+				Error: "a request with this idempotency key is still in progress",
+			})
+		default:
+			ctx.Header(IdempotentReplayedHeader, "true")
+			ctx.Data(existing.StatusCode, existing.ContentType, existing.Body)
+			ctx.Abort()
+		}
+		return
+	}
+
+	// The outcome is stored even when the client went away, since that is
+	// when it is most likely to retry.
+	storeCtx := context.WithoutCancel(ctx.Request.Context())
+
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+
+		if err := ref.idempotencyService.Release(storeCtx, key); err != nil {
+			slog.ErrorContext(storeCtx, "could not release idempotency key", "error", err)
+		}
+	}()
+
+	writer := &capturingWriter{ResponseWriter: ctx.Writer}
+	ctx.Writer = writer
+
+	ctx.Next()
+
+	status := ctx.Writer.Status()
+	if status >= http.StatusInternalServerError {
+		return
+	}
+
+	err = ref.idempotencyService.Complete(storeCtx, key, fingerprint, status, ctx.Writer.Header().Get("Content-Type"), writer.body.Bytes())
+	if err != nil {
+		slog.ErrorContext(storeCtx, "could not store idempotent response", "error", err)
+		return
+	}
+
+	completed = true
+}
+
+func requestFingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// capturingWriter keeps a copy of the response body while writing it.
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (ref *capturingWriter) Write(data []byte) (int, error) {
+	ref.body.Write(data)
+	return ref.ResponseWriter.Write(data)
+}
+
+func (ref *capturingWriter) WriteString(data string) (int, error) {
+	ref.body.WriteString(data)
+	return ref.ResponseWriter.WriteString(data)
+}
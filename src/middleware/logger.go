@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// quietRoutes are polled by probes and scrapers, so they are logged at debug
+// level only.
+var quietRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// Logger writes one access log line per request.
+func Logger(ctx *gin.Context) {
+	start := time.Now()
+
+	ctx.Next()
+
+	route := ctx.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+
+	status := ctx.Writer.Status()
+
+	level := slog.LevelInfo
+	switch {
+	case status >= http.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	case quietRoutes[route]:
+		level = slog.LevelDebug
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", ctx.Request.Method),
+		slog.String("route", route),
+		slog.String("path", ctx.Request.URL.Path),
+		slog.Int("status", status),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.Int("bytes", ctx.Writer.Size()),
+		slog.String("client_ip", ctx.ClientIP()),
+	}
+
+	if errors := ctx.Errors.String(); errors != "" {
+		attrs = append(attrs, slog.String("errors", errors))
+	}
+
+	slog.LogAttrs(ctx.Request.Context(), level, "http request", attrs...)
+}
+
+// Recovery answers 500 when a handler panics, logging the panic with the
+// request context.
+func Recovery(ctx *gin.Context, recovered any) {
+	slog.ErrorContext(ctx.Request.Context(), "handler panicked", "panic", recovered)
+	ctx.AbortWithStatus(http.StatusInternalServerError)
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that match no route, so that arbitrary paths
+// do not create new series.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and latency of every request by route template
+// and status.
+func Metrics(ctx *gin.Context) {
+	start := time.Now()
+
+	ctx.Next()
+
+	route := ctx.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+
+	status := strconv.Itoa(ctx.Writer.Status())
+	method := ctx.Request.Method
+
+	metrics.HTTPRequests.WithLabelValues(method, route, status).Inc()
+	metrics.ObserveSince(metrics.HTTPRequestDuration.WithLabelValues(method, route, status), start)
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"github.com/caiiomp/vehicle-resale-api/src/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	maxRequestIDLength = 128
+)
+
+// RequestID keeps the X-Request-ID sent by the client, or generates one, and
+// stores it in the request context and in the response header.
+func RequestID(ctx *gin.Context) {
+	requestID := ctx.GetHeader(RequestIDHeader)
+	if !validHeaderToken(requestID, maxRequestIDLength) {
+		requestID = uuid.NewString()
+	}
+
+	ctx.Set(logging.RequestIDKey, requestID)
+	ctx.Request = ctx.Request.WithContext(logging.WithRequestID(ctx.Request.Context(), requestID))
+	ctx.Header(RequestIDHeader, requestID)
+
+	ctx.Next()
+}
+
+// validHeaderToken accepts printable ASCII only, so that the value can be
+// logged and echoed back safely.
+func validHeaderToken(value string, maxLength int) bool {
+	if value == "" || len(value) > maxLength {
+		return false
+	}
+
+	for i := 0; i < len(value); i++ {
+		if value[i] < 0x21 || value[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
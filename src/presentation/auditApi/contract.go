@@ -0,0 +1,5 @@
+package auditApi
+
+type auditQuery struct {
+	EntityID string `form:"entity_id" binding:"required"`
+}
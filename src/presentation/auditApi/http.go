@@ -0,0 +1,60 @@
+package auditApi
+
+import (
+	"net/http"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type auditApi struct {
+	auditService interfaces.AuditService
+}
+
+func RegisterAuditRoutes(app *gin.Engine, authMiddleware middleware.AuthMiddleware, auditService interfaces.AuditService) {
+	service := auditApi{
+		auditService: auditService,
+	}
+
+	app.GET("/audit", authMiddleware.Auth, authMiddleware.Admin, service.search)
+}
+
+// Create godoc
+// @Summary Search Audit Records
+// @Description List the changes made to a vehicle or sale, oldest first. Requires the admin role.
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entity_id query string true "Vehicle or sale ID"
+// @Success 200 {array} responses.AuditRecord
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /audit [get]
+func (ref *auditApi) search(ctx *gin.Context) {
+	var query auditQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	records, err := ref.auditService.Search(ctx, query.EntityID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	response := make([]responses.AuditRecord, 0, len(records))
+	for _, record := range records {
+		response = append(response, responses.AuditRecordFromDomain(record))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// formulaPrefixes are the characters spreadsheets read a formula from.
+const formulaPrefixes = "=+-@"
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{
+		writer: csv.NewWriter(w),
+	}
+}
+
+func (ref *csvWriter) Write(row []any) error {
+	record := make([]string, len(row))
+
+	for i, value := range row {
+		record[i] = formatCell(value)
+
+		if _, ok := value.(string); ok {
+			record[i] = escapeFormula(record[i])
+		}
+	}
+
+	return ref.writer.Write(record)
+}
+
+func (ref *csvWriter) Close() error {
+	ref.writer.Flush()
+	return ref.writer.Error()
+}
+
+// escapeFormula prefixes text that a spreadsheet would run as a formula, so
+// it is shown as typed. Numbers are left alone, they are never formulas.
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+		return "'" + cell
+	}
+
+	return cell
+}
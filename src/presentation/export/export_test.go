@@ -0,0 +1,100 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWriter(t *testing.T) {
+	t.Run("should not create writer for unsupported format", func(t *testing.T) {
+		writer, err := NewWriter("pdf", io.Discard)
+		assert.Nil(t, writer)
+		assert.EqualError(t, err, "unsupported export format")
+	})
+
+	t.Run("should write csv rows", func(t *testing.T) {
+		soldAt := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+		var buffer bytes.Buffer
+
+		writer, err := NewWriter(FormatCSV, &buffer)
+		require.NoError(t, err)
+
+		assert.NoError(t, writer.Write([]any{"brand", "price", "year", "sold_at"}))
+		assert.NoError(t, writer.Write([]any{"Ford, Inc", 50000.5, 2022, &soldAt}))
+		assert.NoError(t, writer.Write([]any{"Fiat", 35000.0, 2015, (*time.Time)(nil)}))
+		assert.NoError(t, writer.Close())
+
+		expected := "brand,price,year,sold_at\n" +
+			"\"Ford, Inc\",50000.5,2022,2025-03-10T12:00:00Z\n" +
+			"Fiat,35000,2015,\n"
+
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	t.Run("should escape csv formulas", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		writer, err := NewWriter(FormatCSV, &buffer)
+		require.NoError(t, err)
+
+		assert.NoError(t, writer.Write([]any{"=HYPERLINK(\"x\")", "+1", "-1", "@SUM(A1)", "Ford", -1500.0}))
+		assert.NoError(t, writer.Close())
+
+		expected := "\"'=HYPERLINK(\"\"x\"\")\",'+1,'-1,'@SUM(A1),Ford,-1500\n"
+
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	t.Run("should write xlsx workbook", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		writer, err := NewWriter(FormatXLSX, &buffer)
+		require.NoError(t, err)
+
+		assert.NoError(t, writer.Write([]any{"brand", "price"}))
+		assert.NoError(t, writer.Write([]any{"Ford <Ka>", 50000.0}))
+		assert.NoError(t, writer.Close())
+
+		archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		require.NoError(t, err)
+
+		names := make([]string, len(archive.File))
+		for i, file := range archive.File {
+			names[i] = file.Name
+		}
+
+		assert.Equal(t, []string{
+			"[Content_Types].xml",
+			"_rels/.rels",
+			"xl/workbook.xml",
+			"xl/_rels/workbook.xml.rels",
+			"xl/worksheets/sheet1.xml",
+		}, names)
+
+		sheet, err := archive.File[4].Open()
+		require.NoError(t, err)
+		defer sheet.Close()
+
+		content, err := io.ReadAll(sheet)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(content), `<row r="1"><c r="A1" t="inlineStr"><is><t>brand</t></is></c><c r="B1" t="inlineStr"><is><t>price</t></is></c></row>`)
+		assert.Contains(t, string(content), `<row r="2"><c r="A2" t="inlineStr"><is><t>Ford &lt;Ka&gt;</t></is></c><c r="B2"><v>50000</v></c></row>`)
+		assert.Contains(t, string(content), `</sheetData></worksheet>`)
+	})
+}
+
+func Test_columnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AZ", columnName(51))
+	assert.Equal(t, "BA", columnName(52))
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+// xlsxWriter writes a single sheet workbook. Every part but the sheet is
+// static, so the sheet is the last zip entry and rows are streamed into it.
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	rows    int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	archive := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = io.WriteString(file, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = io.WriteString(sheet, xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{
+		archive: archive,
+		sheet:   sheet,
+	}, nil
+}
+
+func (ref *xlsxWriter) Write(row []any) error {
+	ref.rows++
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, `<row r="%d">`, ref.rows)
+
+	for i, value := range row {
+		reference := columnName(i) + strconv.Itoa(ref.rows)
+
+		switch v := value.(type) {
+		case int:
+			fmt.Fprintf(&builder, `<c r="%s"><v>%d</v></c>`, reference, v)
+		case float64:
+			fmt.Fprintf(&builder, `<c r="%s"><v>%s</v></c>`, reference, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			fmt.Fprintf(&builder, `<c r="%s" t="inlineStr"><is><t>`, reference)
+			if err := xml.EscapeText(&builder, []byte(formatCell(value))); err != nil {
+				return err
+			}
+			builder.WriteString(`</t></is></c>`)
+		}
+	}
+
+	builder.WriteString(`</row>`)
+
+	_, err := io.WriteString(ref.sheet, builder.String())
+	return err
+}
+
+func (ref *xlsxWriter) Close() error {
+	if _, err := io.WriteString(ref.sheet, xlsxSheetFooter); err != nil {
+		return err
+	}
+
+	return ref.archive.Close()
+}
+
+// columnName converts a zero based column index to its spreadsheet name
+// (0 → A, 25 → Z, 26 → AA).
+func columnName(index int) string {
+	name := ""
+
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
@@ -0,0 +1,57 @@
+package healthApi
+
+import (
+	"net/http"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/gin-gonic/gin"
+)
+
+type healthApi struct {
+	healthService interfaces.HealthService
+}
+
+func RegisterHealthRoutes(app *gin.Engine, healthService interfaces.HealthService) {
+	service := healthApi{
+		healthService: healthService,
+	}
+
+	app.GET("/healthz", service.liveness)
+	app.GET("/readyz", service.readiness)
+}
+
+// Create godoc
+// @Summary Liveness
+// @Description Report that the process is running
+// @Tags Health
+// @Produce json
+// @Success 200 {object} responses.HealthReport
+// @Router /healthz [get]
+func (ref *healthApi) liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, responses.HealthReport{
+		Status: entity.HealthStatusUp,
+		Checks: []responses.HealthCheck{},
+	})
+}
+
+// Create godoc
+// @Summary Readiness
+// @Description Report whether the dependencies are reachable and the application can serve traffic
+// @Tags Health
+// @Produce json
+// @Success 200 {object} responses.HealthReport
+// @Failure 503 {object} responses.HealthReport
+// @Router /readyz [get]
+func (ref *healthApi) readiness(ctx *gin.Context) {
+	report := ref.healthService.Readiness(ctx)
+
+	status := http.StatusOK
+	if report.Status != entity.HealthStatusUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	response := responses.HealthReportFromDomain(report)
+	ctx.JSON(status, response)
+}
@@ -0,0 +1,107 @@
+package jobApi
+
+import (
+	"net/http"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type jobApi struct {
+	jobService interfaces.JobService
+}
+
+type jobURI struct {
+	JobID string `uri:"job_id"`
+}
+
+func RegisterJobRoutes(app *gin.Engine, authMiddleware middleware.AuthMiddleware, jobService interfaces.JobService) {
+	service := jobApi{
+		jobService: jobService,
+	}
+
+	app.GET("/jobs/:job_id", authMiddleware.Auth, service.get)
+	app.POST("/jobs/:job_id/cancel", authMiddleware.Auth, service.cancel)
+}
+
+// Create godoc
+// @Summary Get Job
+// @Description Get the status of a background job of the authenticated user
+// @Tags Job
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param job_id path string true "Job ID"
+// @Success 200 {object} responses.Job
+// @Failure 204 {object} responses.ErrorResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /jobs/{job_id} [get]
+func (ref *jobApi) get(ctx *gin.Context) {
+	var uri jobURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	job, err := ref.jobService.GetByID(ctx, uri.JobID, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// Jobs of other users are reported as missing, so that their IDs cannot
+	// be probed.
+	if job == nil {
+		ctx.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	response := responses.JobFromDomain(*job)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Create godoc
+// @Summary Cancel Job
+// @Description Cancel a pending or running background job of the authenticated user
+// @Tags Job
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param job_id path string true "Job ID"
+// @Success 200 {object} responses.Job
+// @Failure 204 {object} responses.ErrorResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /jobs/{job_id}/cancel [post]
+func (ref *jobApi) cancel(ctx *gin.Context) {
+	var uri jobURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	job, err := ref.jobService.Cancel(ctx, uri.JobID, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if job == nil {
+		ctx.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	response := responses.JobFromDomain(*job)
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,10 @@
+package metricsApi
+
+import (
+	"github.com/caiiomp/vehicle-resale-api/src/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterMetricsRoutes(app *gin.Engine) {
+	app.GET("/metrics", gin.WrapH(metrics.Handler()))
+}
@@ -0,0 +1,337 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Apply applies a JSON Patch (RFC 6902) to document. Operations are applied
+// in order, and the document is only returned when all of them succeed.
+func Apply(document, patch []byte) ([]byte, error) {
+	target, err := decode(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []map[string]json.RawMessage
+	if err = json.Unmarshal(patch, &operations); err != nil {
+		return nil, malformed("%v", err)
+	}
+
+	for i, operation := range operations {
+		target, err = applyOperation(target, operation)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func applyOperation(target any, operation map[string]json.RawMessage) (any, error) {
+	var op string
+	if err := member(operation, "op", &op); err != nil {
+		return nil, err
+	}
+
+	var path string
+	if err := member(operation, "path", &path); err != nil {
+		return nil, err
+	}
+
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "add", "replace", "test":
+		raw, ok := operation["value"]
+		if !ok {
+			return nil, malformed("%s requires a value", op)
+		}
+
+		value, err := decode(raw)
+		if err != nil {
+			return nil, malformed("%v", err)
+		}
+
+		switch op {
+		case "add":
+			return add(target, tokens, value)
+		case "replace":
+			if len(tokens) == 0 {
+				return value, nil
+			}
+
+			if target, err = remove(target, tokens); err != nil {
+				return nil, err
+			}
+			return add(target, tokens, value)
+		default:
+			current, err := get(target, tokens)
+			if err != nil {
+				return nil, err
+			}
+
+			if !equal(current, value) {
+				return nil, notApplicable("test of %q failed", path)
+			}
+
+			return target, nil
+		}
+	case "remove":
+		return remove(target, tokens)
+	case "move", "copy":
+		var from string
+		if err := member(operation, "from", &from); err != nil {
+			return nil, err
+		}
+
+		fromTokens, err := parsePointer(from)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := get(target, fromTokens)
+		if err != nil {
+			return nil, err
+		}
+
+		if op == "copy" {
+			if value, err = clone(value); err != nil {
+				return nil, err
+			}
+			return add(target, tokens, value)
+		}
+
+		if strings.HasPrefix(path, from+"/") {
+			return nil, notApplicable("cannot move %q into itself", from)
+		}
+
+		if target, err = remove(target, fromTokens); err != nil {
+			return nil, err
+		}
+
+		return add(target, tokens, value)
+	}
+
+	return nil, malformed("unknown operation %q", op)
+}
+
+func member(operation map[string]json.RawMessage, name string, value *string) error {
+	raw, ok := operation[name]
+	if !ok {
+		return malformed("operation requires %q", name)
+	}
+
+	if err := json.Unmarshal(raw, value); err != nil {
+		return malformed("%q must be a string", name)
+	}
+
+	return nil
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, malformed("pointer %q must start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func get(target any, tokens []string) (any, error) {
+	for _, token := range tokens {
+		switch node := target.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, notApplicable("member %q does not exist", token)
+			}
+			target = value
+		case []any:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			target = node[index]
+		default:
+			return nil, notApplicable("%q is not inside an object or array", token)
+		}
+	}
+
+	return target, nil
+}
+
+func add(target any, tokens []string, value any) (any, error) {
+	return update(target, tokens, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			index := len(node)
+			if token != "-" {
+				var err error
+				if index, err = arrayIndex(token, len(node)); err != nil {
+					return nil, err
+				}
+			}
+
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+
+			return node, nil
+		}
+
+		return nil, notApplicable("cannot add %q outside an object or array", token)
+	}, value)
+}
+
+func remove(target any, tokens []string) (any, error) {
+	if len(tokens) == 0 {
+		return nil, notApplicable("cannot remove the whole document")
+	}
+
+	return update(target, tokens, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			if _, ok := node[token]; !ok {
+				return nil, notApplicable("member %q does not exist", token)
+			}
+			delete(node, token)
+			return node, nil
+		case []any:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:index], node[index+1:]...), nil
+		}
+
+		return nil, notApplicable("cannot remove %q outside an object or array", token)
+	}, nil)
+}
+
+// update applies change to the container holding the last token and stores
+// the returned container back into its own parent, since arrays may be
+// reallocated. An empty pointer replaces the whole document with root.
+func update(target any, tokens []string, change func(parent any, token string) (any, error), root any) (any, error) {
+	if len(tokens) == 0 {
+		return root, nil
+	}
+
+	if len(tokens) == 1 {
+		return change(target, tokens[0])
+	}
+
+	child, err := get(target, tokens[:1])
+	if err != nil {
+		return nil, err
+	}
+
+	child, err = update(child, tokens[1:], change, root)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node := target.(type) {
+	case map[string]any:
+		node[tokens[0]] = child
+	case []any:
+		index, _ := arrayIndex(tokens[0], len(node)-1)
+		node[index] = child
+	}
+
+	return target, nil
+}
+
+// arrayIndex parses an array index token, which must be at most maxIndex.
+func arrayIndex(token string, maxIndex int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.Trim(token, "0123456789") != "" {
+		return 0, notApplicable("%q is not an array index", token)
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index > maxIndex {
+		return 0, notApplicable("array index %s is out of bounds", token)
+	}
+
+	return index, nil
+}
+
+func clone(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode(data)
+}
+
+// equal compares JSON values, with numbers compared by value.
+func equal(a, b any) bool {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+
+		for name, value := range x {
+			other, ok := y[name]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+
+		return true
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+
+		first, _, err := big.ParseFloat(string(x), 10, 256, big.ToNearestEven)
+		if err != nil {
+			return false
+		}
+
+		second, _, err := big.ParseFloat(string(y), 10, 256, big.ToNearestEven)
+		if err != nil {
+			return false
+		}
+
+		return first.Cmp(second) == 0
+	}
+
+	return a == b
+}
@@ -0,0 +1,43 @@
+package patch
+
+import "encoding/json"
+
+// Merge applies a JSON Merge Patch (RFC 7396) to document: members of the
+// patch replace those of the document, objects are merged recursively and
+// null removes a member.
+func Merge(document, patch []byte) ([]byte, error) {
+	target, err := decode(document)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := decode(patch)
+	if err != nil {
+		return nil, malformed("%v", err)
+	}
+
+	return json.Marshal(merge(target, changes))
+}
+
+func merge(target, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	object, ok := target.(map[string]any)
+	if !ok {
+		object = map[string]any{}
+	}
+
+	for name, value := range changes {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+
+		object[name] = merge(object[name], value)
+	}
+
+	return object
+}
@@ -0,0 +1,47 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	ContentTypeMergePatch = "application/merge-patch+json"
+	ContentTypeJSONPatch  = "application/json-patch+json"
+)
+
+var (
+	// ErrMalformed is returned for patches that are not valid JSON or do not
+	// follow their RFC.
+	ErrMalformed = errors.New("malformed patch")
+	// ErrNotApplicable is returned for valid patches that cannot be applied
+	// to the document, such as a failed test or a missing path.
+	ErrNotApplicable = errors.New("patch cannot be applied")
+)
+
+// decode keeps numbers as written, so that untouched values are not rounded.
+func decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+
+	return value, nil
+}
+
+func malformed(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrMalformed, fmt.Sprintf(format, args...))
+}
+
+func notApplicable(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrNotApplicable, fmt.Sprintf(format, args...))
+}
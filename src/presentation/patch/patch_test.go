@@ -0,0 +1,112 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	// Examples from RFC 7396, appendix A.
+	tests := []struct {
+		document string
+		patch    string
+		expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{`{"price":50000.10}`, `{"color":"Preto"}`, `{"price":50000.10,"color":"Preto"}`},
+	}
+
+	for _, test := range tests {
+		actual, err := Merge([]byte(test.document), []byte(test.patch))
+		require.NoError(t, err, test.patch)
+		assert.JSONEq(t, test.expected, string(actual), test.patch)
+	}
+
+	t.Run("should reject invalid patch", func(t *testing.T) {
+		_, err := Merge([]byte(`{}`), []byte(`{"a":`))
+		assert.ErrorIs(t, err, ErrMalformed)
+	})
+}
+
+func TestApply(t *testing.T) {
+	// Examples from RFC 6902, appendix A.
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{"add object member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"remove object member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replace value", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"move value", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"move array element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"test value", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{"add nested member", `{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+		{"escape pointer", `{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"remove","path":"/~1"}]`, `{"~1":10}`},
+		{"add to end of array", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{"copy value", `{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"replace","path":"/baz/bar","value":2}]`, `{"foo":{"bar":1},"baz":{"bar":2}}`},
+		{"set null", `{"color":"Preto"}`, `[{"op":"replace","path":"/color","value":null}]`, `{"color":null}`},
+		{"compare numbers by value", `{"price":1.0}`, `[{"op":"test","path":"/price","value":1}]`, `{"price":1.0}`},
+	}
+
+	for _, test := range tests {
+		t.Run("should "+test.name, func(t *testing.T) {
+			actual, err := Apply([]byte(test.document), []byte(test.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(actual))
+		})
+	}
+
+	failures := []struct {
+		name     string
+		document string
+		patch    string
+		expected error
+	}{
+		{"missing target", `{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, ErrNotApplicable},
+		{"failed test", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, ErrNotApplicable},
+		{"remove missing member", `{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, ErrNotApplicable},
+		{"replace missing member", `{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`, ErrNotApplicable},
+		{"index out of bounds", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/2","value":1}]`, ErrNotApplicable},
+		{"leading zero index", `{"foo":["bar","baz"]}`, `[{"op":"remove","path":"/foo/01"}]`, ErrNotApplicable},
+		{"move into itself", `{"foo":{"bar":1}}`, `[{"op":"move","from":"/foo","path":"/foo/bar/baz"}]`, ErrNotApplicable},
+		{"unknown operation", `{}`, `[{"op":"merge","path":"/foo","value":1}]`, ErrMalformed},
+		{"missing value", `{}`, `[{"op":"add","path":"/foo"}]`, ErrMalformed},
+		{"missing path", `{}`, `[{"op":"remove"}]`, ErrMalformed},
+		{"invalid pointer", `{}`, `[{"op":"add","path":"foo","value":1}]`, ErrMalformed},
+		{"patch that is not an array", `{}`, `{"op":"add","path":"/foo","value":1}`, ErrMalformed},
+	}
+
+	for _, test := range failures {
+		t.Run("should fail on "+test.name, func(t *testing.T) {
+			_, err := Apply([]byte(test.document), []byte(test.patch))
+			assert.ErrorIs(t, err, test.expected)
+		})
+	}
+
+	t.Run("should not apply any operation when one fails", func(t *testing.T) {
+		document := `{"foo":"bar"}`
+
+		_, err := Apply([]byte(document), []byte(`[{"op":"add","path":"/baz","value":1},{"op":"test","path":"/foo","value":"qux"}]`))
+		assert.ErrorIs(t, err, ErrNotApplicable)
+		assert.EqualError(t, err, `operation 1: patch cannot be applied: test of "/foo" failed`)
+	})
+}
@@ -0,0 +1,128 @@
+package paymentApi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+const signatureHeader = "X-Payment-Signature"
+
+type paymentApi struct {
+	paymentService interfaces.PaymentService
+}
+
+type paymentURI struct {
+	PaymentID string `uri:"payment_id"`
+}
+
+func RegisterPaymentRoutes(app *gin.Engine, authMiddleware middleware.AuthMiddleware, paymentService interfaces.PaymentService) {
+	service := paymentApi{
+		paymentService: paymentService,
+	}
+
+	app.GET("/payments/:payment_id", authMiddleware.Auth, service.get)
+	app.POST("/payments/webhook", service.webhook)
+}
+
+// Create godoc
+// @Summary Get Payment
+// @Description Get the payment of a purchase made by the user
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param payment_id path string true "Payment ID"
+// @Success 200 {object} responses.Payment
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /payments/{payment_id} [get]
+func (ref *paymentApi) get(ctx *gin.Context) {
+	var uri paymentURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	payment, err := ref.paymentService.GetByID(ctx, uri.PaymentID, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// Payments of other users are reported as missing, so that their IDs
+	// cannot be probed.
+	if payment == nil {
+		ctx.JSON(http.StatusNotFound, responses.ErrorResponse{
+			Error: "payment not found",
+		})
+		return
+	}
+
+	response := responses.PaymentFromDomain(*payment)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Create godoc
+// @Summary Payment webhook
+// @Description Receive payment confirmations from the payment gateway
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param X-Payment-Signature header string true "HMAC-SHA256 signature of the body"
+// @Success 200 {object} responses.Payment
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /payments/webhook [post]
+func (ref *paymentApi) webhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// Finalizing a purchase spans several writes; it must not stop halfway
+	// because the gateway dropped the connection.
+	payment, err := ref.paymentService.HandleWebhook(context.WithoutCancel(ctx), payload, ctx.GetHeader(signatureHeader))
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidPaymentSignature) {
+			ctx.JSON(http.StatusUnauthorized, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		// Retrying cannot make the payment exist, so the gateway is not
+		// asked to.
+		if errors.Is(err, entity.ErrPaymentNotFound) {
+			ctx.JSON(http.StatusNotFound, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	response := responses.PaymentFromDomain(*payment)
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,25 @@
+package reportApi
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type salesReportQuery struct {
+	GroupBy string     `form:"group_by" binding:"required,oneof=day week month brand model seller"`
+	From    *time.Time `form:"from" time_format:"2006-01-02"`
+	To      *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+func (ref salesReportQuery) ToDomain() entity.SalesReportFilter {
+	return entity.SalesReportFilter{
+		GroupBy: ref.GroupBy,
+		From:    ref.From,
+		To:      ref.To,
+	}
+}
+
+type inventoryAgingQuery struct {
+	Limit int `form:"limit,default=10" binding:"min=1,max=100"`
+}
@@ -0,0 +1,30 @@
+package reportApi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_salesReportQueryToDomain(t *testing.T) {
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	query := salesReportQuery{
+		GroupBy: entity.SalesReportGroupByMonth,
+		From:    &from,
+		To:      &to,
+	}
+
+	expected := entity.SalesReportFilter{
+		GroupBy: entity.SalesReportGroupByMonth,
+		From:    &from,
+		To:      &to,
+	}
+
+	actual := query.ToDomain()
+
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,93 @@
+package reportApi
+
+import (
+	"net/http"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type reportApi struct {
+	saleService    interfaces.SaleService
+	vehicleService interfaces.VehicleService
+}
+
+func RegisterReportRoutes(app *gin.Engine, authMiddleware middleware.AuthMiddleware, saleService interfaces.SaleService, vehicleService interfaces.VehicleService) {
+	service := reportApi{
+		saleService:    saleService,
+		vehicleService: vehicleService,
+	}
+
+	app.GET("/reports/sales", authMiddleware.Auth, service.sales)
+	app.GET("/reports/inventory-aging", authMiddleware.Auth, service.inventoryAging)
+}
+
+// Create godoc
+// @Summary Sales report
+// @Description Revenue net of trade-in credits, sales count, average ticket and average days on market grouped by period, brand, model or seller
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string true "Grouping" Enums(day, week, month, brand, model, seller)
+// @Param from query string false "Sold on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Sold before this date (YYYY-MM-DD)"
+// @Success 200 {object} responses.SalesReport
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /reports/sales [get]
+func (ref *reportApi) sales(ctx *gin.Context) {
+	var query salesReportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	report, err := ref.saleService.Report(ctx, query.ToDomain())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	response := responses.SalesReportFromDomain(*report)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Create godoc
+// @Summary Inventory aging report
+// @Description Unsold vehicles bucketed by days listed, with capital tied up per bucket and brand and the oldest listings
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of oldest listings to return" default(10)
+// @Success 200 {object} responses.InventoryAgingReport
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /reports/inventory-aging [get]
+func (ref *reportApi) inventoryAging(ctx *gin.Context) {
+	var query inventoryAgingQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	report, err := ref.vehicleService.InventoryAging(ctx, query.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	response := responses.InventoryAgingReportFromDomain(*report)
+	ctx.JSON(http.StatusOK, response)
+}
@@ -1,7 +1,25 @@
 package presentation
 
-import "github.com/gin-gonic/gin"
+import (
+	"io"
 
-func SetupServer() *gin.Engine {
-	return gin.Default()
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupServer creates the engine with the middlewares every route shares.
+// The given middlewares run inside the logger but outside the recovery, so
+// that they see the 500 a panicking handler ends with.
+func SetupServer(middlewares ...gin.HandlerFunc) *gin.Engine {
+	app := gin.New()
+
+	// Handlers pass the *gin.Context to the services, so it must expose the
+	// values and cancellation of the request context, such as trace spans.
+	app.ContextWithFallback = true
+
+	app.Use(middleware.RequestID, middleware.Logger)
+	app.Use(middlewares...)
+	app.Use(gin.CustomRecoveryWithWriter(io.Discard, middleware.Recovery))
+
+	return app
 }
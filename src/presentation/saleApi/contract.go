@@ -0,0 +1,26 @@
+package saleApi
+
+import (
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type exportSaleQuery struct {
+	Format string `form:"format,default=csv" binding:"oneof=csv xlsx"`
+}
+
+var saleExportHeader = []any{
+	"id", "vehicle_id", "user_id", "price", "trade_in_vehicle_id", "trade_in_credit", "net_price", "sold_at",
+}
+
+func saleExportRow(sale entity.Sale) []any {
+	return []any{
+		sale.ID,
+		sale.VehicleID,
+		sale.UserID,
+		sale.Price,
+		sale.TradeInVehicleID,
+		sale.TradeInCredit,
+		sale.NetPrice,
+		sale.SoldAt,
+	}
+}
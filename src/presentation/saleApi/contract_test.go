@@ -0,0 +1,31 @@
+package saleApi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_saleExportRow(t *testing.T) {
+	now := time.Now()
+
+	sale := entity.Sale{
+		ID:               "some-id",
+		VehicleID:        "some-vehicle",
+		UserID:           "some-user",
+		Price:            80000,
+		TradeInVehicleID: "some-trade-in",
+		TradeInCredit:    20000,
+		NetPrice:         60000,
+		SoldAt:           now,
+	}
+
+	expected := []any{"some-id", "some-vehicle", "some-user", 80000.0, "some-trade-in", 20000.0, 60000.0, now}
+
+	actual := saleExportRow(sale)
+
+	assert.Equal(t, expected, actual)
+	assert.Len(t, saleExportHeader, len(actual))
+}
@@ -1,10 +1,14 @@
 package saleApi
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
 	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/export"
 	"github.com/gin-gonic/gin"
 )
 
@@ -18,6 +22,7 @@ func RegisterSaleRoutes(app *gin.Engine, saleService interfaces.SaleService) {
 	}
 
 	app.GET("/sales", service.search)
+	app.GET("/sales/export", service.export)
 }
 
 // Create godoc
@@ -46,3 +51,65 @@ func (ref *saleApi) search(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, response)
 }
+
+// Create godoc
+// @Summary Export sales
+// @Description Export sales as a CSV or XLSX file
+// @Tags Sale
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "File format" Enums(csv, xlsx) default(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /sales/export [get]
+func (ref *saleApi) export(ctx *gin.Context) {
+	var query exportSaleQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// The XLSX writer writes the static parts of the workbook right away, so
+	// the headers have to be set before it is created.
+	ctx.Header("Content-Type", export.ContentType(query.Format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="sales.%s"`, query.Format))
+
+	writer, err := export.NewWriter(query.Format, ctx.Writer)
+	if err != nil {
+		ctx.Header("Content-Disposition", "")
+		ctx.Header("Content-Type", "")
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// Exports can take longer than the server write timeout. Writers that do
+	// not support deadlines, like test recorders, have none to clear.
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	err = writer.Write(saleExportHeader)
+	if err == nil {
+		err = ref.saleService.Iterate(ctx, func(sale entity.Sale) error {
+			return writer.Write(saleExportRow(sale))
+		})
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err != nil {
+		// Once rows have been flushed the status is already sent and the
+		// client only sees a truncated file.
+		if !ctx.Writer.Written() {
+			ctx.Header("Content-Disposition", "")
+			ctx.Header("Content-Type", "")
+			ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+	}
+}
@@ -1,7 +1,28 @@
 package vehicleApi
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/patch"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	importFormatCSV    = "csv"
+	importFormatNDJSON = "ndjson"
+
+	maxImportRows = 10000
 )
 
 type createVehicleRequest struct {
@@ -26,15 +47,34 @@ type vehicleURI struct {
 	VehicleID string `uri:"vehicle_id"`
 }
 
-type updateVehicleRequest struct {
-	Brand string  `json:"brand"`
-	Model string  `json:"model"`
-	Year  int     `json:"year"`
+// errInvalidVehicleDocument is returned when a patch produces a vehicle that
+// is not valid.
+var errInvalidVehicleDocument = errors.New("invalid vehicle")
+
+var errUnsupportedPatch = errors.New("content type must be application/json, " + patch.ContentTypeMergePatch + " or " + patch.ContentTypeJSONPatch)
+
+// vehicleDocument is the part of a vehicle that PATCH requests change. Absent
+// or null members are cleared, so required ones cannot be removed. The status
+// is left out, since it changes through purchases and publishing only.
+type vehicleDocument struct {
+	Brand string  `json:"brand" binding:"required"`
+	Model string  `json:"model" binding:"required"`
+	Year  int     `json:"year" binding:"gt=0"`
 	Color string  `json:"color"`
-	Price float64 `json:"price"`
+	Price float64 `json:"price" binding:"gte=0"`
 }
 
-func (ref updateVehicleRequest) ToDomain() *entity.Vehicle {
+func vehicleDocumentFromDomain(vehicle entity.Vehicle) vehicleDocument {
+	return vehicleDocument{
+		Brand: vehicle.Brand,
+		Model: vehicle.Model,
+		Year:  vehicle.Year,
+		Color: vehicle.Color,
+		Price: vehicle.Price,
+	}
+}
+
+func (ref vehicleDocument) ToDomain() *entity.Vehicle {
 	return &entity.Vehicle{
 		Brand: ref.Brand,
 		Model: ref.Model,
@@ -44,6 +84,350 @@ func (ref updateVehicleRequest) ToDomain() *entity.Vehicle {
 	}
 }
 
+// applyVehiclePatch applies a JSON Merge Patch, or a JSON Patch when the
+// content type says so, to the document of current. Plain JSON is read as a
+// merge patch.
+func applyVehiclePatch(current entity.Vehicle, contentType string, body []byte) (*entity.Vehicle, error) {
+	document, err := json.Marshal(vehicleDocumentFromDomain(current))
+	if err != nil {
+		return nil, err
+	}
+
+	switch contentType {
+	case "", binding.MIMEJSON, patch.ContentTypeMergePatch:
+		document, err = patch.Merge(document, body)
+	case patch.ContentTypeJSONPatch:
+		document, err = patch.Apply(document, body)
+	default:
+		return nil, errUnsupportedPatch
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.DisallowUnknownFields()
+
+	var patched vehicleDocument
+	if err = decoder.Decode(&patched); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidVehicleDocument, err)
+	}
+
+	if err = binding.Validator.ValidateStruct(&patched); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidVehicleDocument, err)
+	}
+
+	vehicle := patched.ToDomain()
+	vehicle.Status = current.Status
+
+	return vehicle, nil
+}
+
+func patchErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errUnsupportedPatch):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, patch.ErrMalformed):
+		return http.StatusBadRequest
+	case errors.Is(err, patch.ErrNotApplicable):
+		return http.StatusConflict
+	case errors.Is(err, errInvalidVehicleDocument):
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
+
+// vehicleETag identifies the version of a vehicle.
+func vehicleETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseVehicleETag reads the version from an If-Match header. Weak tags are
+// not accepted, since If-Match uses the strong comparison.
+func parseVehicleETag(value string) (int64, bool) {
+	value = strings.TrimSpace(value)
+
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return 0, false
+	}
+
+	version, err := strconv.ParseInt(value[1:len(value)-1], 10, 64)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// matchesETag reports whether an If-None-Match header lists etag, using the
+// weak comparison.
+func matchesETag(header, etag string) bool {
+	for _, value := range strings.Split(header, ",") {
+		value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+
+		if value == "*" || value == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// streamHeartbeatInterval keeps idle streams from being closed by proxies.
+const streamHeartbeatInterval = 15 * time.Second
+
+// deletedQuery lets admins see deleted vehicles.
+type deletedQuery struct {
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
 type vehicleQuery struct {
+	deletedQuery
 	IsSold *bool `form:"is_sold"`
 }
+
+// streamQuery has no include_deleted: the stream sends deleted vehicles only
+// in their VehicleDeleted event.
+type streamQuery struct {
+	IsSold *bool `form:"is_sold"`
+}
+
+type getVehicleQuery struct {
+	deletedQuery
+	AsOf *time.Time `form:"as_of" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+func (ref vehicleQuery) ToDomain() entity.VehicleFilter {
+	return entity.VehicleFilter{
+		IsSold:         ref.IsSold,
+		IncludeDeleted: ref.IncludeDeleted,
+	}
+}
+
+type exportVehicleQuery struct {
+	vehicleQuery
+	Format string `form:"format,default=csv" binding:"oneof=csv xlsx"`
+}
+
+var vehicleExportHeader = []any{
+	"id", "brand", "model", "year", "color", "price", "status", "seller_id", "sold_at", "created_at", "updated_at",
+}
+
+func vehicleExportRow(vehicle entity.Vehicle) []any {
+	return []any{
+		vehicle.ID,
+		vehicle.Brand,
+		vehicle.Model,
+		vehicle.Year,
+		vehicle.Color,
+		vehicle.Price,
+		vehicle.Status,
+		vehicle.SellerID,
+		vehicle.SoldAt,
+		vehicle.CreatedAt,
+		vehicle.UpdatedAt,
+	}
+}
+
+type buyVehicleRequest struct {
+	TradeIn *tradeInRequest `json:"trade_in"`
+}
+
+type tradeInRequest struct {
+	Brand     string  `json:"brand" binding:"required"`
+	Model     string  `json:"model" binding:"required"`
+	Year      int     `json:"year" binding:"required"`
+	Color     string  `json:"color" binding:"required"`
+	Valuation float64 `json:"valuation" binding:"required,gt=0"`
+}
+
+func (ref buyVehicleRequest) ToDomain() *entity.TradeIn {
+	if ref.TradeIn == nil {
+		return nil
+	}
+
+	return &entity.TradeIn{
+		Brand:     ref.TradeIn.Brand,
+		Model:     ref.TradeIn.Model,
+		Year:      ref.TradeIn.Year,
+		Color:     ref.TradeIn.Color,
+		Valuation: ref.TradeIn.Valuation,
+	}
+}
+
+func buyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrVehicleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrVehicleSold), errors.Is(err, entity.ErrVehicleReserved),
+		errors.Is(err, entity.ErrVehicleNotForSale), errors.Is(err, entity.ErrVehicleVersionMismatch):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrInvalidTradeInValuation), errors.Is(err, entity.ErrTradeInExceedsPrice):
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
+
+type importVehiclesQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
+	DryRun bool   `form:"dry_run"`
+}
+
+type importJobURI struct {
+	JobID string `uri:"job_id"`
+}
+
+var importCSVColumns = []string{"brand", "model", "year", "color", "price"}
+
+// parseVehicleImport reads every row of an import file. Rows that cannot be
+// parsed or fail the createVehicleRequest validation are returned with an
+// error instead of a vehicle, so that the job can report them all at once.
+func parseVehicleImport(format string, reader io.Reader) ([]entity.ImportRow, error) {
+	switch format {
+	case importFormatCSV:
+		return parseVehicleImportCSV(reader)
+	case importFormatNDJSON:
+		return parseVehicleImportNDJSON(reader)
+	}
+
+	return nil, errors.New("unsupported import format")
+}
+
+func parseVehicleImportCSV(reader io.Reader) ([]entity.ImportRow, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, errors.New("import file has no header")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range importCSVColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("import file is missing column %q", column)
+		}
+	}
+
+	rows := make([]entity.ImportRow, 0)
+
+	for number := 1; ; number++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rows) == maxImportRows {
+			return nil, fmt.Errorf("import file exceeds %d rows", maxImportRows)
+		}
+
+		value := func(column string) string {
+			index := columns[column]
+			if index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		request := createVehicleRequest{
+			Brand: value("brand"),
+			Model: value("model"),
+			Color: value("color"),
+		}
+
+		if year := value("year"); year != "" {
+			if request.Year, err = strconv.Atoi(year); err != nil {
+				rows = append(rows, entity.ImportRow{Row: number, Error: fmt.Sprintf("invalid year %q", year)})
+				continue
+			}
+		}
+
+		if price := value("price"); price != "" {
+			if request.Price, err = strconv.ParseFloat(price, 64); err != nil {
+				rows = append(rows, entity.ImportRow{Row: number, Error: fmt.Sprintf("invalid price %q", price)})
+				continue
+			}
+		}
+
+		rows = append(rows, validateImportRow(number, request))
+	}
+
+	return rows, nil
+}
+
+func parseVehicleImportNDJSON(reader io.Reader) ([]entity.ImportRow, error) {
+	scanner := bufio.NewScanner(reader)
+
+	rows := make([]entity.ImportRow, 0)
+
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if len(rows) == maxImportRows {
+			return nil, fmt.Errorf("import file exceeds %d rows", maxImportRows)
+		}
+
+		var request createVehicleRequest
+		if err := json.Unmarshal([]byte(line), &request); err != nil {
+			rows = append(rows, entity.ImportRow{Row: number, Error: err.Error()})
+			continue
+		}
+
+		rows = append(rows, validateImportRow(number, request))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func validateImportRow(number int, request createVehicleRequest) entity.ImportRow {
+	if err := binding.Validator.ValidateStruct(request); err != nil {
+		return entity.ImportRow{Row: number, Error: err.Error()}
+	}
+
+	return entity.ImportRow{Row: number, Vehicle: request.ToDomain()}
+}
+
+// importFormat resolves the format of an upload from the query string, the
+// file extension or the content type, in this order.
+func importFormat(query, filename, contentType string) string {
+	if query != "" {
+		return query
+	}
+
+	switch {
+	case strings.HasSuffix(strings.ToLower(filename), ".csv"):
+		return importFormatCSV
+	case strings.HasSuffix(strings.ToLower(filename), ".ndjson"), strings.HasSuffix(strings.ToLower(filename), ".jsonl"):
+		return importFormatNDJSON
+	case strings.HasPrefix(contentType, "text/csv"):
+		return importFormatCSV
+	case strings.HasPrefix(contentType, "application/x-ndjson"), strings.HasPrefix(contentType, "application/ndjson"):
+		return importFormatNDJSON
+	}
+
+	return ""
+}
+
+func writeStreamEvent(w io.Writer, event entity.VehicleStreamEvent) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+}
@@ -1,10 +1,17 @@
 package vehicleApi
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/patch"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_createVehicleRequestToDomain(t *testing.T) {
@@ -29,8 +36,8 @@ func Test_createVehicleRequestToDomain(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
-func Test_updateVehicleRequestToDomain(t *testing.T) {
-	request := updateVehicleRequest{
+func Test_vehicleDocumentToDomain(t *testing.T) {
+	request := vehicleDocument{
 		Brand: "Some Brand",
 		Model: "Some Model",
 		Year:  2025,
@@ -50,3 +57,274 @@ func Test_updateVehicleRequestToDomain(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func Test_applyVehiclePatch(t *testing.T) {
+	current := entity.Vehicle{
+		ID:      "vehicle-123",
+		Brand:   "Ford",
+		Model:   "Ka",
+		Year:    2022,
+		Color:   "Preto",
+		Price:   50000,
+		Status:  entity.VehicleStatusAvailable,
+		Version: 3,
+	}
+
+	t.Run("should clear field and set zero with merge patch", func(t *testing.T) {
+		actual, err := applyVehiclePatch(current, patch.ContentTypeMergePatch, []byte(`{"color":null,"price":0}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, &entity.Vehicle{
+			Brand:  "Ford",
+			Model:  "Ka",
+			Year:   2022,
+			Price:  0,
+			Status: entity.VehicleStatusAvailable,
+		}, actual)
+	})
+
+	t.Run("should read plain json as merge patch", func(t *testing.T) {
+		actual, err := applyVehiclePatch(current, "application/json", []byte(`{"price":48000}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "Preto", actual.Color)
+		assert.Equal(t, 48000.0, actual.Price)
+	})
+
+	t.Run("should apply json patch", func(t *testing.T) {
+		actual, err := applyVehiclePatch(current, patch.ContentTypeJSONPatch, []byte(`[
+			{"op":"test","path":"/price","value":50000},
+			{"op":"replace","path":"/price","value":45000},
+			{"op":"remove","path":"/color"}
+		]`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "", actual.Color)
+		assert.Equal(t, 45000.0, actual.Price)
+	})
+
+	t.Run("should reject invalid vehicles", func(t *testing.T) {
+		for _, body := range []string{
+			`{"brand":null}`,
+			`{"year":0}`,
+			`{"price":-1}`,
+			`{"price":"cheap"}`,
+			`{"seller_id":"user-123"}`,
+			`{"status":"sold"}`,
+			`{"status":"available"}`,
+			`["not","a","vehicle"]`,
+		} {
+			_, err := applyVehiclePatch(current, patch.ContentTypeMergePatch, []byte(body))
+			assert.ErrorIs(t, err, errInvalidVehicleDocument, body)
+			assert.Equal(t, http.StatusUnprocessableEntity, patchErrorStatus(err), body)
+		}
+	})
+
+	t.Run("should keep status of reserved vehicle", func(t *testing.T) {
+		reserved := current
+		reserved.Status = entity.VehicleStatusReserved
+
+		actual, err := applyVehiclePatch(reserved, patch.ContentTypeMergePatch, []byte(`{"color":"Branco"}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, entity.VehicleStatusReserved, actual.Status)
+	})
+
+	t.Run("should not change status with json patch", func(t *testing.T) {
+		draft := current
+		draft.Status = entity.VehicleStatusDraft
+
+		_, err := applyVehiclePatch(draft, patch.ContentTypeJSONPatch, []byte(`[{"op":"add","path":"/status","value":"available"}]`))
+		assert.ErrorIs(t, err, errInvalidVehicleDocument)
+	})
+
+	t.Run("should map patch errors to status", func(t *testing.T) {
+		_, err := applyVehiclePatch(current, "text/plain", []byte(`{}`))
+		assert.Equal(t, http.StatusUnsupportedMediaType, patchErrorStatus(err))
+
+		_, err = applyVehiclePatch(current, patch.ContentTypeMergePatch, []byte(`{`))
+		assert.Equal(t, http.StatusBadRequest, patchErrorStatus(err))
+
+		_, err = applyVehiclePatch(current, patch.ContentTypeJSONPatch, []byte(`[{"op":"test","path":"/price","value":1}]`))
+		assert.Equal(t, http.StatusConflict, patchErrorStatus(err))
+	})
+}
+
+func Test_buyErrorStatus(t *testing.T) {
+	for err, expected := range map[error]int{
+		entity.ErrVehicleNotFound:                                   http.StatusNotFound,
+		entity.ErrVehicleSold:                                       http.StatusConflict,
+		entity.ErrVehicleReserved:                                   http.StatusConflict,
+		entity.ErrVehicleNotForSale:                                 http.StatusConflict,
+		fmt.Errorf("reserve: %w", entity.ErrVehicleVersionMismatch): http.StatusConflict,
+		entity.ErrTradeInExceedsPrice:                               http.StatusUnprocessableEntity,
+		errors.New("unexpected error"):                              http.StatusInternalServerError,
+	} {
+		assert.Equal(t, expected, buyErrorStatus(err), err.Error())
+	}
+}
+
+func Test_buyVehicleRequestToDomain(t *testing.T) {
+	t.Run("should return nil when there is no trade-in", func(t *testing.T) {
+		request := buyVehicleRequest{}
+
+		actual := request.ToDomain()
+
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should convert trade-in to domain", func(t *testing.T) {
+		request := buyVehicleRequest{
+			TradeIn: &tradeInRequest{
+				Brand:     "Some Brand",
+				Model:     "Some Model",
+				Year:      2015,
+				Color:     "Black",
+				Valuation: 30000,
+			},
+		}
+
+		expected := &entity.TradeIn{
+			Brand:     "Some Brand",
+			Model:     "Some Model",
+			Year:      2015,
+			Color:     "Black",
+			Valuation: 30000,
+		}
+
+		actual := request.ToDomain()
+
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func Test_vehicleExportRow(t *testing.T) {
+	now := time.Now()
+
+	vehicle := entity.Vehicle{
+		ID:        "some-id",
+		Brand:     "Some Brand",
+		Model:     "Some Model",
+		Year:      2025,
+		Color:     "Gray",
+		Price:     80000,
+		Status:    entity.VehicleStatusSold,
+		SellerID:  "some-seller",
+		SoldAt:    &now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	expected := []any{"some-id", "Some Brand", "Some Model", 2025, "Gray", 80000.0, entity.VehicleStatusSold, "some-seller", &now, now, now}
+
+	actual := vehicleExportRow(vehicle)
+
+	assert.Equal(t, expected, actual)
+	assert.Len(t, vehicleExportHeader, len(actual))
+}
+
+func Test_parseVehicleImport(t *testing.T) {
+	t.Run("should not parse unsupported format", func(t *testing.T) {
+		actual, err := parseVehicleImport("xml", strings.NewReader(""))
+
+		assert.Nil(t, actual)
+		assert.EqualError(t, err, "unsupported import format")
+	})
+
+	t.Run("should not parse csv without required columns", func(t *testing.T) {
+		actual, err := parseVehicleImport(importFormatCSV, strings.NewReader("brand,model,year,color\n"))
+
+		assert.Nil(t, actual)
+		assert.EqualError(t, err, `import file is missing column "price"`)
+	})
+
+	t.Run("should parse csv rows reporting invalid ones", func(t *testing.T) {
+		file := "Price,Brand,Model,Year,Color\n" +
+			"80000,Some Brand,Some Model,2025,Gray\n" +
+			"80000,Some Brand,Some Model,two thousand,Gray\n" +
+			"80000,,Some Model,2025,Gray\n"
+
+		actual, err := parseVehicleImport(importFormatCSV, strings.NewReader(file))
+		require.NoError(t, err)
+		require.Len(t, actual, 3)
+
+		assert.Equal(t, entity.ImportRow{
+			Row: 1,
+			Vehicle: &entity.Vehicle{
+				Brand: "Some Brand",
+				Model: "Some Model",
+				Year:  2025,
+				Color: "Gray",
+				Price: 80000,
+			},
+		}, actual[0])
+
+		assert.Equal(t, entity.ImportRow{Row: 2, Error: `invalid year "two thousand"`}, actual[1])
+
+		assert.Equal(t, 3, actual[2].Row)
+		assert.Nil(t, actual[2].Vehicle)
+		assert.Contains(t, actual[2].Error, "Brand")
+	})
+
+	t.Run("should parse ndjson rows reporting invalid ones", func(t *testing.T) {
+		file := `{"brand":"Some Brand","model":"Some Model","year":2025,"color":"Gray","price":80000}` + "\n" +
+			"\n" +
+			`{"brand":"Some Brand","model":"Some Model","year":2025,"color":"Gray"}` + "\n" +
+			`not json` + "\n"
+
+		actual, err := parseVehicleImport(importFormatNDJSON, strings.NewReader(file))
+		require.NoError(t, err)
+		require.Len(t, actual, 3)
+
+		assert.Equal(t, 1, actual[0].Row)
+		assert.NotNil(t, actual[0].Vehicle)
+
+		assert.Equal(t, 3, actual[1].Row)
+		assert.Nil(t, actual[1].Vehicle)
+		assert.Contains(t, actual[1].Error, "Price")
+
+		assert.Equal(t, 4, actual[2].Row)
+		assert.Nil(t, actual[2].Vehicle)
+		assert.NotEmpty(t, actual[2].Error)
+	})
+}
+
+func Test_importFormat(t *testing.T) {
+	assert.Equal(t, importFormatNDJSON, importFormat(importFormatNDJSON, "vehicles.csv", "text/csv"))
+	assert.Equal(t, importFormatCSV, importFormat("", "vehicles.CSV", "multipart/form-data"))
+	assert.Equal(t, importFormatNDJSON, importFormat("", "vehicles.jsonl", "multipart/form-data"))
+	assert.Equal(t, importFormatCSV, importFormat("", "", "text/csv"))
+	assert.Equal(t, importFormatNDJSON, importFormat("", "", "application/x-ndjson"))
+	assert.Equal(t, "", importFormat("", "", "application/json"))
+}
+
+func Test_writeStreamEvent(t *testing.T) {
+	var builder strings.Builder
+
+	writeStreamEvent(&builder, entity.VehicleStreamEvent{
+		ID:   42,
+		Type: entity.EventVehiclePriceChanged,
+		Data: []byte(`{"id":"vehicle-123","price":75000}`),
+	})
+
+	assert.Equal(t, "id: 42\nevent: VehiclePriceChanged\ndata: {\"id\":\"vehicle-123\",\"price\":75000}\n\n", builder.String())
+}
+
+func Test_parseVehicleETag(t *testing.T) {
+	version, ok := parseVehicleETag(vehicleETag(3))
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), version)
+
+	for _, value := range []string{`W/"3"`, `3`, `"abc"`, `"0"`, `"`} {
+		_, ok = parseVehicleETag(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func Test_matchesETag(t *testing.T) {
+	assert.True(t, matchesETag(`"3"`, `"3"`))
+	assert.True(t, matchesETag(`"1", W/"3"`, `"3"`))
+	assert.True(t, matchesETag(`*`, `"3"`))
+	assert.False(t, matchesETag(`"2"`, `"3"`))
+	assert.False(t, matchesETag(``, `"3"`))
+}
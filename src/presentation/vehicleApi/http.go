@@ -1,11 +1,19 @@
 package vehicleApi
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/actor"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
 	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
 	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/export"
 	"github.com/gin-gonic/gin"
 )
 
@@ -14,17 +22,47 @@ type vehicleApi struct {
 	authMiddleware middleware.AuthMiddleware
 }
 
-func RegisterVehicleRoutes(app *gin.Engine, authMiddleware middleware.AuthMiddleware, vehicleService interfaces.VehicleService) {
+func RegisterVehicleRoutes(app *gin.Engine, authMiddleware middleware.AuthMiddleware, idempotencyMiddleware middleware.IdempotencyMiddleware, vehicleService interfaces.VehicleService) {
 	service := vehicleApi{
 		vehicleService: vehicleService,
 		authMiddleware: authMiddleware,
 	}
 
-	app.POST("/vehicles", authMiddleware.Auth, service.create)
-	app.GET("/vehicles", service.search)
-	app.GET("/vehicles/:vehicle_id", service.get)
+	app.POST("/vehicles", authMiddleware.Auth, idempotencyMiddleware.Idempotent, service.create)
+	app.GET("/vehicles", authMiddleware.OptionalAuth, service.search)
+	app.GET("/vehicles/export", authMiddleware.OptionalAuth, service.export)
+	app.GET("/vehicles/:vehicle_id", authMiddleware.OptionalAuth, service.get)
+	app.GET("/vehicles/:vehicle_id/history", authMiddleware.OptionalAuth, service.history)
 	app.PATCH("/vehicles/:vehicle_id", authMiddleware.Auth, service.update)
-	app.POST("/vehicles/:vehicle_id/buy", authMiddleware.Auth, service.buy)
+	app.DELETE("/vehicles/:vehicle_id", authMiddleware.Auth, service.delete)
+	app.POST("/vehicles/:vehicle_id/restore", authMiddleware.Auth, authMiddleware.Admin, service.restore)
+	app.POST("/vehicles/:vehicle_id/publish", authMiddleware.Auth, authMiddleware.Admin, service.publish)
+	app.POST("/vehicles/:vehicle_id/buy", authMiddleware.Auth, idempotencyMiddleware.Idempotent, service.buy)
+}
+
+type vehicleImportApi struct {
+	vehicleImportService interfaces.VehicleImportService
+}
+
+func RegisterVehicleImportRoutes(app *gin.Engine, authMiddleware middleware.AuthMiddleware, vehicleImportService interfaces.VehicleImportService) {
+	service := vehicleImportApi{
+		vehicleImportService: vehicleImportService,
+	}
+
+	app.POST("/vehicles/import", authMiddleware.Auth, service.importVehicles)
+	app.GET("/imports/:job_id", authMiddleware.Auth, service.get)
+}
+
+type vehicleStreamApi struct {
+	vehicleStreamService interfaces.VehicleStreamService
+}
+
+func RegisterVehicleStreamRoutes(app *gin.Engine, vehicleStreamService interfaces.VehicleStreamService) {
+	service := vehicleStreamApi{
+		vehicleStreamService: vehicleStreamService,
+	}
+
+	app.GET("/vehicles/stream", service.stream)
 }
 
 // Create godoc
@@ -35,9 +73,12 @@ func RegisterVehicleRoutes(app *gin.Engine, authMiddleware middleware.AuthMiddle
 // @Produce json
 // @Security BearerAuth
 // @Param user body vehicleApi.createVehicleRequest true "Body"
+// @Param Idempotency-Key header string false "Key to safely retry the request"
 // @Success 201 {object} responses.Vehicle
 // @Failure 204 {object} responses.ErrorResponse
 // @Failure 400 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
 // @Failure 500 {object} responses.ErrorResponse
 // @Router /vehicles [post]
 func (ref *vehicleApi) create(ctx *gin.Context) {
@@ -49,7 +90,10 @@ func (ref *vehicleApi) create(ctx *gin.Context) {
 		return
 	}
 
-	vehicle, err := ref.vehicleService.Create(ctx, *request.ToDomain())
+	vehicleToCreate := request.ToDomain()
+	vehicleToCreate.SellerID = ctx.GetString("user_id")
+
+	vehicle, err := ref.vehicleService.Create(ctx, *vehicleToCreate)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
 			Error: err.Error(),
@@ -73,8 +117,10 @@ func (ref *vehicleApi) create(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param is_sold query boolean false "Filter vehicles by sold status"
+// @Param include_deleted query boolean false "Include deleted vehicles, for admins"
 // @Success 200 {array} responses.Vehicle
 // @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
 // @Failure 500 {object} responses.ErrorResponse
 // @Router /vehicles [get]
 func (ref *vehicleApi) search(ctx *gin.Context) {
@@ -86,7 +132,11 @@ func (ref *vehicleApi) search(ctx *gin.Context) {
 		return
 	}
 
-	vehicles, err := ref.vehicleService.Search(ctx, query.IsSold)
+	if !allowDeleted(ctx, query.deletedQuery) {
+		return
+	}
+
+	vehicles, err := ref.vehicleService.Search(ctx, query.ToDomain())
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
 			Error: err.Error(),
@@ -103,6 +153,75 @@ func (ref *vehicleApi) search(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// Create godoc
+// @Summary Export vehicles
+// @Description Export vehicles as a CSV or XLSX file
+// @Tags Vehicle
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param is_sold query boolean false "Filter vehicles by sold status"
+// @Param include_deleted query boolean false "Include deleted vehicles, for admins"
+// @Param format query string false "File format" Enums(csv, xlsx) default(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /vehicles/export [get]
+func (ref *vehicleApi) export(ctx *gin.Context) {
+	var query exportVehicleQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if !allowDeleted(ctx, query.deletedQuery) {
+		return
+	}
+
+	// The XLSX writer writes the static parts of the workbook right away, so
+	// the headers have to be set before it is created.
+	ctx.Header("Content-Type", export.ContentType(query.Format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="vehicles.%s"`, query.Format))
+
+	writer, err := export.NewWriter(query.Format, ctx.Writer)
+	if err != nil {
+		ctx.Header("Content-Disposition", "")
+		ctx.Header("Content-Type", "")
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// Exports can take longer than the server write timeout. Writers that do
+	// not support deadlines, like test recorders, have none to clear.
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	err = writer.Write(vehicleExportHeader)
+	if err == nil {
+		err = ref.vehicleService.Iterate(ctx, query.ToDomain(), func(vehicle entity.Vehicle) error {
+			return writer.Write(vehicleExportRow(vehicle))
+		})
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err != nil {
+		// Once rows have been flushed the status is already sent and the
+		// client only sees a truncated file.
+		if !ctx.Writer.Written() {
+			ctx.Header("Content-Disposition", "")
+			ctx.Header("Content-Type", "")
+			ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+	}
+}
+
 // Create godoc
 // @Summary Get Vehicle
 // @Description Get a vehicle
@@ -110,9 +229,15 @@ func (ref *vehicleApi) search(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param vehicle_id path string true "Vehicle ID"
+// @Param include_deleted query boolean false "Also get a deleted vehicle, for admins"
+// @Param as_of query string false "Get the vehicle as it was at this RFC 3339 time"
+// @Param If-None-Match header string false "ETag of the cached vehicle"
 // @Success 200 {object} responses.Vehicle
+// @Header 200 {string} ETag "Version of the vehicle"
+// @Success 304 "Vehicle was not modified"
 // @Failure 204 {object} responses.ErrorResponse
 // @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
 // @Failure 500 {object} responses.ErrorResponse
 // @Router /vehicles/{vehicle_id} [get]
 func (ref *vehicleApi) get(ctx *gin.Context) {
@@ -124,6 +249,18 @@ func (ref *vehicleApi) get(ctx *gin.Context) {
 		return
 	}
 
+	var query getVehicleQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if !allowDeleted(ctx, query.deletedQuery) {
+		return
+	}
+
 	vehicle, err := ref.vehicleService.GetByID(ctx, uri.VehicleID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
@@ -132,27 +269,122 @@ func (ref *vehicleApi) get(ctx *gin.Context) {
 		return
 	}
 
-	if vehicle == nil {
+	if vehicle == nil || (vehicle.IsDeleted() && !query.IncludeDeleted) {
 		ctx.JSON(http.StatusNoContent, nil)
 		return
 	}
 
+	if query.AsOf != nil {
+		vehicle, err = ref.vehicleService.GetAsOf(ctx, uri.VehicleID, *query.AsOf)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		if vehicle == nil || (vehicle.IsDeleted() && !query.IncludeDeleted) {
+			ctx.JSON(http.StatusNoContent, nil)
+			return
+		}
+	}
+
+	etag := vehicleETag(vehicle.Version)
+	ctx.Header("ETag", etag)
+
+	if matchesETag(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
 	response := responses.VehicleFromDomain(*vehicle)
 	ctx.JSON(http.StatusOK, response)
 }
 
+// Create godoc
+// @Summary Vehicle history
+// @Description List every recorded version of a vehicle from the oldest, with the fields changed from the previous version
+// @Tags Vehicle
+// @Produce json
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param include_deleted query boolean false "Also get the history of a deleted vehicle, for admins"
+// @Success 200 {array} responses.VehicleHistoryEntry
+// @Failure 204 {object} responses.ErrorResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /vehicles/{vehicle_id}/history [get]
+func (ref *vehicleApi) history(ctx *gin.Context) {
+	var uri vehicleURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var query deletedQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if !allowDeleted(ctx, query) {
+		return
+	}
+
+	vehicle, err := ref.vehicleService.GetByID(ctx, uri.VehicleID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if vehicle == nil || (vehicle.IsDeleted() && !query.IncludeDeleted) {
+		ctx.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	history, err := ref.vehicleService.History(ctx, uri.VehicleID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	response := make([]responses.VehicleHistoryEntry, len(history))
+
+	for i, entry := range history {
+		response[i] = responses.VehicleHistoryEntryFromDomain(entry)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
 // Create godoc
 // @Summary Update Vehicle
-// @Description Update a vehicle
+// @Description Update a vehicle with a JSON Merge Patch (RFC 7396), also accepted as plain JSON, or a JSON Patch (RFC 6902)
 // @Tags Vehicle
 // @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
 // @Produce json
 // @Security BearerAuth
 // @Param vehicle_id path string true "Vehicle ID"
-// @Param user body vehicleApi.updateVehicleRequest false "Body"
+// @Param user body vehicleApi.vehicleDocument false "Patch"
+// @Param If-Match header string false "ETag of the vehicle the change was made from"
 // @Success 200 {object} responses.Vehicle
+// @Header 200 {string} ETag "Version of the vehicle"
 // @Failure 204 {object} responses.ErrorResponse
 // @Failure 400 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 412 {object} responses.ErrorResponse
+// @Failure 415 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
 // @Failure 500 {object} responses.ErrorResponse
 // @Router /vehicles/{vehicle_id} [patch]
 func (ref *vehicleApi) update(ctx *gin.Context) {
@@ -164,16 +396,58 @@ func (ref *vehicleApi) update(ctx *gin.Context) {
 		return
 	}
 
-	var request updateVehicleRequest
-	if err := ctx.ShouldBindJSON(&request); err != nil {
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
 		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
 
-	vehicle, err := ref.vehicleService.Update(ctx, uri.VehicleID, *request.ToDomain())
+	current, err := ref.vehicleService.GetByID(ctx, uri.VehicleID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if current == nil || current.IsDeleted() {
+		ctx.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	if ifMatch := ctx.GetHeader("If-Match"); ifMatch != "" && ifMatch != "*" {
+		version, ok := parseVehicleETag(ifMatch)
+		if !ok || version != current.Version {
+			ctx.JSON(http.StatusPreconditionFailed, responses.ErrorResponse{
+				Error: entity.ErrVehicleVersionMismatch.Error(),
+			})
+			return
+		}
+	}
+
+	vehicleToUpdate, err := applyVehiclePatch(*current, ctx.ContentType(), body)
+	if err != nil {
+		ctx.JSON(patchErrorStatus(err), responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// The patch was applied to this version, so the update fails if the
+	// vehicle changed in the meantime.
+	vehicleToUpdate.Version = current.Version
+
+	vehicle, err := ref.vehicleService.Update(ctx, uri.VehicleID, *vehicleToUpdate)
 	if err != nil {
+		if errors.Is(err, entity.ErrVehicleVersionMismatch) {
+			ctx.JSON(http.StatusPreconditionFailed, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
 		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
 			Error: err.Error(),
 		})
@@ -185,21 +459,167 @@ func (ref *vehicleApi) update(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", vehicleETag(vehicle.Version))
+
 	response := responses.VehicleFromDomain(*vehicle)
 	ctx.JSON(http.StatusOK, response)
 }
 
 // Create godoc
-// @Summary Buy Vehicle
-// @Description Buy a vehicle
+// @Summary Delete Vehicle
+// @Description Soft delete a vehicle, which is hidden from searches and permanently removed after the retention period. Sold and reserved vehicles cannot be deleted.
+// @Tags Vehicle
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} responses.Vehicle
+// @Failure 204 {object} responses.ErrorResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /vehicles/{vehicle_id} [delete]
+func (ref *vehicleApi) delete(ctx *gin.Context) {
+	var uri vehicleURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	vehicle, err := ref.vehicleService.Delete(ctx, uri.VehicleID, ctx.GetString("user_id"))
+	if err != nil {
+		if errors.Is(err, entity.ErrVehicleNotDeletable) || errors.Is(err, entity.ErrVehicleVersionMismatch) {
+			ctx.JSON(http.StatusConflict, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if vehicle == nil {
+		ctx.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	response := responses.VehicleFromDomain(*vehicle)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Create godoc
+// @Summary Restore Vehicle
+// @Description Restore a deleted vehicle before the retention period ends
+// @Tags Vehicle
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} responses.Vehicle
+// @Failure 204 {object} responses.ErrorResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /vehicles/{vehicle_id}/restore [post]
+func (ref *vehicleApi) restore(ctx *gin.Context) {
+	var uri vehicleURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	vehicle, err := ref.vehicleService.Restore(ctx, uri.VehicleID)
+	if err != nil {
+		if errors.Is(err, entity.ErrVehicleVersionMismatch) {
+			ctx.JSON(http.StatusConflict, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if vehicle == nil {
+		ctx.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	response := responses.VehicleFromDomain(*vehicle)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Create godoc
+// @Summary Publish Vehicle
+// @Description Make a draft vehicle, such as a trade-in, available for sale
 // @Tags Vehicle
-// @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param vehicle_id path string true "Vehicle ID"
 // @Success 200 {object} responses.Vehicle
 // @Failure 204 {object} responses.ErrorResponse
 // @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /vehicles/{vehicle_id}/publish [post]
+func (ref *vehicleApi) publish(ctx *gin.Context) {
+	var uri vehicleURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	vehicle, err := ref.vehicleService.Publish(ctx, uri.VehicleID)
+	if err != nil {
+		if errors.Is(err, entity.ErrVehicleVersionMismatch) {
+			ctx.JSON(http.StatusConflict, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if vehicle == nil {
+		ctx.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	response := responses.VehicleFromDomain(*vehicle)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Create godoc
+// @Summary Buy Vehicle
+// @Description Reserve a vehicle and start the payment of its purchase
+// @Tags Vehicle
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param user body vehicleApi.buyVehicleRequest false "Body"
+// @Param Idempotency-Key header string false "Key to safely retry the request"
+// @Success 202 {object} responses.Payment
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
 // @Failure 500 {object} responses.ErrorResponse
 // @Router /vehicles/{vehicle_id}/buy [post]
 func (ref *vehicleApi) buy(ctx *gin.Context) {
@@ -211,9 +631,108 @@ func (ref *vehicleApi) buy(ctx *gin.Context) {
 		return
 	}
 
+	var request buyVehicleRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+	}
+
 	userID := ctx.GetString("user_id")
 
-	vehicle, err := ref.vehicleService.Buy(ctx, uri.VehicleID, userID)
+	// Reserving the vehicle and creating the payment must not stop halfway
+	// because the client dropped the connection.
+	payment, err := ref.vehicleService.Buy(context.WithoutCancel(ctx), uri.VehicleID, userID, request.ToDomain())
+	if err != nil {
+		ctx.JSON(buyErrorStatus(err), responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	response := responses.PaymentFromDomain(*payment)
+	response.ClientSecret = payment.ClientSecret
+
+	ctx.JSON(http.StatusAccepted, response)
+}
+
+// allowDeleted responds with 403 when someone other than an admin asks for
+// deleted vehicles.
+func allowDeleted(ctx *gin.Context, query deletedQuery) bool {
+	if query.IncludeDeleted && !actor.FromContext(ctx.Request.Context()).IsAdmin() {
+		ctx.JSON(http.StatusForbidden, responses.ErrorResponse{
+			Error: "admin role is required to include deleted vehicles",
+		})
+		return false
+	}
+
+	return true
+}
+
+// Create godoc
+// @Summary Import vehicles
+// @Description Start an asynchronous import of vehicles from a CSV or NDJSON file, sent as the request body or as the multipart field "file"
+// @Tags Vehicle
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "File format, inferred from the file name or content type when absent" Enums(csv, ndjson)
+// @Param dry_run query boolean false "Only validate the file"
+// @Param file formData file false "Import file"
+// @Success 202 {object} responses.ImportJob
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /vehicles/import [post]
+func (ref *vehicleImportApi) importVehicles(ctx *gin.Context) {
+	var query importVehiclesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var (
+		reader   io.Reader = ctx.Request.Body
+		filename string
+	)
+
+	if ctx.ContentType() == "multipart/form-data" {
+		file, err := ctx.FormFile("file")
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		opened, err := file.Open()
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		defer opened.Close()
+
+		reader = opened
+		filename = file.Filename
+	}
+
+	rows, err := parseVehicleImport(importFormat(query.Format, filename, ctx.ContentType()), reader)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	job, err := ref.vehicleImportService.Import(ctx, ctx.GetString("user_id"), rows, query.DryRun)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
 			Error: err.Error(),
@@ -221,11 +740,111 @@ func (ref *vehicleApi) buy(ctx *gin.Context) {
 		return
 	}
 
-	if vehicle == nil {
+	response := responses.ImportJobFromDomain(*job)
+	ctx.JSON(http.StatusAccepted, response)
+}
+
+// Create godoc
+// @Summary Get import
+// @Description Get the status and per-row errors of a vehicle import of the authenticated user
+// @Tags Vehicle
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param job_id path string true "Import job ID"
+// @Success 200 {object} responses.ImportJob
+// @Failure 204 {object} responses.ErrorResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /imports/{job_id} [get]
+func (ref *vehicleImportApi) get(ctx *gin.Context) {
+	var uri importJobURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	job, err := ref.vehicleImportService.GetByID(ctx, uri.JobID, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// Imports of other users are reported as missing, so that their IDs
+	// cannot be probed.
+	if job == nil {
 		ctx.JSON(http.StatusNoContent, nil)
 		return
 	}
 
-	response := responses.VehicleFromDomain(*vehicle)
+	response := responses.ImportJobFromDomain(*job)
 	ctx.JSON(http.StatusOK, response)
 }
+
+// Create godoc
+// @Summary Stream vehicles
+// @Description Push VehicleCreated, VehiclePriceChanged, VehicleSold, VehicleDeleted and VehicleRestored events as Server-Sent Events, with the vehicle as data. Reconnecting with Last-Event-ID replays the missed events while they are buffered; otherwise a reset event asks the client to reload the listing.
+// @Tags Vehicle
+// @Produce text/event-stream
+// @Param is_sold query boolean false "Filter vehicles by sold status"
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Success 200 {string} string
+// @Failure 400 {object} responses.ErrorResponse
+// @Router /vehicles/stream [get]
+func (ref *vehicleStreamApi) stream(ctx *gin.Context) {
+	var query streamQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	subscription := ref.vehicleStreamService.Subscribe(ctx.GetHeader("Last-Event-ID"), query.IsSold)
+	defer subscription.Cancel()
+
+	// The stream outlives the server write timeout.
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	if subscription.Reset {
+		fmt.Fprint(ctx.Writer, "event: reset\ndata: {}\n\n")
+	}
+
+	for _, event := range subscription.Replay {
+		writeStreamEvent(ctx.Writer, event)
+	}
+
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event, ok := <-subscription.Events:
+			// A closed channel means the client fell behind or the server is
+			// shutting down; it reconnects with Last-Event-ID and resumes.
+			if !ok {
+				return
+			}
+
+			writeStreamEvent(ctx.Writer, event)
+		case <-heartbeat.C:
+			fmt.Fprint(ctx.Writer, ": heartbeat\n\n")
+		}
+
+		ctx.Writer.Flush()
+	}
+}
@@ -0,0 +1,24 @@
+package webhookApi
+
+import "github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+
+type createSubscriptionRequest struct {
+	URL        string   `json:"url" binding:"required,url"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,dive,oneof=VehicleCreated VehicleUpdated VehiclePriceChanged VehicleSold VehicleDeleted VehicleRestored SaleCreated"`
+}
+
+func (ref createSubscriptionRequest) ToDomain() *entity.WebhookSubscription {
+	return &entity.WebhookSubscription{
+		URL:        ref.URL,
+		EventTypes: ref.EventTypes,
+	}
+}
+
+type subscriptionURI struct {
+	WebhookID string `uri:"webhook_id"`
+}
+
+type deliveryURI struct {
+	WebhookID  string `uri:"webhook_id"`
+	DeliveryID string `uri:"delivery_id"`
+}
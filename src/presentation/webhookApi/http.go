@@ -0,0 +1,260 @@
+package webhookApi
+
+import (
+	"errors"
+	"net/http"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type webhookApi struct {
+	webhookService interfaces.WebhookService
+}
+
+func RegisterWebhookRoutes(app *gin.Engine, authMiddleware middleware.AuthMiddleware, webhookService interfaces.WebhookService) {
+	service := webhookApi{
+		webhookService: webhookService,
+	}
+
+	app.POST("/webhooks", authMiddleware.Auth, authMiddleware.Admin, service.create)
+	app.GET("/webhooks", authMiddleware.Auth, authMiddleware.Admin, service.search)
+	app.GET("/webhooks/:webhook_id", authMiddleware.Auth, authMiddleware.Admin, service.get)
+	app.DELETE("/webhooks/:webhook_id", authMiddleware.Auth, authMiddleware.Admin, service.delete)
+	app.GET("/webhooks/:webhook_id/deliveries", authMiddleware.Auth, authMiddleware.Admin, service.searchDeliveries)
+	app.POST("/webhooks/:webhook_id/deliveries/:delivery_id/replay", authMiddleware.Auth, authMiddleware.Admin, service.replay)
+}
+
+// Create godoc
+// @Summary Create Webhook Subscription
+// @Description Subscribe a URL to vehicle and sale events. The secret used to sign deliveries is only returned here. Requires the admin role.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param subscription body createSubscriptionRequest true "Body"
+// @Success 201 {object} responses.WebhookSubscription
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /webhooks [post]
+func (ref *webhookApi) create(ctx *gin.Context) {
+	var request createSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	subscription, err := ref.webhookService.CreateSubscription(ctx, *request.ToDomain())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	response := responses.WebhookSubscriptionFromDomain(*subscription)
+	response.Secret = subscription.Secret
+
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// Create godoc
+// @Summary Search Webhook Subscriptions
+// @Description List webhook subscriptions. Requires the admin role.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} responses.WebhookSubscription
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /webhooks [get]
+func (ref *webhookApi) search(ctx *gin.Context) {
+	subscriptions, err := ref.webhookService.SearchSubscriptions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	response := make([]responses.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		response = append(response, responses.WebhookSubscriptionFromDomain(subscription))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Create godoc
+// @Summary Get Webhook Subscription
+// @Description Get a webhook subscription. Requires the admin role.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook_id path string true "Webhook subscription ID"
+// @Success 200 {object} responses.WebhookSubscription
+// @Failure 204 {object} responses.ErrorResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /webhooks/{webhook_id} [get]
+func (ref *webhookApi) get(ctx *gin.Context) {
+	var uri subscriptionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	subscription, err := ref.webhookService.GetSubscription(ctx, uri.WebhookID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if subscription == nil {
+		ctx.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	response := responses.WebhookSubscriptionFromDomain(*subscription)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Create godoc
+// @Summary Delete Webhook Subscription
+// @Description Stop sending events to a webhook subscription. Its pending deliveries are dead-lettered and its delivery log is kept. Requires the admin role.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook_id path string true "Webhook subscription ID"
+// @Success 200 {object} responses.WebhookSubscription
+// @Failure 204 {object} responses.ErrorResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /webhooks/{webhook_id} [delete]
+func (ref *webhookApi) delete(ctx *gin.Context) {
+	var uri subscriptionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	subscription, err := ref.webhookService.DeleteSubscription(ctx, uri.WebhookID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if subscription == nil {
+		ctx.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	response := responses.WebhookSubscriptionFromDomain(*subscription)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Create godoc
+// @Summary Search Webhook Deliveries
+// @Description List the deliveries of a webhook subscription, newest first. Requires the admin role.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook_id path string true "Webhook subscription ID"
+// @Success 200 {array} responses.WebhookDelivery
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /webhooks/{webhook_id}/deliveries [get]
+func (ref *webhookApi) searchDeliveries(ctx *gin.Context) {
+	var uri subscriptionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	deliveries, err := ref.webhookService.SearchDeliveries(ctx, uri.WebhookID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	response := make([]responses.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, responses.WebhookDeliveryFromDomain(delivery))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Create godoc
+// @Summary Replay Webhook Delivery
+// @Description Send a delivered or dead-lettered delivery again. Requires the admin role.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook_id path string true "Webhook subscription ID"
+// @Param delivery_id path string true "Webhook delivery ID"
+// @Success 202 {object} responses.WebhookDelivery
+// @Failure 204 {object} responses.ErrorResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /webhooks/{webhook_id}/deliveries/{delivery_id}/replay [post]
+func (ref *webhookApi) replay(ctx *gin.Context) {
+	var uri deliveryURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	delivery, err := ref.webhookService.Replay(ctx, uri.WebhookID, uri.DeliveryID)
+	if err != nil {
+		if errors.Is(err, entity.ErrWebhookDeliveryPending) {
+			ctx.JSON(http.StatusConflict, responses.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, responses.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if delivery == nil {
+		ctx.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	response := responses.WebhookDeliveryFromDomain(*delivery)
+	ctx.JSON(http.StatusAccepted, response)
+}
@@ -0,0 +1,46 @@
+package publisher
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+// Envelope is how events are serialized for other systems. Data holds the
+// vehicle or sale as returned by the API.
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	EntityID   string          `json:"entity_id"`
+	RequestID  string          `json:"request_id,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func Marshal(event entity.Event) ([]byte, error) {
+	return json.Marshal(Envelope{
+		ID:         event.ID,
+		Type:       event.Type,
+		EntityID:   event.EntityID,
+		RequestID:  event.RequestID,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Payload,
+	})
+}
+
+func Unmarshal(body []byte) (entity.Event, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return entity.Event{}, err
+	}
+
+	return entity.Event{
+		ID:         envelope.ID,
+		Type:       envelope.Type,
+		EntityID:   envelope.EntityID,
+		Payload:    envelope.Data,
+		RequestID:  envelope.RequestID,
+		OccurredAt: envelope.OccurredAt,
+	}, nil
+}
@@ -0,0 +1,30 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type fanout []interfaces.EventPublisher
+
+// Fanout publishes each event to all publishers. When any of them fails the
+// event is published to all of them again, so each publisher must tolerate
+// redeliveries like any other consumer.
+func Fanout(publishers ...interfaces.EventPublisher) interfaces.EventPublisher {
+	return fanout(publishers)
+}
+
+func (ref fanout) Publish(ctx context.Context, event entity.Event) error {
+	var errs []error
+
+	for _, publisher := range ref {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
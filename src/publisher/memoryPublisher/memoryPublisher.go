@@ -0,0 +1,63 @@
+package memoryPublisher
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+// Publisher delivers events to subscribers in the same process.
+type Publisher struct {
+	mutex       sync.Mutex
+	subscribers map[chan entity.Event]struct{}
+}
+
+func NewPublisher() *Publisher {
+	return &Publisher{
+		subscribers: map[chan entity.Event]struct{}{},
+	}
+}
+
+// Publish never blocks. When a subscriber's buffer is full it returns an
+// error, so that the relay publishes the event again later; subscribers that
+// already received it see it twice.
+func (ref *Publisher) Publish(ctx context.Context, event entity.Event) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	var err error
+
+	for subscriber := range ref.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			err = errors.New("subscriber is not keeping up with events")
+		}
+	}
+
+	return err
+}
+
+// Subscribe returns a channel that receives every published event and a
+// function that cancels the subscription and closes the channel.
+func (ref *Publisher) Subscribe(buffer int) (<-chan entity.Event, func()) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	subscriber := make(chan entity.Event, buffer)
+	ref.subscribers[subscriber] = struct{}{}
+
+	var once sync.Once
+
+	return subscriber, func() {
+		once.Do(func() {
+			ref.mutex.Lock()
+			defer ref.mutex.Unlock()
+
+			delete(ref.subscribers, subscriber)
+			close(subscriber)
+		})
+	}
+}
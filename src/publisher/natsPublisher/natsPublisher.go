@@ -0,0 +1,157 @@
+package natsPublisher
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher"
+	"github.com/nats-io/nats.go"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// Publisher publishes events to NATS and passes the events published by every
+// instance to its subscribers.
+type Publisher interface {
+	interfaces.EventPublisher
+	Subscribe(consumer interfaces.EventPublisher) error
+	Close(ctx context.Context) error
+}
+
+// natsPublisher publishes each event to the subject <prefix>.<event type>.
+// Every publish is flushed, and the event only counts as published once the
+// server answers, so events the server never received are published again.
+// Publishing while the connection is being reestablished fails right away
+// instead of buffering the event. The event ID is sent in the Nats-Msg-Id
+// header, which JetStream uses to discard duplicates.
+type natsPublisher struct {
+	conn          *nats.Conn
+	subjectPrefix string
+
+	// mutex serializes publishes, so that an error the server sends back
+	// belongs to the publish in progress.
+	mutex    sync.Mutex
+	asyncErr atomic.Pointer[error]
+
+	closed chan struct{}
+}
+
+// NewPublisher connects to the server at rawURL in the background, so that
+// the API starts while NATS is unavailable.
+func NewPublisher(rawURL, subjectPrefix string) (Publisher, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Scheme != "nats" || parsed.Host == "" {
+		return nil, errors.New("nats url must look like nats://host:port")
+	}
+
+	eventPublisher := &natsPublisher{
+		subjectPrefix: subjectPrefix,
+		closed:        make(chan struct{}),
+	}
+
+	eventPublisher.conn, err = nats.Connect(rawURL,
+		nats.Name("vehicle-resale-api"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.ReconnectBufSize(-1),
+		nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+			eventPublisher.asyncErr.Store(&err)
+		}),
+		nats.ClosedHandler(func(_ *nats.Conn) {
+			close(eventPublisher.closed)
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return eventPublisher, nil
+}
+
+func (ref *natsPublisher) Publish(ctx context.Context, event entity.Event) error {
+	body, err := publisher.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	ref.asyncErr.Store(nil)
+
+	message := nats.NewMsg(ref.subjectPrefix + "." + event.Type)
+	message.Header.Set(nats.MsgIdHdr, event.ID)
+	message.Data = body
+
+	err = ref.conn.PublishMsg(message)
+	if errors.Is(err, nats.ErrHeadersNotSupported) {
+		err = ref.conn.Publish(message.Subject, body)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	err = ref.conn.FlushWithContext(ctx)
+
+	// Errors such as permission violations arrive asynchronously, before the
+	// flush is answered, and may be followed by the server disconnecting.
+	if asyncErr := ref.asyncErr.Load(); asyncErr != nil {
+		return *asyncErr
+	}
+
+	return err
+}
+
+// Subscribe passes every event published under the subject prefix, by this
+// instance or any other, to consumer. Events published while this instance is
+// disconnected are not received; the consumer must tolerate redeliveries.
+func (ref *natsPublisher) Subscribe(consumer interfaces.EventPublisher) error {
+	_, err := ref.conn.Subscribe(ref.subjectPrefix+".*", func(message *nats.Msg) {
+		event, err := publisher.Unmarshal(message.Data)
+		if err != nil {
+			slog.Error("could not decode event", "subject", message.Subject, "error", err)
+			return
+		}
+
+		if err = consumer.Publish(context.Background(), event); err != nil {
+			slog.Error("could not consume event", "event_id", event.ID, "error", err)
+		}
+	})
+
+	return err
+}
+
+// Close drains the connection, so that the events already received are
+// passed to the subscribers and those being published are flushed, and waits
+// for it to close. A connection that is not connected is closed right away.
+func (ref *natsPublisher) Close(ctx context.Context) error {
+	err := ref.conn.Drain()
+	if err != nil && !errors.Is(err, nats.ErrConnectionReconnecting) {
+		return err
+	}
+
+	select {
+	case <-ref.closed:
+		return nil
+	case <-ctx.Done():
+		ref.conn.Close()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,303 @@
+package natsPublisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type message struct {
+	subject string
+	header  string
+	payload []byte
+}
+
+// standIn is a minimal NATS server that accepts connections one at a time
+// and records what is published.
+type standIn struct {
+	listener net.Listener
+	headers  bool
+	reject   atomic.Bool
+	connects chan string
+	messages chan message
+}
+
+func newStandIn(t *testing.T, headers bool) *standIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &standIn{
+		listener: listener,
+		headers:  headers,
+		connects: make(chan string, 10),
+		messages: make(chan message, 10),
+	}
+
+	go server.serve()
+
+	t.Cleanup(func() { listener.Close() })
+
+	return server
+}
+
+func (ref *standIn) url() string {
+	return "nats://token@" + ref.listener.Addr().String()
+}
+
+func (ref *standIn) serve() {
+	for {
+		conn, err := ref.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		ref.handle(conn)
+	}
+}
+
+func (ref *standIn) handle(conn net.Conn) {
+	defer conn.Close()
+
+	fmt.Fprintf(conn, "INFO {\"server_id\":\"stand-in\",\"proto\":1,\"max_payload\":1048576,\"headers\":%t}\r\n", ref.headers)
+
+	reader := bufio.NewReader(conn)
+
+	// subscriptions maps the sid of each subscription to its subject.
+	subscriptions := map[string]string{}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "CONNECT":
+			ref.connects <- strings.TrimSpace(strings.TrimPrefix(line, "CONNECT"))
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "SUB":
+			subscriptions[fields[len(fields)-1]] = fields[1]
+		case "PUB", "HPUB":
+			headerSize := 0
+			if fields[0] == "HPUB" {
+				headerSize, _ = strconv.Atoi(fields[2])
+			}
+
+			totalSize, _ := strconv.Atoi(fields[len(fields)-1])
+
+			content := make([]byte, totalSize+2)
+			if _, err = io.ReadFull(reader, content); err != nil {
+				return
+			}
+
+			if ref.reject.Load() {
+				fmt.Fprint(conn, "-ERR 'Permissions Violation for Publish'\r\n")
+				return
+			}
+
+			ref.messages <- message{
+				subject: fields[1],
+				header:  string(content[:headerSize]),
+				payload: content[headerSize:totalSize],
+			}
+
+			for sid, subject := range subscriptions {
+				if matches(subject, fields[1]) {
+					fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", fields[1], sid, totalSize-headerSize, content[headerSize:totalSize])
+				}
+			}
+		}
+	}
+}
+
+// matches reports whether subject matches pattern, where * stands for a
+// single token.
+func matches(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+
+	if len(patternTokens) != len(subjectTokens) {
+		return false
+	}
+
+	for i, token := range patternTokens {
+		if token != "*" && token != subjectTokens[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.TODO()
+
+	event := entity.Event{
+		ID:         "event-123",
+		Type:       entity.EventVehicleCreated,
+		EntityID:   "vehicle-123",
+		Payload:    []byte(`{"id":"vehicle-123"}`),
+		OccurredAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	t.Run("should not create publisher with invalid url", func(t *testing.T) {
+		actual, err := NewPublisher("http://localhost:4222", "vehicle-resale")
+
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	t.Run("should publish event with message id header", func(t *testing.T) {
+		server := newStandIn(t, true)
+
+		eventPublisher, err := NewPublisher(server.url(), "vehicle-resale")
+		require.NoError(t, err)
+
+		require.NoError(t, eventPublisher.Publish(ctx, event))
+
+		var options struct {
+			AuthToken string `json:"auth_token"`
+			Headers   bool   `json:"headers"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(<-server.connects), &options))
+		assert.Equal(t, "token", options.AuthToken)
+		assert.True(t, options.Headers)
+
+		published := <-server.messages
+
+		assert.Equal(t, "vehicle-resale.VehicleCreated", published.subject)
+		assert.Contains(t, published.header, "Nats-Msg-Id: event-123\r\n")
+
+		var envelope publisher.Envelope
+		require.NoError(t, json.Unmarshal(published.payload, &envelope))
+		assert.Equal(t, "event-123", envelope.ID)
+		assert.Equal(t, entity.EventVehicleCreated, envelope.Type)
+		assert.JSONEq(t, `{"id":"vehicle-123"}`, string(envelope.Data))
+	})
+
+	t.Run("should publish event without headers to older servers", func(t *testing.T) {
+		server := newStandIn(t, false)
+
+		eventPublisher, err := NewPublisher(server.url(), "vehicle-resale")
+		require.NoError(t, err)
+
+		require.NoError(t, eventPublisher.Publish(ctx, event))
+
+		published := <-server.messages
+
+		assert.Equal(t, "vehicle-resale.VehicleCreated", published.subject)
+		assert.Empty(t, published.header)
+	})
+
+	t.Run("should return server error and reconnect on next publish", func(t *testing.T) {
+		server := newStandIn(t, true)
+		server.reject.Store(true)
+
+		eventPublisher, err := NewPublisher(server.url(), "vehicle-resale")
+		require.NoError(t, err)
+
+		err = eventPublisher.Publish(ctx, event)
+		assert.ErrorContains(t, err, "permissions violation")
+
+		server.reject.Store(false)
+
+		// The client reconnects in the background, and publishing fails
+		// until it does.
+		assert.Eventually(t, func() bool {
+			return eventPublisher.Publish(ctx, event) == nil
+		}, 10*time.Second, 100*time.Millisecond)
+
+		published := <-server.messages
+
+		assert.Len(t, server.connects, 2)
+		assert.Equal(t, "vehicle-resale.VehicleCreated", published.subject)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx := context.TODO()
+
+	event := entity.Event{
+		ID:         "event-123",
+		Type:       entity.EventVehicleSold,
+		EntityID:   "vehicle-123",
+		Payload:    []byte(`{"id":"vehicle-123"}`),
+		RequestID:  "request-123",
+		OccurredAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	t.Run("should pass published events to consumer", func(t *testing.T) {
+		server := newStandIn(t, true)
+
+		eventPublisher, err := NewPublisher(server.url(), "vehicle-resale")
+		require.NoError(t, err)
+
+		consumer := memoryPublisher.NewPublisher()
+		received, cancel := consumer.Subscribe(1)
+		defer cancel()
+
+		require.NoError(t, eventPublisher.Subscribe(consumer))
+		require.NoError(t, eventPublisher.Publish(ctx, event))
+
+		select {
+		case actual := <-received:
+			assert.Equal(t, event.ID, actual.ID)
+			assert.Equal(t, event.Type, actual.Type)
+			assert.Equal(t, event.EntityID, actual.EntityID)
+			assert.Equal(t, event.RequestID, actual.RequestID)
+			assert.True(t, event.OccurredAt.Equal(actual.OccurredAt))
+			assert.JSONEq(t, string(event.Payload), string(actual.Payload))
+		case <-time.After(5 * time.Second):
+			t.Fatal("event was not received")
+		}
+	})
+}
+
+func TestClose(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should drain and close connection", func(t *testing.T) {
+		server := newStandIn(t, true)
+
+		eventPublisher, err := NewPublisher(server.url(), "vehicle-resale")
+		require.NoError(t, err)
+
+		require.NoError(t, eventPublisher.Subscribe(memoryPublisher.NewPublisher()))
+		require.NoError(t, eventPublisher.Publish(ctx, entity.Event{ID: "event-123", Type: entity.EventVehicleCreated}))
+
+		require.NoError(t, eventPublisher.Close(ctx))
+
+		err = eventPublisher.Publish(ctx, entity.Event{ID: "event-456", Type: entity.EventVehicleCreated})
+		assert.Error(t, err)
+	})
+
+	t.Run("should close connection that is not connected", func(t *testing.T) {
+		server := newStandIn(t, true)
+		server.listener.Close()
+
+		eventPublisher, err := NewPublisher(server.url(), "vehicle-resale")
+		require.NoError(t, err)
+
+		assert.NoError(t, eventPublisher.Close(ctx))
+	})
+}
@@ -0,0 +1,54 @@
+package webhookPublisher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher"
+)
+
+// webhookPublisher posts each event to a single URL. Any response other than 2xx is
+// treated as a failure and the event is published again later.
+type webhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewPublisher(url string, client *http.Client) interfaces.EventPublisher {
+	return &webhookPublisher{
+		url:    url,
+		client: client,
+	}
+}
+
+func (ref *webhookPublisher) Publish(ctx context.Context, event entity.Event) error {
+	body, err := publisher.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ref.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", event.ID)
+	req.Header.Set("X-Event-Type", event.Type)
+
+	resp, err := ref.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package webhookPublisher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublish(t *testing.T) {
+	ctx := context.TODO()
+
+	event := entity.Event{
+		ID:       "event-123",
+		Type:     entity.EventSaleCreated,
+		EntityID: "sale-123",
+		Payload:  []byte(`{"id":"sale-123"}`),
+	}
+
+	t.Run("should post event envelope", func(t *testing.T) {
+		var (
+			headers http.Header
+			body    []byte
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = r.Header
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := NewPublisher(server.URL, server.Client()).Publish(ctx, event)
+		require.NoError(t, err)
+
+		assert.Equal(t, "event-123", headers.Get("X-Event-ID"))
+		assert.Equal(t, entity.EventSaleCreated, headers.Get("X-Event-Type"))
+
+		var envelope publisher.Envelope
+		require.NoError(t, json.Unmarshal(body, &envelope))
+		assert.Equal(t, "sale-123", envelope.EntityID)
+		assert.JSONEq(t, `{"id":"sale-123"}`, string(envelope.Data))
+	})
+
+	t.Run("should fail when webhook does not accept event", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewPublisher(server.URL, server.Client()).Publish(ctx, event)
+
+		assert.EqualError(t, err, "webhook responded with status 503")
+	})
+}
@@ -0,0 +1,216 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SaleRepository runs the sale repository suite. newRepositories is called
+// once per subtest and must return empty repositories, the sale repository
+// reading vehicles from the vehicle repository for the reports.
+func SaleRepository(t *testing.T, newRepositories func(t *testing.T) (interfaces.VehicleRepository, interfaces.SaleRepository)) {
+	ctx := context.TODO()
+
+	t.Run("should create sale", func(t *testing.T) {
+		_, repository := newRepositories(t)
+
+		soldAt := time.Now()
+
+		actual, err := repository.Create(ctx, entity.Sale{
+			VehicleID:        primitive.NewObjectID().Hex(),
+			UserID:           "user-1",
+			Price:            50000,
+			TradeInVehicleID: primitive.NewObjectID().Hex(),
+			TradeInCredit:    20000,
+			NetPrice:         30000,
+			SoldAt:           soldAt,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.NotEmpty(t, actual.ID)
+		assert.Equal(t, "user-1", actual.UserID)
+		assert.Equal(t, 50000.0, actual.Price)
+		assert.NotEmpty(t, actual.TradeInVehicleID)
+		assert.Equal(t, 20000.0, actual.TradeInCredit)
+		assert.Equal(t, 30000.0, actual.NetPrice)
+		assert.WithinDuration(t, soldAt, actual.SoldAt, time.Millisecond)
+	})
+
+	t.Run("should search sales in the order they were made", func(t *testing.T) {
+		_, repository := newRepositories(t)
+
+		first := createSale(t, repository, entity.Sale{VehicleID: primitive.NewObjectID().Hex(), Price: 50000, SoldAt: time.Now()})
+		second := createSale(t, repository, entity.Sale{VehicleID: primitive.NewObjectID().Hex(), Price: 30000, SoldAt: time.Now()})
+
+		actual, err := repository.Search(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []entity.Sale{*first, *second}, actual)
+
+		var iterated []entity.Sale
+
+		err = repository.Iterate(ctx, func(sale entity.Sale) error {
+			iterated = append(iterated, sale)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, actual, iterated)
+	})
+
+	t.Run("should return no sales when there are none", func(t *testing.T) {
+		_, repository := newRepositories(t)
+
+		actual, err := repository.Search(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+
+		report, err := repository.Report(ctx, entity.SalesReportFilter{GroupBy: entity.SalesReportGroupByMonth})
+		require.NoError(t, err)
+		assert.Empty(t, report)
+	})
+
+	t.Run("should stop iterating when callback fails", func(t *testing.T) {
+		_, repository := newRepositories(t)
+
+		createSale(t, repository, entity.Sale{VehicleID: primitive.NewObjectID().Hex(), Price: 50000, SoldAt: time.Now()})
+		createSale(t, repository, entity.Sale{VehicleID: primitive.NewObjectID().Hex(), Price: 30000, SoldAt: time.Now()})
+
+		unexpectedError := errors.New("unexpected error")
+		calls := 0
+
+		err := repository.Iterate(ctx, func(sale entity.Sale) error {
+			calls++
+			return unexpectedError
+		})
+
+		assert.ErrorIs(t, err, unexpectedError)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should report sales grouped by vehicle", func(t *testing.T) {
+		vehicleRepository, repository := newRepositories(t)
+
+		ford := createVehicle(t, vehicleRepository, entity.Vehicle{Brand: "Ford", Model: "Ka", Price: 50000, SellerID: "seller-1"})
+		fiat := createVehicle(t, vehicleRepository, entity.Vehicle{Brand: "Fiat", Model: "Uno", Price: 30000, SellerID: "seller-2"})
+
+		twoDaysLater := ford.CreatedAt.Add(48 * time.Hour)
+		fourDaysLater := fiat.CreatedAt.Add(96 * time.Hour)
+
+		createSale(t, repository, entity.Sale{VehicleID: ford.ID, Price: 50000, SoldAt: twoDaysLater})
+		// Revenue is net of the trade-in credit.
+		createSale(t, repository, entity.Sale{VehicleID: ford.ID, Price: 40000, TradeInCredit: 15000, NetPrice: 25000, SoldAt: twoDaysLater})
+		createSale(t, repository, entity.Sale{VehicleID: fiat.ID, Price: 30000, SoldAt: fourDaysLater})
+		// Sales of vehicles that cannot be found are still counted.
+		createSale(t, repository, entity.Sale{VehicleID: "legacy-id", Price: 10000, SoldAt: twoDaysLater})
+
+		tests := []struct {
+			groupBy  string
+			expected []entity.SalesReportGroup
+		}{
+			{entity.SalesReportGroupByBrand, []entity.SalesReportGroup{
+				{Key: "", Count: 1, Revenue: 10000, AverageTicket: 10000},
+				{Key: "Fiat", Count: 1, Revenue: 30000, AverageTicket: 30000, AverageDaysOnMarket: 4},
+				{Key: "Ford", Count: 2, Revenue: 75000, AverageTicket: 37500, AverageDaysOnMarket: 2},
+			}},
+			{entity.SalesReportGroupByModel, []entity.SalesReportGroup{
+				{Key: " ", Count: 1, Revenue: 10000, AverageTicket: 10000},
+				{Key: "Fiat Uno", Count: 1, Revenue: 30000, AverageTicket: 30000, AverageDaysOnMarket: 4},
+				{Key: "Ford Ka", Count: 2, Revenue: 75000, AverageTicket: 37500, AverageDaysOnMarket: 2},
+			}},
+			{entity.SalesReportGroupBySeller, []entity.SalesReportGroup{
+				{Key: "", Count: 1, Revenue: 10000, AverageTicket: 10000},
+				{Key: "seller-1", Count: 2, Revenue: 75000, AverageTicket: 37500, AverageDaysOnMarket: 2},
+				{Key: "seller-2", Count: 1, Revenue: 30000, AverageTicket: 30000, AverageDaysOnMarket: 4},
+			}},
+			{"", []entity.SalesReportGroup{
+				{Key: "", Count: 4, Revenue: 115000, AverageTicket: 28750, AverageDaysOnMarket: 8.0 / 3},
+			}},
+		}
+
+		for _, test := range tests {
+			actual, err := repository.Report(ctx, entity.SalesReportFilter{GroupBy: test.groupBy})
+			require.NoError(t, err, test.groupBy)
+
+			assertReport(t, test.expected, actual, test.groupBy)
+		}
+	})
+
+	t.Run("should report sales made in the period grouped by date", func(t *testing.T) {
+		_, repository := newRepositories(t)
+
+		dates := []time.Time{
+			time.Date(2025, time.January, 31, 23, 59, 59, 0, time.UTC),
+			time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, time.February, 3, 12, 0, 0, 0, time.UTC),
+			time.Date(2025, time.February, 3, 15, 0, 0, 0, time.UTC),
+			time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		}
+
+		for _, soldAt := range dates {
+			createSale(t, repository, entity.Sale{VehicleID: primitive.NewObjectID().Hex(), Price: 1000, SoldAt: soldAt})
+		}
+
+		from := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+		tests := []struct {
+			groupBy  string
+			expected []entity.SalesReportGroup
+		}{
+			{entity.SalesReportGroupByDay, []entity.SalesReportGroup{
+				{Key: "2025-02-01", Count: 1, Revenue: 1000, AverageTicket: 1000},
+				{Key: "2025-02-03", Count: 2, Revenue: 2000, AverageTicket: 1000},
+			}},
+			{entity.SalesReportGroupByWeek, []entity.SalesReportGroup{
+				{Key: "2025-W05", Count: 1, Revenue: 1000, AverageTicket: 1000},
+				{Key: "2025-W06", Count: 2, Revenue: 2000, AverageTicket: 1000},
+			}},
+			{entity.SalesReportGroupByMonth, []entity.SalesReportGroup{
+				{Key: "2025-02", Count: 3, Revenue: 3000, AverageTicket: 1000},
+			}},
+		}
+
+		for _, test := range tests {
+			actual, err := repository.Report(ctx, entity.SalesReportFilter{GroupBy: test.groupBy, From: &from, To: &to})
+			require.NoError(t, err, test.groupBy)
+
+			assertReport(t, test.expected, actual, test.groupBy)
+		}
+	})
+}
+
+func createSale(t *testing.T, repository interfaces.SaleRepository, sale entity.Sale) *entity.Sale {
+	t.Helper()
+
+	created, err := repository.Create(context.TODO(), sale)
+	require.NoError(t, err)
+	require.NotNil(t, created)
+
+	return created
+}
+
+// assertReport compares the averages with a tolerance, since backends may
+// compute them in a different order.
+func assertReport(t *testing.T, expected, actual []entity.SalesReportGroup, groupBy string) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected), groupBy)
+
+	for i := range expected {
+		assert.Equal(t, expected[i].Key, actual[i].Key, groupBy)
+		assert.Equal(t, expected[i].Count, actual[i].Count, groupBy)
+		assert.InDelta(t, expected[i].Revenue, actual[i].Revenue, 0.001, groupBy)
+		assert.InDelta(t, expected[i].AverageTicket, actual[i].AverageTicket, 0.001, groupBy)
+		assert.InDelta(t, expected[i].AverageDaysOnMarket, actual[i].AverageDaysOnMarket, 0.001, groupBy)
+	}
+}
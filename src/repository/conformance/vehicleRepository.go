@@ -0,0 +1,392 @@
+// Package conformance holds the behavior every repository backend must have,
+// so that the memory repositories used by the integration tests behave like
+// the ones used in production. Each backend runs the suite from its own tests.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// VehicleRepository runs the vehicle repository suite. newRepository is
+// called once per subtest and must return an empty repository.
+func VehicleRepository(t *testing.T, newRepository func(t *testing.T) interfaces.VehicleRepository) {
+	ctx := context.TODO()
+
+	t.Run("should create vehicle at its first version", func(t *testing.T) {
+		repository := newRepository(t)
+
+		before := time.Now().Add(-time.Second)
+
+		actual, err := repository.Create(ctx, entity.Vehicle{
+			Brand:    "Ford",
+			Model:    "Ka",
+			Year:     2022,
+			Color:    "Preto",
+			Price:    50000,
+			Status:   entity.VehicleStatusAvailable,
+			SellerID: "seller-1",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.NotEmpty(t, actual.ID)
+		assert.Equal(t, "Ford", actual.Brand)
+		assert.Equal(t, "Ka", actual.Model)
+		assert.Equal(t, 2022, actual.Year)
+		assert.Equal(t, "Preto", actual.Color)
+		assert.Equal(t, 50000.0, actual.Price)
+		assert.Equal(t, entity.VehicleStatusAvailable, actual.Status)
+		assert.Equal(t, "seller-1", actual.SellerID)
+		assert.Equal(t, int64(1), actual.Version)
+		assert.Nil(t, actual.SoldAt)
+		assert.False(t, actual.IsDeleted())
+		assert.True(t, actual.CreatedAt.After(before))
+		assert.Equal(t, actual.CreatedAt, actual.UpdatedAt)
+	})
+
+	t.Run("should get vehicle by id", func(t *testing.T) {
+		repository := newRepository(t)
+
+		created := createVehicle(t, repository, entity.Vehicle{Brand: "Ford", Price: 50000})
+
+		actual, err := repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, created, actual)
+	})
+
+	t.Run("should return nil for vehicle that does not exist", func(t *testing.T) {
+		repository := newRepository(t)
+
+		actual, err := repository.GetByID(ctx, missingVehicleID(t, repository))
+
+		assert.Nil(t, actual)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not find vehicle with malformed id", func(t *testing.T) {
+		repository := newRepository(t)
+
+		actual, err := repository.GetByID(ctx, "not-an-id")
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should search vehicles by sold state ordered by price", func(t *testing.T) {
+		repository := newRepository(t)
+
+		soldAt := time.Now()
+
+		expensive := createVehicle(t, repository, entity.Vehicle{Brand: "BMW", Price: 300000})
+		sold := createVehicle(t, repository, entity.Vehicle{Brand: "Fiat", Price: 30000, SoldAt: &soldAt})
+		cheap := createVehicle(t, repository, entity.Vehicle{Brand: "Ford", Price: 50000})
+
+		isSold, isNotSold := true, false
+
+		tests := []struct {
+			name     string
+			filter   entity.VehicleFilter
+			expected []string
+		}{
+			{"all", entity.VehicleFilter{}, []string{sold.ID, cheap.ID, expensive.ID}},
+			{"sold", entity.VehicleFilter{IsSold: &isSold}, []string{sold.ID}},
+			{"not sold", entity.VehicleFilter{IsSold: &isNotSold}, []string{cheap.ID, expensive.ID}},
+		}
+
+		for _, test := range tests {
+			actual, err := repository.Search(ctx, test.filter)
+			require.NoError(t, err, test.name)
+
+			assert.Equal(t, test.expected, vehicleIDs(actual), test.name)
+		}
+	})
+
+	t.Run("should hide deleted vehicles unless included", func(t *testing.T) {
+		repository := newRepository(t)
+
+		kept := createVehicle(t, repository, entity.Vehicle{Brand: "Ford", Price: 50000})
+		deleted := createVehicle(t, repository, entity.Vehicle{Brand: "Fiat", Price: 30000})
+
+		_, err := repository.Delete(ctx, deleted.ID, deleted.Version, "user-1")
+		require.NoError(t, err)
+
+		actual, err := repository.Search(ctx, entity.VehicleFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{kept.ID}, vehicleIDs(actual))
+
+		actual, err = repository.Search(ctx, entity.VehicleFilter{IncludeDeleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{deleted.ID, kept.ID}, vehicleIDs(actual))
+
+		found, err := repository.GetByID(ctx, deleted.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.True(t, found.IsDeleted())
+	})
+
+	t.Run("should iterate vehicles like search", func(t *testing.T) {
+		repository := newRepository(t)
+
+		first := createVehicle(t, repository, entity.Vehicle{Brand: "Fiat", Price: 30000})
+		second := createVehicle(t, repository, entity.Vehicle{Brand: "Ford", Price: 50000})
+
+		var actual []entity.Vehicle
+
+		err := repository.Iterate(ctx, entity.VehicleFilter{}, func(vehicle entity.Vehicle) error {
+			actual = append(actual, vehicle)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{first.ID, second.ID}, vehicleIDs(actual))
+	})
+
+	t.Run("should stop iterating when callback fails", func(t *testing.T) {
+		repository := newRepository(t)
+
+		createVehicle(t, repository, entity.Vehicle{Brand: "Fiat", Price: 30000})
+		createVehicle(t, repository, entity.Vehicle{Brand: "Ford", Price: 50000})
+
+		unexpectedError := errors.New("unexpected error")
+		calls := 0
+
+		err := repository.Iterate(ctx, entity.VehicleFilter{}, func(vehicle entity.Vehicle) error {
+			calls++
+			return unexpectedError
+		})
+
+		assert.ErrorIs(t, err, unexpectedError)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should update only the fields that are set", func(t *testing.T) {
+		repository := newRepository(t)
+
+		created := createVehicle(t, repository, entity.Vehicle{
+			Brand: "Ford",
+			Model: "Ka",
+			Year:  2022,
+			Color: "Preto",
+			Price: 50000,
+		})
+
+		actual, err := repository.Update(ctx, created.ID, entity.Vehicle{Price: 45000})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, 45000.0, actual.Price)
+		assert.Equal(t, "Ford", actual.Brand)
+		assert.Equal(t, "Ka", actual.Model)
+		assert.Equal(t, 2022, actual.Year)
+		assert.Equal(t, "Preto", actual.Color)
+		assert.Equal(t, int64(2), actual.Version)
+		assert.Equal(t, created.CreatedAt, actual.CreatedAt)
+		assert.False(t, actual.UpdatedAt.Before(created.UpdatedAt))
+
+		found, err := repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, actual, found)
+	})
+
+	t.Run("should update sale and ownership fields", func(t *testing.T) {
+		repository := newRepository(t)
+
+		created := createVehicle(t, repository, entity.Vehicle{Brand: "Ford", Price: 50000})
+
+		soldAt := time.Now()
+
+		actual, err := repository.Update(ctx, created.ID, entity.Vehicle{
+			Status:   entity.VehicleStatusSold,
+			SellerID: "seller-1",
+			SoldAt:   &soldAt,
+			Version:  created.Version,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, entity.VehicleStatusSold, actual.Status)
+		assert.Equal(t, "seller-1", actual.SellerID)
+		require.NotNil(t, actual.SoldAt)
+		assert.WithinDuration(t, soldAt, *actual.SoldAt, time.Millisecond)
+	})
+
+	t.Run("should replace every editable field", func(t *testing.T) {
+		repository := newRepository(t)
+
+		created := createVehicle(t, repository, entity.Vehicle{
+			Brand:    "Ford",
+			Model:    "Ka",
+			Year:     2022,
+			Color:    "Preto",
+			Price:    50000,
+			SellerID: "seller-1",
+		})
+
+		actual, err := repository.Replace(ctx, created.ID, entity.Vehicle{
+			Brand:   "Fiat",
+			Model:   "Uno",
+			Year:    2020,
+			Price:   30000,
+			Version: created.Version,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, "Fiat", actual.Brand)
+		assert.Equal(t, "Uno", actual.Model)
+		assert.Equal(t, 2020, actual.Year)
+		assert.Empty(t, actual.Color)
+		assert.Equal(t, 30000.0, actual.Price)
+		assert.Equal(t, "seller-1", actual.SellerID)
+		assert.Equal(t, int64(2), actual.Version)
+	})
+
+	t.Run("should reject changes to a stale version", func(t *testing.T) {
+		repository := newRepository(t)
+
+		created := createVehicle(t, repository, entity.Vehicle{Brand: "Ford", Price: 50000})
+
+		stale := created.Version + 1
+
+		_, err := repository.Update(ctx, created.ID, entity.Vehicle{Price: 45000, Version: stale})
+		assert.ErrorIs(t, err, entity.ErrVehicleVersionMismatch)
+
+		_, err = repository.Replace(ctx, created.ID, entity.Vehicle{Brand: "Fiat", Version: stale})
+		assert.ErrorIs(t, err, entity.ErrVehicleVersionMismatch)
+
+		_, err = repository.Delete(ctx, created.ID, stale, "user-1")
+		assert.ErrorIs(t, err, entity.ErrVehicleVersionMismatch)
+
+		_, err = repository.Restore(ctx, created.ID, stale)
+		assert.ErrorIs(t, err, entity.ErrVehicleVersionMismatch)
+
+		found, err := repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created, found)
+	})
+
+	t.Run("should return nil when changing vehicle that does not exist", func(t *testing.T) {
+		repository := newRepository(t)
+
+		id := missingVehicleID(t, repository)
+
+		for _, version := range []int64{0, 1} {
+			actual, err := repository.Update(ctx, id, entity.Vehicle{Price: 45000, Version: version})
+			assert.Nil(t, actual)
+			assert.NoError(t, err)
+
+			actual, err = repository.Delete(ctx, id, version, "user-1")
+			assert.Nil(t, actual)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("should not change vehicle with malformed id", func(t *testing.T) {
+		repository := newRepository(t)
+
+		updated, err := repository.Update(ctx, "not-an-id", entity.Vehicle{Price: 45000, Version: 1})
+		assert.Nil(t, updated)
+		assert.Nil(t, err)
+
+		deleted, err := repository.Delete(ctx, "not-an-id", 0, "user-1")
+		assert.Nil(t, deleted)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should soft delete and restore vehicle", func(t *testing.T) {
+		repository := newRepository(t)
+
+		created := createVehicle(t, repository, entity.Vehicle{Brand: "Ford", Price: 50000})
+
+		deleted, err := repository.Delete(ctx, created.ID, created.Version, "user-1")
+		require.NoError(t, err)
+		require.NotNil(t, deleted)
+
+		assert.True(t, deleted.IsDeleted())
+		assert.Equal(t, "user-1", deleted.DeletedBy)
+		assert.Equal(t, int64(2), deleted.Version)
+		assert.Equal(t, *deleted.DeletedAt, deleted.UpdatedAt)
+
+		restored, err := repository.Restore(ctx, created.ID, deleted.Version)
+		require.NoError(t, err)
+		require.NotNil(t, restored)
+
+		assert.False(t, restored.IsDeleted())
+		assert.Empty(t, restored.DeletedBy)
+		assert.Equal(t, int64(3), restored.Version)
+		assert.Equal(t, "Ford", restored.Brand)
+	})
+
+	t.Run("should purge vehicles deleted before the given time", func(t *testing.T) {
+		repository := newRepository(t)
+
+		deleted := createVehicle(t, repository, entity.Vehicle{Brand: "Ford", Price: 50000})
+		kept := createVehicle(t, repository, entity.Vehicle{Brand: "Fiat", Price: 30000})
+
+		_, err := repository.Delete(ctx, deleted.ID, deleted.Version, "user-1")
+		require.NoError(t, err)
+
+		purged, err := repository.Purge(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 0, purged)
+
+		purged, err = repository.Purge(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		found, err := repository.GetByID(ctx, deleted.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		found, err = repository.GetByID(ctx, kept.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, found)
+	})
+}
+
+func createVehicle(t *testing.T, repository interfaces.VehicleRepository, vehicle entity.Vehicle) *entity.Vehicle {
+	t.Helper()
+
+	created, err := repository.Create(context.TODO(), vehicle)
+	require.NoError(t, err)
+	require.NotNil(t, created)
+
+	return created
+}
+
+// missingVehicleID returns an id in the format of the backend that no vehicle
+// has, since each backend has ids of its own.
+func missingVehicleID(t *testing.T, repository interfaces.VehicleRepository) string {
+	t.Helper()
+
+	ctx := context.TODO()
+
+	purged := createVehicle(t, repository, entity.Vehicle{Brand: "Fiat", Price: 30000})
+
+	_, err := repository.Delete(ctx, purged.ID, purged.Version, "user-1")
+	require.NoError(t, err)
+
+	_, err = repository.Purge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	return purged.ID
+}
+
+func vehicleIDs(vehicles []entity.Vehicle) []string {
+	ids := make([]string, len(vehicles))
+
+	for i, vehicle := range vehicles {
+		ids[i] = vehicle.ID
+	}
+
+	return ids
+}
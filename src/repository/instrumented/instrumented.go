@@ -0,0 +1,31 @@
+// Package instrumented holds decorators that record metrics and trace spans
+// around the repositories.
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/metrics"
+	"github.com/caiiomp/vehicle-resale-api/src/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Start begins measuring a repository operation. The returned function must
+// be called with the result of the operation.
+func Start(ctx context.Context, repository, method string) (context.Context, func(err error)) {
+	ctx, span := tracing.Start(ctx, repository+"Repository."+method,
+		trace.WithAttributes(
+			attribute.String("repository", repository),
+			attribute.String("method", method),
+		),
+	)
+
+	start := time.Now()
+
+	return ctx, func(err error) {
+		metrics.ObserveRepository(repository, method, start, err)
+		span.End(err)
+	}
+}
@@ -0,0 +1,66 @@
+package paymentRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/instrumented"
+)
+
+const repositoryName = "payment"
+
+type paymentRepository struct {
+	next interfaces.PaymentRepository
+}
+
+// NewPaymentRepository measures and traces every operation of the wrapped
+// repository.
+func NewPaymentRepository(next interfaces.PaymentRepository) interfaces.PaymentRepository {
+	return &paymentRepository{
+		next: next,
+	}
+}
+
+func (ref *paymentRepository) Create(ctx context.Context, payment entity.Payment) (*entity.Payment, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Create")
+	created, err := ref.next.Create(ctx, payment)
+	done(err)
+	return created, err
+}
+
+func (ref *paymentRepository) GetByID(ctx context.Context, id string) (*entity.Payment, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "GetByID")
+	payment, err := ref.next.GetByID(ctx, id)
+	done(err)
+	return payment, err
+}
+
+func (ref *paymentRepository) GetByIntentID(ctx context.Context, intentID string) (*entity.Payment, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "GetByIntentID")
+	payment, err := ref.next.GetByIntentID(ctx, intentID)
+	done(err)
+	return payment, err
+}
+
+func (ref *paymentRepository) SearchExpired(ctx context.Context, before time.Time) ([]entity.Payment, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "SearchExpired")
+	payments, err := ref.next.SearchExpired(ctx, before)
+	done(err)
+	return payments, err
+}
+
+func (ref *paymentRepository) Update(ctx context.Context, id string, payment entity.Payment) (*entity.Payment, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Update")
+	updated, err := ref.next.Update(ctx, id, payment)
+	done(err)
+	return updated, err
+}
+
+func (ref *paymentRepository) UpdatePending(ctx context.Context, id string, payment entity.Payment) (*entity.Payment, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "UpdatePending")
+	updated, err := ref.next.UpdatePending(ctx, id, payment)
+	done(err)
+	return updated, err
+}
@@ -0,0 +1,52 @@
+package saleRepository
+
+import (
+	"context"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/instrumented"
+)
+
+const repositoryName = "sale"
+
+type saleRepository struct {
+	next interfaces.SaleRepository
+}
+
+// NewSaleRepository measures and traces every operation of the wrapped
+// repository.
+func NewSaleRepository(next interfaces.SaleRepository) interfaces.SaleRepository {
+	return &saleRepository{
+		next: next,
+	}
+}
+
+func (ref *saleRepository) Create(ctx context.Context, sale entity.Sale) (*entity.Sale, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Create")
+	created, err := ref.next.Create(ctx, sale)
+	done(err)
+	return created, err
+}
+
+func (ref *saleRepository) Search(ctx context.Context) ([]entity.Sale, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Search")
+	sales, err := ref.next.Search(ctx)
+	done(err)
+	return sales, err
+}
+
+// The duration of Iterate covers the callbacks as well as the cursor.
+func (ref *saleRepository) Iterate(ctx context.Context, fn func(entity.Sale) error) error {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Iterate")
+	err := ref.next.Iterate(ctx, fn)
+	done(err)
+	return err
+}
+
+func (ref *saleRepository) Report(ctx context.Context, filter entity.SalesReportFilter) ([]entity.SalesReportGroup, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Report")
+	groups, err := ref.next.Report(ctx, filter)
+	done(err)
+	return groups, err
+}
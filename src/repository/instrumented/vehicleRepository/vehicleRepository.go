@@ -0,0 +1,89 @@
+package vehicleRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/instrumented"
+)
+
+const repositoryName = "vehicle"
+
+type vehicleRepository struct {
+	next interfaces.VehicleRepository
+}
+
+// NewVehicleRepository records the latency of every operation of the wrapped
+// repository and traces it as a span.
+func NewVehicleRepository(next interfaces.VehicleRepository) interfaces.VehicleRepository {
+	return &vehicleRepository{
+		next: next,
+	}
+}
+
+func (ref *vehicleRepository) Create(ctx context.Context, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Create")
+	created, err := ref.next.Create(ctx, vehicle)
+	done(err)
+	return created, err
+}
+
+func (ref *vehicleRepository) GetByID(ctx context.Context, id string) (*entity.Vehicle, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "GetByID")
+	vehicle, err := ref.next.GetByID(ctx, id)
+	done(err)
+	return vehicle, err
+}
+
+func (ref *vehicleRepository) Search(ctx context.Context, filter entity.VehicleFilter) ([]entity.Vehicle, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Search")
+	vehicles, err := ref.next.Search(ctx, filter)
+	done(err)
+	return vehicles, err
+}
+
+// Iterate also measures the time spent in fn, which for exports includes
+// writing the response.
+func (ref *vehicleRepository) Iterate(ctx context.Context, filter entity.VehicleFilter, fn func(entity.Vehicle) error) error {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Iterate")
+	err := ref.next.Iterate(ctx, filter, fn)
+	done(err)
+	return err
+}
+
+func (ref *vehicleRepository) Update(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Update")
+	updated, err := ref.next.Update(ctx, id, vehicle)
+	done(err)
+	return updated, err
+}
+
+func (ref *vehicleRepository) Replace(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Replace")
+	replaced, err := ref.next.Replace(ctx, id, vehicle)
+	done(err)
+	return replaced, err
+}
+
+func (ref *vehicleRepository) Delete(ctx context.Context, id string, version int64, deletedBy string) (*entity.Vehicle, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Delete")
+	deleted, err := ref.next.Delete(ctx, id, version, deletedBy)
+	done(err)
+	return deleted, err
+}
+
+func (ref *vehicleRepository) Restore(ctx context.Context, id string, version int64) (*entity.Vehicle, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Restore")
+	restored, err := ref.next.Restore(ctx, id, version)
+	done(err)
+	return restored, err
+}
+
+func (ref *vehicleRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, done := instrumented.Start(ctx, repositoryName, "Purge")
+	purged, err := ref.next.Purge(ctx, deletedBefore)
+	done(err)
+	return purged, err
+}
@@ -0,0 +1,49 @@
+package auditRepository
+
+import (
+	"context"
+	"sync"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/google/uuid"
+)
+
+type auditRepository struct {
+	mutex   sync.Mutex
+	records []model.AuditRecord
+}
+
+func NewAuditRepository() interfaces.AuditRepository {
+	return &auditRepository{
+		records: []model.AuditRecord{},
+	}
+}
+
+func (ref *auditRepository) Append(ctx context.Context, record entity.AuditRecord) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	document := model.AuditRecordFromDomain(record)
+	document.ID = uuid.NewString()
+
+	ref.records = append(ref.records, document)
+
+	return nil
+}
+
+func (ref *auditRepository) Search(ctx context.Context, entityID string) ([]entity.AuditRecord, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	records := make([]entity.AuditRecord, 0)
+
+	for _, document := range ref.records {
+		if document.EntityID == entityID {
+			records = append(records, *document.ToDomain())
+		}
+	}
+
+	return records, nil
+}
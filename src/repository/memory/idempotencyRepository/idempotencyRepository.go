@@ -0,0 +1,80 @@
+package idempotencyRepository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+)
+
+type idempotencyRepository struct {
+	mutex   sync.Mutex
+	records map[string]model.IdempotencyRecord
+}
+
+func NewIdempotencyRepository() interfaces.IdempotencyRepository {
+	return &idempotencyRepository{
+		records: map[string]model.IdempotencyRecord{},
+	}
+}
+
+func (ref *idempotencyRepository) Reserve(ctx context.Context, record entity.IdempotencyRecord, now time.Time) (*entity.IdempotencyRecord, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	if existing, ok := ref.records[record.Key]; ok && existing.ExpiresAt.After(now) {
+		return existing.ToDomain(), nil
+	}
+
+	ref.records[record.Key] = model.IdempotencyRecordFromDomain(record)
+
+	return nil, nil
+}
+
+func (ref *idempotencyRepository) Complete(ctx context.Context, record entity.IdempotencyRecord) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	existing, ok := ref.records[record.Key]
+	if !ok || existing.Fingerprint != record.Fingerprint {
+		return nil
+	}
+
+	existing.Status = record.Status
+	existing.StatusCode = record.StatusCode
+	existing.ContentType = record.ContentType
+	existing.Body = string(record.Body)
+	existing.ExpiresAt = record.ExpiresAt
+
+	ref.records[record.Key] = existing
+
+	return nil
+}
+
+func (ref *idempotencyRepository) Delete(ctx context.Context, key string) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	delete(ref.records, key)
+
+	return nil
+}
+
+func (ref *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	deleted := 0
+
+	for key, record := range ref.records {
+		if !record.ExpiresAt.After(now) {
+			delete(ref.records, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,76 @@
+package importJobRepository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/google/uuid"
+)
+
+// importJobRepository is guarded by a mutex because jobs are updated by the
+// import goroutine while the status endpoint reads them.
+type importJobRepository struct {
+	mutex sync.RWMutex
+	jobs  []model.ImportJob
+}
+
+func NewImportJobRepository() interfaces.ImportJobRepository {
+	return &importJobRepository{
+		jobs: []model.ImportJob{},
+	}
+}
+
+func (ref *importJobRepository) Create(ctx context.Context, job entity.ImportJob) (*entity.ImportJob, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	record := model.ImportJobFromDomain(job)
+	record.ID = uuid.NewString()
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	ref.jobs = append(ref.jobs, record)
+
+	return record.ToDomain(), nil
+}
+
+func (ref *importJobRepository) GetByID(ctx context.Context, id string) (*entity.ImportJob, error) {
+	ref.mutex.RLock()
+	defer ref.mutex.RUnlock()
+
+	for _, job := range ref.jobs {
+		if job.ID == id {
+			return job.ToDomain(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (ref *importJobRepository) Update(ctx context.Context, id string, job entity.ImportJob) (*entity.ImportJob, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for i, record := range ref.jobs {
+		if record.ID != id {
+			continue
+		}
+
+		updated := model.ImportJobFromDomain(job)
+		updated.ID = record.ID
+		updated.CreatedAt = record.CreatedAt
+		updated.UpdatedAt = time.Now()
+
+		ref.jobs[i] = updated
+
+		return updated.ToDomain(), nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,155 @@
+package jobRepository
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/google/uuid"
+)
+
+type jobRepository struct {
+	mutex sync.Mutex
+	jobs  []model.Job
+}
+
+func NewJobRepository() interfaces.JobRepository {
+	return &jobRepository{
+		jobs: []model.Job{},
+	}
+}
+
+func (ref *jobRepository) Create(ctx context.Context, job entity.Job) (*entity.Job, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	record := model.JobFromDomain(job)
+	record.ID = uuid.NewString()
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	ref.jobs = append(ref.jobs, record)
+
+	return record.ToDomain(), nil
+}
+
+func (ref *jobRepository) GetByID(ctx context.Context, id string) (*entity.Job, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for _, job := range ref.jobs {
+		if job.ID == id {
+			return job.ToDomain(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (ref *jobRepository) Update(ctx context.Context, id string, job entity.Job) (*entity.Job, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for i, record := range ref.jobs {
+		if record.ID != id {
+			continue
+		}
+
+		updated := model.JobFromDomain(job)
+		updated.ID = record.ID
+		updated.CreatedAt = record.CreatedAt
+		updated.UpdatedAt = time.Now()
+
+		ref.jobs[i] = updated
+
+		return updated.ToDomain(), nil
+	}
+
+	return nil, nil
+}
+
+func (ref *jobRepository) Cancel(ctx context.Context, id string, finishedAt time.Time) (*entity.Job, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for i, job := range ref.jobs {
+		if job.ID != id {
+			continue
+		}
+
+		if job.Status != entity.JobStatusPending && job.Status != entity.JobStatusRunning {
+			return nil, nil
+		}
+
+		ref.jobs[i].Status = entity.JobStatusCanceled
+		ref.jobs[i].LockedUntil = nil
+		ref.jobs[i].FinishedAt = &finishedAt
+		ref.jobs[i].UpdatedAt = finishedAt
+
+		return ref.jobs[i].ToDomain(), nil
+	}
+
+	return nil, nil
+}
+
+func (ref *jobRepository) ClaimNext(ctx context.Context, types []string, now, lockedUntil time.Time) (*entity.Job, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	next := -1
+
+	for i, job := range ref.jobs {
+		if !slices.Contains(types, job.Type) {
+			continue
+		}
+
+		due := job.Status == entity.JobStatusPending && !job.RunAt.After(now)
+		expired := job.Status == entity.JobStatusRunning && job.LockedUntil != nil && job.LockedUntil.Before(now)
+
+		if !due && !expired {
+			continue
+		}
+
+		if next == -1 || job.RunAt.Before(ref.jobs[next].RunAt) {
+			next = i
+		}
+	}
+
+	if next == -1 {
+		return nil, nil
+	}
+
+	ref.jobs[next].Status = entity.JobStatusRunning
+	ref.jobs[next].Attempts++
+	ref.jobs[next].LockedUntil = &lockedUntil
+	ref.jobs[next].StartedAt = &now
+	ref.jobs[next].UpdatedAt = now
+
+	return ref.jobs[next].ToDomain(), nil
+}
+
+func (ref *jobRepository) Heartbeat(ctx context.Context, id string, lockedUntil time.Time) (*entity.Job, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for i, job := range ref.jobs {
+		if job.ID != id {
+			continue
+		}
+
+		if job.Status == entity.JobStatusRunning {
+			ref.jobs[i].LockedUntil = &lockedUntil
+			ref.jobs[i].UpdatedAt = time.Now()
+		}
+
+		return ref.jobs[i].ToDomain(), nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,89 @@
+package outboxRepository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/google/uuid"
+)
+
+type outboxRepository struct {
+	mutex    sync.Mutex
+	messages []model.OutboxMessage
+}
+
+func NewOutboxRepository() interfaces.OutboxRepository {
+	return &outboxRepository{
+		messages: []model.OutboxMessage{},
+	}
+}
+
+func (ref *outboxRepository) Append(ctx context.Context, event entity.Event) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	record := model.OutboxMessageFromDomain(event)
+	record.ID = uuid.NewString()
+
+	ref.messages = append(ref.messages, record)
+
+	return nil
+}
+
+// ClaimPending returns messages in the order they were appended.
+func (ref *outboxRepository) ClaimPending(ctx context.Context, limit int, now, lockedUntil time.Time) ([]entity.OutboxMessage, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	messages := make([]entity.OutboxMessage, 0, limit)
+
+	for i, message := range ref.messages {
+		if len(messages) == limit {
+			break
+		}
+
+		if message.Status != entity.OutboxStatusPending || message.AvailableAt.After(now) {
+			continue
+		}
+
+		ref.messages[i].AvailableAt = lockedUntil
+		ref.messages[i].Attempts++
+
+		messages = append(messages, *ref.messages[i].ToDomain())
+	}
+
+	return messages, nil
+}
+
+func (ref *outboxRepository) MarkPublished(ctx context.Context, id string, publishedAt time.Time) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for i, message := range ref.messages {
+		if message.ID == id {
+			ref.messages[i].Status = entity.OutboxStatusPublished
+			ref.messages[i].PublishedAt = &publishedAt
+			ref.messages[i].LastError = ""
+		}
+	}
+
+	return nil
+}
+
+func (ref *outboxRepository) MarkFailed(ctx context.Context, id, lastError string, availableAt time.Time) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for i, message := range ref.messages {
+		if message.ID == id {
+			ref.messages[i].LastError = lastError
+			ref.messages[i].AvailableAt = availableAt
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package paymentRepository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/snapshot"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/google/uuid"
+)
+
+type paymentRepository struct {
+	mutex    sync.Mutex
+	payments []model.Payment
+	path     string
+}
+
+func NewPaymentRepository() interfaces.PaymentRepository {
+	return &paymentRepository{
+		payments: []model.Payment{},
+	}
+}
+
+// LoadPaymentRepository starts with the payments saved in the JSON file at
+// path, if it exists, and saves every payment back to it after each change.
+// Pending payments outlive a restart this way, so the vehicles they reserve
+// are still released when they expire. With an empty path it is the same as
+// NewPaymentRepository.
+func LoadPaymentRepository(path string) (interfaces.PaymentRepository, error) {
+	records, err := snapshot.Load[model.Payment](path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &paymentRepository{
+		payments: append([]model.Payment{}, records...),
+		path:     path,
+	}, nil
+}
+
+func (ref *paymentRepository) Create(ctx context.Context, payment entity.Payment) (*entity.Payment, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	record := model.PaymentFromDomain(payment)
+	record.ID = uuid.NewString()
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	ref.payments = append(ref.payments, record)
+
+	if err := ref.save(); err != nil {
+		ref.payments = ref.payments[:len(ref.payments)-1]
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (ref *paymentRepository) GetByID(ctx context.Context, id string) (*entity.Payment, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for _, payment := range ref.payments {
+		if payment.ID == id {
+			return payment.ToDomain(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (ref *paymentRepository) GetByIntentID(ctx context.Context, intentID string) (*entity.Payment, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for _, payment := range ref.payments {
+		if payment.IntentID == intentID {
+			return payment.ToDomain(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (ref *paymentRepository) SearchExpired(ctx context.Context, before time.Time) ([]entity.Payment, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	payments := make([]entity.Payment, 0)
+
+	for _, payment := range ref.payments {
+		if payment.Status == entity.PaymentStatusPending && payment.ExpiresAt.Before(before) {
+			payments = append(payments, *payment.ToDomain())
+		}
+	}
+
+	return payments, nil
+}
+
+func (ref *paymentRepository) Update(ctx context.Context, id string, payment entity.Payment) (*entity.Payment, error) {
+	return ref.update(id, payment, false)
+}
+
+func (ref *paymentRepository) UpdatePending(ctx context.Context, id string, payment entity.Payment) (*entity.Payment, error) {
+	return ref.update(id, payment, true)
+}
+
+func (ref *paymentRepository) update(id string, payment entity.Payment, pendingOnly bool) (*entity.Payment, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for i, record := range ref.payments {
+		if record.ID != id {
+			continue
+		}
+
+		if pendingOnly && record.Status != entity.PaymentStatusPending {
+			return nil, nil
+		}
+
+		if payment.Status != "" {
+			ref.payments[i].Status = payment.Status
+		}
+
+		if payment.IntentID != "" {
+			ref.payments[i].IntentID = payment.IntentID
+		}
+
+		if payment.ClientSecret != "" {
+			ref.payments[i].ClientSecret = payment.ClientSecret
+		}
+
+		if payment.SaleID != "" {
+			ref.payments[i].SaleID = payment.SaleID
+		}
+
+		ref.payments[i].UpdatedAt = time.Now()
+
+		if err := ref.save(); err != nil {
+			ref.payments[i] = record
+			return nil, err
+		}
+
+		return ref.payments[i].ToDomain(), nil
+	}
+
+	return nil, nil
+}
+
+// save writes the payments to the snapshot, when the repository has one. It
+// must be called with the lock held.
+func (ref *paymentRepository) save() error {
+	if ref.path == "" {
+		return nil
+	}
+
+	return snapshot.Save(ref.path, ref.payments)
+}
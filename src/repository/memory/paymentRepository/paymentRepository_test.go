@@ -0,0 +1,79 @@
+package paymentRepository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPaymentRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should reload pending payments saved before", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "payments.json")
+
+		repository, err := LoadPaymentRepository(path)
+		require.NoError(t, err)
+
+		expiresAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Millisecond)
+
+		created, err := repository.Create(ctx, entity.Payment{
+			VehicleID: "vehicle-1",
+			Amount:    50000,
+			Status:    entity.PaymentStatusPending,
+			ExpiresAt: expiresAt,
+		})
+		require.NoError(t, err)
+
+		reloaded, err := LoadPaymentRepository(path)
+		require.NoError(t, err)
+
+		expired, err := reloaded.SearchExpired(ctx, time.Now())
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, created.ID, expired[0].ID)
+		assert.Equal(t, "vehicle-1", expired[0].VehicleID)
+		assert.True(t, expiresAt.Equal(expired[0].ExpiresAt))
+	})
+
+	t.Run("should fail with malformed snapshot", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "payments.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+		actual, err := LoadPaymentRepository(path)
+
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	t.Run("should keep payment unchanged when snapshot cannot be saved", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "payments.json")
+
+		repository, err := LoadPaymentRepository(path)
+		require.NoError(t, err)
+
+		created, err := repository.Create(ctx, entity.Payment{VehicleID: "vehicle-1", Status: entity.PaymentStatusPending})
+		require.NoError(t, err)
+
+		// The snapshot cannot be renamed over a directory.
+		require.NoError(t, os.Remove(path))
+		require.NoError(t, os.Mkdir(path, 0o700))
+
+		actual, err := repository.UpdatePending(ctx, created.ID, entity.Payment{Status: entity.PaymentStatusCanceled})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+
+		_, err = repository.Create(ctx, entity.Payment{VehicleID: "vehicle-2", Status: entity.PaymentStatusPending})
+		assert.Error(t, err)
+
+		payment, err := repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created, payment)
+	})
+}
@@ -2,44 +2,197 @@ package saleRepository
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
 	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/snapshot"
 	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
-	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type saleRepository struct {
-	sales []model.Sale
+	mutex             sync.RWMutex
+	sales             []model.Sale
+	path              string
+	vehicleRepository interfaces.VehicleRepository
 }
 
-func NewSaleRepository() interfaces.SaleRepository {
+// NewSaleRepository mirrors the MongoDB repository: ids are ObjectID hex
+// strings and times are stored in UTC with millisecond precision.
+func NewSaleRepository(vehicleRepository interfaces.VehicleRepository) interfaces.SaleRepository {
 	return &saleRepository{
-		sales: []model.Sale{},
+		sales:             []model.Sale{},
+		vehicleRepository: vehicleRepository,
 	}
 }
 
+// LoadSaleRepository starts with the sales saved in the JSON file at path, if
+// it exists, and saves every sale back to it after each one is created. With
+// an empty path it is the same as NewSaleRepository.
+func LoadSaleRepository(path string, vehicleRepository interfaces.VehicleRepository) (interfaces.SaleRepository, error) {
+	records, err := snapshot.Load[model.Sale](path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saleRepository{
+		sales:             append([]model.Sale{}, records...),
+		path:              path,
+		vehicleRepository: vehicleRepository,
+	}, nil
+}
+
 func (ref *saleRepository) Create(ctx context.Context, sale entity.Sale) (*entity.Sale, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
 	record := model.SaleFromDomain(sale)
-	record.ID = uuid.NewString()
+	record.ID = primitive.NewObjectID().Hex()
+	record.SoldAt = record.SoldAt.UTC().Truncate(time.Millisecond)
 
 	ref.sales = append(ref.sales, record)
 
-	for _, sale := range ref.sales {
-		if sale.ID == record.ID {
-			return sale.ToDomain(), nil
+	if ref.path != "" {
+		if err := snapshot.Save(ref.path, ref.sales); err != nil {
+			ref.sales = ref.sales[:len(ref.sales)-1]
+			return nil, err
 		}
 	}
 
-	return nil, nil
+	return record.ToDomain(), nil
 }
 
 func (ref *saleRepository) Search(ctx context.Context) ([]entity.Sale, error) {
-	sales := make([]entity.Sale, len(ref.sales))
+	records := ref.records()
+
+	sales := make([]entity.Sale, len(records))
 
-	for i, sale := range ref.sales {
+	for i, sale := range records {
 		sales[i] = *sale.ToDomain()
 	}
 
 	return sales, nil
 }
+
+// Iterate calls fn without holding the lock, so that fn may use the
+// repository.
+func (ref *saleRepository) Iterate(ctx context.Context, fn func(entity.Sale) error) error {
+	for _, sale := range ref.records() {
+		if err := fn(*sale.ToDomain()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ref *saleRepository) Report(ctx context.Context, filter entity.SalesReportFilter) ([]entity.SalesReportGroup, error) {
+	type accumulator struct {
+		count            int
+		revenue          float64
+		daysOnMarket     float64
+		daysOnMarketSize int
+	}
+
+	accumulators := map[string]*accumulator{}
+
+	for _, sale := range ref.records() {
+		if filter.From != nil && sale.SoldAt.Before(*filter.From) {
+			continue
+		}
+
+		if filter.To != nil && !sale.SoldAt.Before(*filter.To) {
+			continue
+		}
+
+		var vehicle *entity.Vehicle
+
+		// Like the MongoDB lookup, sales of vehicles with malformed ids are
+		// reported without their vehicle.
+		if primitive.IsValidObjectID(sale.VehicleID) {
+			var err error
+			if vehicle, err = ref.vehicleRepository.GetByID(ctx, sale.VehicleID); err != nil {
+				return nil, err
+			}
+		}
+
+		key := reportGroupKey(filter.GroupBy, sale, vehicle)
+
+		if _, ok := accumulators[key]; !ok {
+			accumulators[key] = &accumulator{}
+		}
+
+		accumulators[key].count++
+		accumulators[key].revenue += sale.Price - sale.TradeInCredit
+
+		if vehicle != nil {
+			accumulators[key].daysOnMarket += sale.SoldAt.Sub(vehicle.CreatedAt).Hours() / 24
+			accumulators[key].daysOnMarketSize++
+		}
+	}
+
+	groups := make([]entity.SalesReportGroup, 0, len(accumulators))
+
+	for key, accumulator := range accumulators {
+		group := entity.SalesReportGroup{
+			Key:           key,
+			Count:         accumulator.count,
+			Revenue:       accumulator.revenue,
+			AverageTicket: accumulator.revenue / float64(accumulator.count),
+		}
+
+		if accumulator.daysOnMarketSize > 0 {
+			group.AverageDaysOnMarket = accumulator.daysOnMarket / float64(accumulator.daysOnMarketSize)
+		}
+
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Key < groups[j].Key
+	})
+
+	return groups, nil
+}
+
+// records returns the sales made so far. Sales are never changed, so the
+// slice can be read after the lock is released.
+func (ref *saleRepository) records() []model.Sale {
+	ref.mutex.RLock()
+	defer ref.mutex.RUnlock()
+
+	return ref.sales[:len(ref.sales):len(ref.sales)]
+}
+
+func reportGroupKey(groupBy string, sale model.Sale, vehicle *entity.Vehicle) string {
+	soldAt := sale.SoldAt.UTC()
+
+	switch groupBy {
+	case entity.SalesReportGroupByDay:
+		return soldAt.Format("2006-01-02")
+	case entity.SalesReportGroupByWeek:
+		year, week := soldAt.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case entity.SalesReportGroupByMonth:
+		return soldAt.Format("2006-01")
+	}
+
+	if vehicle == nil {
+		vehicle = &entity.Vehicle{}
+	}
+
+	switch groupBy {
+	case entity.SalesReportGroupByBrand:
+		return vehicle.Brand
+	case entity.SalesReportGroupByModel:
+		return vehicle.Brand + " " + vehicle.Model
+	case entity.SalesReportGroupBySeller:
+		return vehicle.SellerID
+	default:
+		return ""
+	}
+}
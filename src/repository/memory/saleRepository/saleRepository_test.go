@@ -0,0 +1,58 @@
+package saleRepository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/conformance"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaleRepository(t *testing.T) {
+	conformance.SaleRepository(t, func(t *testing.T) (interfaces.VehicleRepository, interfaces.SaleRepository) {
+		vehicleRepository := vehicleRepository.NewVehicleRepository()
+
+		return vehicleRepository, NewSaleRepository(vehicleRepository)
+	})
+}
+
+func TestLoadSaleRepository(t *testing.T) {
+	conformance.SaleRepository(t, func(t *testing.T) (interfaces.VehicleRepository, interfaces.SaleRepository) {
+		dir := t.TempDir()
+
+		vehicleRepository, err := vehicleRepository.LoadVehicleRepository(filepath.Join(dir, "vehicles.json"))
+		require.NoError(t, err)
+
+		saleRepository, err := LoadSaleRepository(filepath.Join(dir, "sales.json"), vehicleRepository)
+		require.NoError(t, err)
+
+		return vehicleRepository, saleRepository
+	})
+
+	t.Run("should reload sales saved before", func(t *testing.T) {
+		ctx := context.TODO()
+		path := filepath.Join(t.TempDir(), "sales.json")
+
+		repository, err := LoadSaleRepository(path, vehicleRepository.NewVehicleRepository())
+		require.NoError(t, err)
+
+		first, err := repository.Create(ctx, entity.Sale{VehicleID: "vehicle-1", Price: 50000, SoldAt: time.Now()})
+		require.NoError(t, err)
+
+		second, err := repository.Create(ctx, entity.Sale{VehicleID: "vehicle-2", Price: 30000, SoldAt: time.Now()})
+		require.NoError(t, err)
+
+		reloaded, err := LoadSaleRepository(path, vehicleRepository.NewVehicleRepository())
+		require.NoError(t, err)
+
+		actual, err := reloaded.Search(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []entity.Sale{*first, *second}, actual)
+	})
+}
@@ -0,0 +1,73 @@
+// Package snapshot keeps the records of a memory repository in a JSON file,
+// so that the repository can be used as a backend that survives restarts.
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Load reads the records saved at path. A file that does not exist yet, or
+// an empty path, holds no records.
+func Load[T any](path string) ([]T, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var records []T
+	if err = json.Unmarshal(content, &records); err != nil {
+		return nil, fmt.Errorf("could not parse snapshot %s: %w", path, err)
+	}
+
+	return records, nil
+}
+
+// Save replaces the file at path with records. They are written to a
+// temporary file that is then renamed over it, so that a crash never leaves a
+// partial snapshot behind.
+func Save[T any](path string, records []T) error {
+	content, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
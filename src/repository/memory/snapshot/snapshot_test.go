@@ -0,0 +1,52 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	ID    string  `json:"id"`
+	Price float64 `json:"price"`
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should load nothing when file does not exist", func(t *testing.T) {
+		actual, err := Load[record](filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should fail with malformed file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "records.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[{"id":`), 0o600))
+
+		actual, err := Load[record](path)
+
+		assert.Nil(t, actual)
+		assert.ErrorContains(t, err, "could not parse snapshot")
+	})
+}
+
+func TestSave(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	path := filepath.Join(dir, "records.json")
+
+	records := []record{{ID: "1", Price: 50000}, {ID: "2", Price: 30000}}
+
+	require.NoError(t, Save(path, records))
+	require.NoError(t, Save(path, records[:1]))
+
+	actual, err := Load[record](path)
+	require.NoError(t, err)
+	assert.Equal(t, records[:1], actual)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
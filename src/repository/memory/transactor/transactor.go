@@ -0,0 +1,20 @@
+package transactor
+
+import (
+	"context"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+)
+
+// transactor runs functions without a transaction: the memory repositories
+// cannot roll back, so when a write fails, for instance because a snapshot
+// cannot be saved, the writes fn made before it are kept.
+type transactor struct{}
+
+func NewTransactor() interfaces.Transactor {
+	return &transactor{}
+}
+
+func (ref *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
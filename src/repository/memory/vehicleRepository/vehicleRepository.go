@@ -3,141 +3,370 @@ package vehicleRepository
 import (
 	"context"
 	"sort"
+	"sync"
 	"time"
 
 	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
 	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/snapshot"
 	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
-	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type vehicleRepository struct {
-	vehicles []model.Vehicle
+	mutex    sync.RWMutex
+	vehicles map[string]model.Vehicle
+	// ids keeps the order the vehicles were created in.
+	ids  []string
+	path string
 }
 
+// NewVehicleRepository mirrors the MongoDB repository: ids are ObjectID hex
+// strings and times are stored in UTC with millisecond precision.
 func NewVehicleRepository() interfaces.VehicleRepository {
+	return newVehicleRepository("")
+}
+
+// LoadVehicleRepository starts with the vehicles saved in the JSON file at
+// path, if it exists, and saves every vehicle back to it after each change.
+// With an empty path it is the same as NewVehicleRepository.
+func LoadVehicleRepository(path string) (interfaces.VehicleRepository, error) {
+	records, err := snapshot.Load[model.Vehicle](path)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := newVehicleRepository(path)
+
+	for _, record := range records {
+		repository.vehicles[record.ID] = record
+		repository.ids = append(repository.ids, record.ID)
+	}
+
+	return repository, nil
+}
+
+func newVehicleRepository(path string) *vehicleRepository {
 	return &vehicleRepository{
-		vehicles: []model.Vehicle{},
+		vehicles: map[string]model.Vehicle{},
+		ids:      []string{},
+		path:     path,
 	}
 }
 
 func (ref *vehicleRepository) Create(ctx context.Context, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
 	record := model.VehicleFromDomain(vehicle)
 
-	record.ID = uuid.NewString()
+	record.ID = primitive.NewObjectID().Hex()
+	record.Version = 1
+	record.SoldAt = timestampPointer(record.SoldAt)
+	record.DeletedAt = timestampPointer(record.DeletedAt)
 
-	now := time.Now()
+	now := timestamp(time.Now())
 	record.CreatedAt = now
 	record.UpdatedAt = now
 
-	ref.vehicles = append(ref.vehicles, record)
-
-	for _, vehicle := range ref.vehicles {
-		if vehicle.ID == record.ID {
-			return vehicle.ToDomain(), nil
-		}
+	if err := ref.put(record); err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return record.ToDomain(), nil
 }
 
 func (ref *vehicleRepository) GetByID(ctx context.Context, id string) (*entity.Vehicle, error) {
-	for _, vehicle := range ref.vehicles {
-		if vehicle.ID == id {
-			return vehicle.ToDomain(), nil
-		}
+	ref.mutex.RLock()
+	defer ref.mutex.RUnlock()
+
+	record, ok := ref.vehicles[id]
+	if !ok {
+		return nil, nil
 	}
 
-	return nil, nil
+	return record.ToDomain(), nil
 }
 
-func (ref *vehicleRepository) Search(ctx context.Context, isSold *bool) ([]entity.Vehicle, error) {
-	var (
-		hasFilter              bool
-		filterJustSoldVehicles bool
-	)
-
-	if isSold != nil {
-		hasFilter = true
-		filterJustSoldVehicles = *isSold
-	}
+func (ref *vehicleRepository) Search(ctx context.Context, filter entity.VehicleFilter) ([]entity.Vehicle, error) {
+	ref.mutex.RLock()
+	defer ref.mutex.RUnlock()
 
 	vehicles := make([]entity.Vehicle, 0)
 
-	for _, vehicle := range ref.vehicles {
-		if hasFilter {
-			if filterJustSoldVehicles {
-				if vehicle.SoldAt != nil {
-					vehicles = append(vehicles, *vehicle.ToDomain())
-					continue
-				}
-			} else {
-				if vehicle.SoldAt == nil {
-					vehicles = append(vehicles, *vehicle.ToDomain())
-					continue
-				}
-			}
+	for _, id := range ref.ids {
+		vehicle := ref.vehicles[id]
+
+		if vehicle.DeletedAt != nil && !filter.IncludeDeleted {
+			continue
+		}
+
+		if filter.IsSold != nil && *filter.IsSold != (vehicle.SoldAt != nil) {
+			continue
 		}
 
 		vehicles = append(vehicles, *vehicle.ToDomain())
 	}
 
-	sort.Slice(vehicles, func(i, j int) bool {
+	sort.SliceStable(vehicles, func(i, j int) bool {
 		return vehicles[i].Price < vehicles[j].Price
 	})
 
 	return vehicles, nil
 }
 
+// Iterate calls fn without holding the lock, so that fn may use the
+// repository.
+func (ref *vehicleRepository) Iterate(ctx context.Context, filter entity.VehicleFilter, fn func(entity.Vehicle) error) error {
+	vehicles, err := ref.Search(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	for _, vehicle := range vehicles {
+		if err = fn(vehicle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update mirrors the MongoDB $set of the non-zero fields.
 func (ref *vehicleRepository) Update(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
-	vehicleIndex := -1
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	record, err := ref.find(id, vehicle.Version)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	if vehicle.Brand != "" {
+		record.Brand = vehicle.Brand
+	}
+
+	if vehicle.Model != "" {
+		record.Model = vehicle.Model
+	}
+
+	if vehicle.Year != 0 {
+		record.Year = vehicle.Year
+	}
+
+	if vehicle.Color != "" {
+		record.Color = vehicle.Color
+	}
+
+	if vehicle.Price != 0 {
+		record.Price = vehicle.Price
+	}
+
+	if vehicle.Status != "" {
+		record.Status = vehicle.Status
+	}
+
+	if vehicle.SellerID != "" {
+		record.SellerID = vehicle.SellerID
+	}
+
+	if vehicle.SoldAt != nil {
+		record.SoldAt = timestampPointer(vehicle.SoldAt)
+	}
+
+	if vehicle.DeletedAt != nil {
+		record.DeletedAt = timestampPointer(vehicle.DeletedAt)
+	}
+
+	if vehicle.DeletedBy != "" {
+		record.DeletedBy = vehicle.DeletedBy
+	}
+
+	record.Version++
+	record.UpdatedAt = timestamp(time.Now())
+
+	if err = ref.put(*record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (ref *vehicleRepository) Replace(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	record, err := ref.find(id, vehicle.Version)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	record.Brand = vehicle.Brand
+	record.Model = vehicle.Model
+	record.Year = vehicle.Year
+	record.Color = vehicle.Color
+	record.Price = vehicle.Price
+	record.Status = vehicle.Status
+	record.Version++
+	record.UpdatedAt = timestamp(time.Now())
+
+	if err = ref.put(*record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (ref *vehicleRepository) Delete(ctx context.Context, id string, version int64, deletedBy string) (*entity.Vehicle, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	record, err := ref.find(id, version)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	now := timestamp(time.Now())
+
+	record.DeletedAt = &now
+	record.DeletedBy = deletedBy
+	record.Version++
+	record.UpdatedAt = now
+
+	if err = ref.put(*record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (ref *vehicleRepository) Restore(ctx context.Context, id string, version int64) (*entity.Vehicle, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	record, err := ref.find(id, version)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	record.DeletedAt = nil
+	record.DeletedBy = ""
+	record.Version++
+	record.UpdatedAt = timestamp(time.Now())
+
+	if err = ref.put(*record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
 
-	for i, vehicle := range ref.vehicles {
-		if vehicle.ID == id {
-			vehicleIndex = i
-			break
+func (ref *vehicleRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	kept := make([]string, 0, len(ref.ids))
+
+	for _, id := range ref.ids {
+		vehicle := ref.vehicles[id]
+
+		if vehicle.DeletedAt == nil || !vehicle.DeletedAt.Before(deletedBefore) {
+			kept = append(kept, id)
 		}
 	}
 
-	if vehicleIndex == -1 {
-		return nil, nil
+	purged := len(ref.ids) - len(kept)
+	if purged == 0 {
+		return 0, nil
 	}
 
-	var hasUpdate bool
+	if err := ref.save(kept); err != nil {
+		return 0, err
+	}
+
+	keptIDs := make(map[string]bool, len(kept))
+	for _, id := range kept {
+		keptIDs[id] = true
+	}
+
+	for _, id := range ref.ids {
+		if !keptIDs[id] {
+			delete(ref.vehicles, id)
+		}
+	}
+
+	ref.ids = kept
+
+	return purged, nil
+}
 
-	if vehicle.Brand != "" && vehicle.Brand != ref.vehicles[vehicleIndex].Brand {
-		ref.vehicles[vehicleIndex].Brand = vehicle.Brand
-		hasUpdate = true
+// find returns a copy of the stored vehicle to be changed and put back,
+// checking that it is still at version when version is set. It must be
+// called with the lock held.
+func (ref *vehicleRepository) find(id string, version int64) (*model.Vehicle, error) {
+	record, ok := ref.vehicles[id]
+	if !ok {
+		return nil, nil
 	}
 
-	if vehicle.Model != "" && vehicle.Model != ref.vehicles[vehicleIndex].Model {
-		ref.vehicles[vehicleIndex].Model = vehicle.Model
-		hasUpdate = true
+	if version != 0 && version != record.Version {
+		return nil, entity.ErrVehicleVersionMismatch
 	}
 
-	if vehicle.Year != 0 && vehicle.Year != ref.vehicles[vehicleIndex].Year {
-		ref.vehicles[vehicleIndex].Year = vehicle.Year
-		hasUpdate = true
+	return &record, nil
+}
+
+// put stores the vehicle and saves the snapshot, undoing the change when the
+// snapshot cannot be saved. It must be called with the lock held.
+func (ref *vehicleRepository) put(record model.Vehicle) error {
+	previous, exists := ref.vehicles[record.ID]
+
+	ref.vehicles[record.ID] = record
+	if !exists {
+		ref.ids = append(ref.ids, record.ID)
 	}
 
-	if vehicle.Color != "" && vehicle.Color != ref.vehicles[vehicleIndex].Color {
-		ref.vehicles[vehicleIndex].Color = vehicle.Color
-		hasUpdate = true
+	if err := ref.save(ref.ids); err != nil {
+		if exists {
+			ref.vehicles[record.ID] = previous
+		} else {
+			delete(ref.vehicles, record.ID)
+			ref.ids = ref.ids[:len(ref.ids)-1]
+		}
+
+		return err
 	}
 
-	if vehicle.Price != 0 && vehicle.Price != ref.vehicles[vehicleIndex].Price {
-		ref.vehicles[vehicleIndex].Price = vehicle.Price
-		hasUpdate = true
+	return nil
+}
+
+// save writes the vehicles with the given ids to the snapshot, when the
+// repository has one. snapshot.Save writes a temporary file and renames it
+// over the snapshot, so a crash leaves either the old or the new file.
+func (ref *vehicleRepository) save(ids []string) error {
+	if ref.path == "" {
+		return nil
 	}
 
-	if vehicle.SoldAt != nil && vehicle.SoldAt != ref.vehicles[vehicleIndex].SoldAt {
-		ref.vehicles[vehicleIndex].SoldAt = vehicle.SoldAt
-		hasUpdate = true
+	records := make([]model.Vehicle, len(ids))
+
+	for i, id := range ids {
+		records[i] = ref.vehicles[id]
 	}
 
-	if hasUpdate {
-		ref.vehicles[vehicleIndex].UpdatedAt = time.Now()
+	return snapshot.Save(ref.path, records)
+}
+
+// timestamp rounds t the way MongoDB stores it.
+func timestamp(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Millisecond)
+}
+
+func timestampPointer(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
 	}
 
-	return ref.vehicles[vehicleIndex].ToDomain(), nil
+	rounded := timestamp(*t)
+	return &rounded
 }
@@ -0,0 +1,138 @@
+package vehicleRepository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/conformance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVehicleRepository(t *testing.T) {
+	conformance.VehicleRepository(t, func(t *testing.T) interfaces.VehicleRepository {
+		return NewVehicleRepository()
+	})
+}
+
+func TestLoadVehicleRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	conformance.VehicleRepository(t, func(t *testing.T) interfaces.VehicleRepository {
+		repository, err := LoadVehicleRepository(filepath.Join(t.TempDir(), "vehicles.json"))
+		require.NoError(t, err)
+
+		return repository
+	})
+
+	t.Run("should reload vehicles saved before", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "vehicles.json")
+
+		repository, err := LoadVehicleRepository(path)
+		require.NoError(t, err)
+
+		purged, err := repository.Create(ctx, entity.Vehicle{Brand: "Fiat", Price: 30000})
+		require.NoError(t, err)
+
+		_, err = repository.Delete(ctx, purged.ID, purged.Version, "user-1")
+		require.NoError(t, err)
+
+		_, err = repository.Purge(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		created, err := repository.Create(ctx, entity.Vehicle{Brand: "Ford", Price: 50000})
+		require.NoError(t, err)
+
+		updated, err := repository.Update(ctx, created.ID, entity.Vehicle{Price: 45000})
+		require.NoError(t, err)
+
+		reloaded, err := LoadVehicleRepository(path)
+		require.NoError(t, err)
+
+		actual, err := reloaded.Search(ctx, entity.VehicleFilter{IncludeDeleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, []entity.Vehicle{*updated}, actual)
+	})
+
+	t.Run("should fail with malformed snapshot", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "vehicles.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+		actual, err := LoadVehicleRepository(path)
+
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	t.Run("should keep vehicle unchanged when snapshot cannot be saved", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "vehicles.json")
+
+		repository, err := LoadVehicleRepository(path)
+		require.NoError(t, err)
+
+		created, err := repository.Create(ctx, entity.Vehicle{Brand: "Ford", Price: 50000})
+		require.NoError(t, err)
+
+		// The snapshot cannot be renamed over a directory.
+		require.NoError(t, os.Remove(path))
+		require.NoError(t, os.Mkdir(path, 0o700))
+
+		actual, err := repository.Update(ctx, created.ID, entity.Vehicle{Price: 45000})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+
+		_, err = repository.Create(ctx, entity.Vehicle{Brand: "Fiat", Price: 30000})
+		assert.Error(t, err)
+
+		vehicles, err := repository.Search(ctx, entity.VehicleFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []entity.Vehicle{*created}, vehicles)
+	})
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	ctx := context.TODO()
+
+	repository := NewVehicleRepository()
+
+	created, err := repository.Create(ctx, entity.Vehicle{Brand: "Ford", Price: 50000})
+	require.NoError(t, err)
+
+	var (
+		wg        sync.WaitGroup
+		mutex     sync.Mutex
+		succeeded int
+	)
+
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// Every buyer read the same version, so only one may reserve it.
+			_, err := repository.Update(ctx, created.ID, entity.Vehicle{Status: entity.VehicleStatusReserved, Version: created.Version})
+			if err == nil {
+				mutex.Lock()
+				succeeded++
+				mutex.Unlock()
+			}
+
+			_, _ = repository.Search(ctx, entity.VehicleFilter{})
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, succeeded)
+
+	actual, err := repository.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), actual.Version)
+}
@@ -0,0 +1,74 @@
+package vehicleVersionRepository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/google/uuid"
+)
+
+type vehicleVersionRepository struct {
+	mutex    sync.Mutex
+	versions []model.VehicleVersion
+}
+
+func NewVehicleVersionRepository() interfaces.VehicleVersionRepository {
+	return &vehicleVersionRepository{
+		versions: []model.VehicleVersion{},
+	}
+}
+
+func (ref *vehicleVersionRepository) Append(ctx context.Context, version entity.VehicleVersion) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	document := model.VehicleVersionFromDomain(version)
+	document.ID = uuid.NewString()
+
+	ref.versions = append(ref.versions, document)
+
+	return nil
+}
+
+// Search relies on versions being appended in order.
+func (ref *vehicleVersionRepository) Search(ctx context.Context, vehicleID string) ([]entity.VehicleVersion, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	versions := make([]entity.VehicleVersion, 0)
+
+	for _, document := range ref.versions {
+		if document.VehicleID == vehicleID {
+			versions = append(versions, *document.ToDomain())
+		}
+	}
+
+	return versions, nil
+}
+
+func (ref *vehicleVersionRepository) GetAsOf(ctx context.Context, vehicleID string, asOf time.Time) (*entity.VehicleVersion, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	var found *model.VehicleVersion
+
+	for i, document := range ref.versions {
+		if document.VehicleID != vehicleID || document.RecordedAt.After(asOf) {
+			continue
+		}
+
+		if found == nil || document.Version > found.Version {
+			found = &ref.versions[i]
+		}
+	}
+
+	if found == nil {
+		return nil, nil
+	}
+
+	return found.ToDomain(), nil
+}
@@ -0,0 +1,121 @@
+package webhookDeliveryRepository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/google/uuid"
+)
+
+type webhookDeliveryRepository struct {
+	mutex      sync.Mutex
+	deliveries []model.WebhookDelivery
+}
+
+func NewWebhookDeliveryRepository() interfaces.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		deliveries: []model.WebhookDelivery{},
+	}
+}
+
+func (ref *webhookDeliveryRepository) Enqueue(ctx context.Context, delivery entity.WebhookDelivery) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for _, record := range ref.deliveries {
+		if record.SubscriptionID == delivery.SubscriptionID && record.EventID == delivery.EventID {
+			return nil
+		}
+	}
+
+	record := model.WebhookDeliveryFromDomain(delivery)
+	record.ID = uuid.NewString()
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	ref.deliveries = append(ref.deliveries, record)
+
+	return nil
+}
+
+func (ref *webhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for _, delivery := range ref.deliveries {
+		if delivery.ID == id {
+			return delivery.ToDomain(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (ref *webhookDeliveryRepository) SearchBySubscriptionID(ctx context.Context, subscriptionID string) ([]entity.WebhookDelivery, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+
+	for i := len(ref.deliveries) - 1; i >= 0; i-- {
+		if ref.deliveries[i].SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, *ref.deliveries[i].ToDomain())
+		}
+	}
+
+	return deliveries, nil
+}
+
+// ClaimPending returns deliveries in the order they were enqueued.
+func (ref *webhookDeliveryRepository) ClaimPending(ctx context.Context, limit int, now, lockedUntil time.Time) ([]entity.WebhookDelivery, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	deliveries := make([]entity.WebhookDelivery, 0, limit)
+
+	for i, delivery := range ref.deliveries {
+		if len(deliveries) == limit {
+			break
+		}
+
+		if delivery.Status != entity.WebhookDeliveryStatusPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+
+		ref.deliveries[i].NextAttemptAt = lockedUntil
+		ref.deliveries[i].Attempts++
+		ref.deliveries[i].UpdatedAt = now
+
+		deliveries = append(deliveries, *ref.deliveries[i].ToDomain())
+	}
+
+	return deliveries, nil
+}
+
+func (ref *webhookDeliveryRepository) Update(ctx context.Context, id string, delivery entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for i, record := range ref.deliveries {
+		if record.ID != id {
+			continue
+		}
+
+		updated := model.WebhookDeliveryFromDomain(delivery)
+		updated.ID = record.ID
+		updated.CreatedAt = record.CreatedAt
+		updated.UpdatedAt = time.Now()
+
+		ref.deliveries[i] = updated
+
+		return updated.ToDomain(), nil
+	}
+
+	return nil, nil
+}
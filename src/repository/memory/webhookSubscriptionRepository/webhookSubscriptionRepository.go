@@ -0,0 +1,93 @@
+package webhookSubscriptionRepository
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/google/uuid"
+)
+
+type webhookSubscriptionRepository struct {
+	mutex         sync.Mutex
+	subscriptions []model.WebhookSubscription
+}
+
+func NewWebhookSubscriptionRepository() interfaces.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{
+		subscriptions: []model.WebhookSubscription{},
+	}
+}
+
+func (ref *webhookSubscriptionRepository) Create(ctx context.Context, subscription entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	record := model.WebhookSubscriptionFromDomain(subscription)
+	record.ID = uuid.NewString()
+	record.EventTypes = slices.Clone(record.EventTypes)
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	ref.subscriptions = append(ref.subscriptions, record)
+
+	return record.ToDomain(), nil
+}
+
+func (ref *webhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	for _, subscription := range ref.subscriptions {
+		if subscription.ID == id {
+			return subscription.ToDomain(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (ref *webhookSubscriptionRepository) Search(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	subscriptions := make([]entity.WebhookSubscription, 0, len(ref.subscriptions))
+
+	for _, subscription := range ref.subscriptions {
+		subscriptions = append(subscriptions, *subscription.ToDomain())
+	}
+
+	return subscriptions, nil
+}
+
+func (ref *webhookSubscriptionRepository) SearchByEventType(ctx context.Context, eventType string) ([]entity.WebhookSubscription, error) {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	subscriptions := make([]entity.WebhookSubscription, 0)
+
+	for _, subscription := range ref.subscriptions {
+		if slices.Contains(subscription.EventTypes, eventType) {
+			subscriptions = append(subscriptions, *subscription.ToDomain())
+		}
+	}
+
+	return subscriptions, nil
+}
+
+func (ref *webhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	ref.mutex.Lock()
+	defer ref.mutex.Unlock()
+
+	ref.subscriptions = slices.DeleteFunc(ref.subscriptions, func(subscription model.WebhookSubscription) bool {
+		return subscription.ID == id
+	})
+
+	return nil
+}
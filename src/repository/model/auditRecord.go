@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type AuditRecord struct {
+	ID         string        `json:"id,omitempty" bson:"_id,omitempty"`
+	EntityType string        `json:"entity_type" bson:"entity_type"`
+	EntityID   string        `json:"entity_id" bson:"entity_id"`
+	Action     string        `json:"action" bson:"action"`
+	ActorID    string        `json:"actor_id" bson:"actor_id"`
+	ActorRole  string        `json:"actor_role,omitempty" bson:"actor_role,omitempty"`
+	RequestID  string        `json:"request_id,omitempty" bson:"request_id,omitempty"`
+	Changes    []AuditChange `json:"changes" bson:"changes"`
+	OccurredAt time.Time     `json:"occurred_at" bson:"occurred_at"`
+}
+
+type AuditChange struct {
+	Field  string `json:"field" bson:"field"`
+	Before any    `json:"before" bson:"before"`
+	After  any    `json:"after" bson:"after"`
+}
+
+func AuditRecordFromDomain(record entity.AuditRecord) AuditRecord {
+	changes := make([]AuditChange, 0, len(record.Changes))
+
+	for _, change := range record.Changes {
+		changes = append(changes, AuditChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	return AuditRecord{
+		ID:         record.ID,
+		EntityType: record.EntityType,
+		EntityID:   record.EntityID,
+		Action:     record.Action,
+		ActorID:    record.ActorID,
+		ActorRole:  record.ActorRole,
+		RequestID:  record.RequestID,
+		Changes:    changes,
+		OccurredAt: record.OccurredAt,
+	}
+}
+
+func (ref AuditRecord) ToDomain() *entity.AuditRecord {
+	changes := make([]entity.AuditChange, 0, len(ref.Changes))
+
+	for _, change := range ref.Changes {
+		changes = append(changes, entity.AuditChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	return &entity.AuditRecord{
+		ID:         ref.ID,
+		EntityType: ref.EntityType,
+		EntityID:   ref.EntityID,
+		Action:     ref.Action,
+		ActorID:    ref.ActorID,
+		ActorRole:  ref.ActorRole,
+		RequestID:  ref.RequestID,
+		Changes:    changes,
+		OccurredAt: ref.OccurredAt,
+	}
+}
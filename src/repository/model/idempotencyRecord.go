@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type IdempotencyRecord struct {
+	Key         string    `json:"key" bson:"_id"`
+	Fingerprint string    `json:"fingerprint" bson:"fingerprint"`
+	Status      string    `json:"status" bson:"status"`
+	StatusCode  int       `json:"status_code" bson:"status_code"`
+	ContentType string    `json:"content_type" bson:"content_type"`
+	Body        string    `json:"body" bson:"body"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+func IdempotencyRecordFromDomain(record entity.IdempotencyRecord) IdempotencyRecord {
+	return IdempotencyRecord{
+		Key:         record.Key,
+		Fingerprint: record.Fingerprint,
+		Status:      record.Status,
+		StatusCode:  record.StatusCode,
+		ContentType: record.ContentType,
+		Body:        string(record.Body),
+		CreatedAt:   record.CreatedAt,
+		ExpiresAt:   record.ExpiresAt,
+	}
+}
+
+func (ref IdempotencyRecord) ToDomain() *entity.IdempotencyRecord {
+	return &entity.IdempotencyRecord{
+		Key:         ref.Key,
+		Fingerprint: ref.Fingerprint,
+		Status:      ref.Status,
+		StatusCode:  ref.StatusCode,
+		ContentType: ref.ContentType,
+		Body:        []byte(ref.Body),
+		CreatedAt:   ref.CreatedAt,
+		ExpiresAt:   ref.ExpiresAt,
+	}
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type ImportJob struct {
+	ID         string           `json:"id,omitempty" bson:"_id,omitempty"`
+	JobID      string           `json:"job_id,omitempty" bson:"job_id,omitempty"`
+	UserID     string           `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	DryRun     bool             `json:"dry_run" bson:"dry_run"`
+	Status     string           `json:"status,omitempty" bson:"status,omitempty"`
+	Total      int              `json:"total" bson:"total"`
+	Valid      int              `json:"valid" bson:"valid"`
+	Processed  int              `json:"processed" bson:"processed"`
+	Imported   int              `json:"imported" bson:"imported"`
+	Errors     []ImportRowError `json:"errors" bson:"errors"`
+	CreatedAt  time.Time        `json:"created_at" bson:"created_at,omitempty"`
+	UpdatedAt  time.Time        `json:"updated_at" bson:"updated_at,omitempty"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty" bson:"finished_at,omitempty"`
+}
+
+type ImportRowError struct {
+	Row   int    `json:"row" bson:"row"`
+	Error string `json:"error" bson:"error"`
+}
+
+func ImportJobFromDomain(job entity.ImportJob) ImportJob {
+	rowErrors := make([]ImportRowError, len(job.Errors))
+
+	for i, rowError := range job.Errors {
+		rowErrors[i] = ImportRowError{
+			Row:   rowError.Row,
+			Error: rowError.Error,
+		}
+	}
+
+	return ImportJob{
+		ID:         job.ID,
+		JobID:      job.JobID,
+		UserID:     job.UserID,
+		DryRun:     job.DryRun,
+		Status:     job.Status,
+		Total:      job.Total,
+		Valid:      job.Valid,
+		Processed:  job.Processed,
+		Imported:   job.Imported,
+		Errors:     rowErrors,
+		FinishedAt: job.FinishedAt,
+	}
+}
+
+func (ref ImportJob) ToDomain() *entity.ImportJob {
+	rowErrors := make([]entity.ImportRowError, len(ref.Errors))
+
+	for i, rowError := range ref.Errors {
+		rowErrors[i] = entity.ImportRowError{
+			Row:   rowError.Row,
+			Error: rowError.Error,
+		}
+	}
+
+	return &entity.ImportJob{
+		ID:         ref.ID,
+		JobID:      ref.JobID,
+		UserID:     ref.UserID,
+		DryRun:     ref.DryRun,
+		Status:     ref.Status,
+		Total:      ref.Total,
+		Valid:      ref.Valid,
+		Processed:  ref.Processed,
+		Imported:   ref.Imported,
+		Errors:     rowErrors,
+		CreatedAt:  ref.CreatedAt,
+		UpdatedAt:  ref.UpdatedAt,
+		FinishedAt: ref.FinishedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type Job struct {
+	ID          string     `json:"id,omitempty" bson:"_id,omitempty"`
+	Type        string     `json:"type,omitempty" bson:"type,omitempty"`
+	UserID      string     `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	Payload     string     `json:"payload,omitempty" bson:"payload,omitempty"`
+	Status      string     `json:"status,omitempty" bson:"status,omitempty"`
+	Attempts    int        `json:"attempts" bson:"attempts"`
+	MaxAttempts int        `json:"max_attempts" bson:"max_attempts"`
+	LastError   string     `json:"last_error" bson:"last_error"`
+	RunAt       time.Time  `json:"run_at" bson:"run_at"`
+	LockedUntil *time.Time `json:"locked_until" bson:"locked_until"`
+	StartedAt   *time.Time `json:"started_at" bson:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at" bson:"finished_at"`
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at" bson:"updated_at,omitempty"`
+}
+
+func JobFromDomain(job entity.Job) Job {
+	return Job{
+		ID:          job.ID,
+		Type:        job.Type,
+		UserID:      job.UserID,
+		Payload:     string(job.Payload),
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LastError:   job.LastError,
+		RunAt:       job.RunAt,
+		LockedUntil: job.LockedUntil,
+		StartedAt:   job.StartedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+}
+
+func (ref Job) ToDomain() *entity.Job {
+	return &entity.Job{
+		ID:          ref.ID,
+		Type:        ref.Type,
+		UserID:      ref.UserID,
+		Payload:     []byte(ref.Payload),
+		Status:      ref.Status,
+		Attempts:    ref.Attempts,
+		MaxAttempts: ref.MaxAttempts,
+		LastError:   ref.LastError,
+		RunAt:       ref.RunAt,
+		LockedUntil: ref.LockedUntil,
+		StartedAt:   ref.StartedAt,
+		FinishedAt:  ref.FinishedAt,
+		CreatedAt:   ref.CreatedAt,
+		UpdatedAt:   ref.UpdatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type OutboxMessage struct {
+	ID          string     `json:"id,omitempty" bson:"_id,omitempty"`
+	Type        string     `json:"type" bson:"type"`
+	EntityID    string     `json:"entity_id" bson:"entity_id"`
+	Payload     string     `json:"payload" bson:"payload"`
+	RequestID   string     `json:"request_id,omitempty" bson:"request_id,omitempty"`
+	OccurredAt  time.Time  `json:"occurred_at" bson:"occurred_at"`
+	Status      string     `json:"status" bson:"status"`
+	Attempts    int        `json:"attempts" bson:"attempts"`
+	LastError   string     `json:"last_error" bson:"last_error"`
+	AvailableAt time.Time  `json:"available_at" bson:"available_at"`
+	PublishedAt *time.Time `json:"published_at" bson:"published_at"`
+}
+
+func OutboxMessageFromDomain(event entity.Event) OutboxMessage {
+	return OutboxMessage{
+		ID:          event.ID,
+		Type:        event.Type,
+		EntityID:    event.EntityID,
+		Payload:     string(event.Payload),
+		RequestID:   event.RequestID,
+		OccurredAt:  event.OccurredAt,
+		Status:      entity.OutboxStatusPending,
+		AvailableAt: event.OccurredAt,
+	}
+}
+
+func (ref OutboxMessage) ToDomain() *entity.OutboxMessage {
+	return &entity.OutboxMessage{
+		Event: entity.Event{
+			ID:         ref.ID,
+			Type:       ref.Type,
+			EntityID:   ref.EntityID,
+			Payload:    []byte(ref.Payload),
+			RequestID:  ref.RequestID,
+			OccurredAt: ref.OccurredAt,
+		},
+		Status:      ref.Status,
+		Attempts:    ref.Attempts,
+		LastError:   ref.LastError,
+		AvailableAt: ref.AvailableAt,
+		PublishedAt: ref.PublishedAt,
+	}
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type Payment struct {
+	ID           string    `json:"id,omitempty" bson:"_id,omitempty"`
+	VehicleID    string    `json:"vehicle_id,omitempty" bson:"vehicle_id,omitempty"`
+	UserID       string    `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	Price        float64   `json:"price,omitempty" bson:"price,omitempty"`
+	TradeIn      *TradeIn  `json:"trade_in,omitempty" bson:"trade_in,omitempty"`
+	Amount       float64   `json:"amount,omitempty" bson:"amount,omitempty"`
+	Status       string    `json:"status,omitempty" bson:"status,omitempty"`
+	IntentID     string    `json:"intent_id,omitempty" bson:"intent_id,omitempty"`
+	ClientSecret string    `json:"client_secret,omitempty" bson:"client_secret,omitempty"`
+	SaleID       string    `json:"sale_id,omitempty" bson:"sale_id,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at" bson:"expires_at,omitempty"`
+	CreatedAt    time.Time `json:"created_at" bson:"created_at,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at" bson:"updated_at,omitempty"`
+}
+
+type TradeIn struct {
+	Brand     string  `json:"brand" bson:"brand"`
+	Model     string  `json:"model" bson:"model"`
+	Year      int     `json:"year" bson:"year"`
+	Color     string  `json:"color" bson:"color"`
+	Valuation float64 `json:"valuation" bson:"valuation"`
+}
+
+func PaymentFromDomain(payment entity.Payment) Payment {
+	record := Payment{
+		ID:           payment.ID,
+		VehicleID:    payment.VehicleID,
+		UserID:       payment.UserID,
+		Price:        payment.Price,
+		Amount:       payment.Amount,
+		Status:       payment.Status,
+		IntentID:     payment.IntentID,
+		ClientSecret: payment.ClientSecret,
+		SaleID:       payment.SaleID,
+		ExpiresAt:    payment.ExpiresAt,
+	}
+
+	if payment.TradeIn != nil {
+		record.TradeIn = &TradeIn{
+			Brand:     payment.TradeIn.Brand,
+			Model:     payment.TradeIn.Model,
+			Year:      payment.TradeIn.Year,
+			Color:     payment.TradeIn.Color,
+			Valuation: payment.TradeIn.Valuation,
+		}
+	}
+
+	return record
+}
+
+func (ref Payment) ToDomain() *entity.Payment {
+	payment := &entity.Payment{
+		ID:           ref.ID,
+		VehicleID:    ref.VehicleID,
+		UserID:       ref.UserID,
+		Price:        ref.Price,
+		Amount:       ref.Amount,
+		Status:       ref.Status,
+		IntentID:     ref.IntentID,
+		ClientSecret: ref.ClientSecret,
+		SaleID:       ref.SaleID,
+		ExpiresAt:    ref.ExpiresAt,
+		CreatedAt:    ref.CreatedAt,
+		UpdatedAt:    ref.UpdatedAt,
+	}
+
+	if ref.TradeIn != nil {
+		payment.TradeIn = &entity.TradeIn{
+			Brand:     ref.TradeIn.Brand,
+			Model:     ref.TradeIn.Model,
+			Year:      ref.TradeIn.Year,
+			Color:     ref.TradeIn.Color,
+			Valuation: ref.TradeIn.Valuation,
+		}
+	}
+
+	return payment
+}
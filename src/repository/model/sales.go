@@ -7,28 +7,55 @@ import (
 )
 
 type Sale struct {
-	ID        string    `json:"id,omitempty" bson:"_id,omitempty"`
-	VehicleID string    `json:"vehicle_id" bson:"vehicle_id"`
-	UserID    string    `json:"user_id" bson:"user_id"`
-	Price     float64   `json:"price" bson:"price"`
-	SoldAt    time.Time `json:"sold_at" bson:"sold_at"`
+	ID               string    `json:"id,omitempty" bson:"_id,omitempty"`
+	VehicleID        string    `json:"vehicle_id" bson:"vehicle_id"`
+	UserID           string    `json:"user_id" bson:"user_id"`
+	Price            float64   `json:"price" bson:"price"`
+	TradeInVehicleID string    `json:"trade_in_vehicle_id,omitempty" bson:"trade_in_vehicle_id,omitempty"`
+	TradeInCredit    float64   `json:"trade_in_credit" bson:"trade_in_credit"`
+	NetPrice         float64   `json:"net_price" bson:"net_price"`
+	SoldAt           time.Time `json:"sold_at" bson:"sold_at"`
 }
 
 func SaleFromDomain(sale entity.Sale) Sale {
 	return Sale{
-		VehicleID: sale.VehicleID,
-		UserID:    sale.UserID,
-		Price:     sale.Price,
-		SoldAt:    sale.SoldAt,
+		VehicleID:        sale.VehicleID,
+		UserID:           sale.UserID,
+		Price:            sale.Price,
+		TradeInVehicleID: sale.TradeInVehicleID,
+		TradeInCredit:    sale.TradeInCredit,
+		NetPrice:         sale.NetPrice,
+		SoldAt:           sale.SoldAt,
 	}
 }
 
 func (ref *Sale) ToDomain() *entity.Sale {
 	return &entity.Sale{
-		ID:        ref.ID,
-		VehicleID: ref.VehicleID,
-		UserID:    ref.UserID,
-		Price:     ref.Price,
-		SoldAt:    ref.SoldAt,
+		ID:               ref.ID,
+		VehicleID:        ref.VehicleID,
+		UserID:           ref.UserID,
+		Price:            ref.Price,
+		TradeInVehicleID: ref.TradeInVehicleID,
+		TradeInCredit:    ref.TradeInCredit,
+		NetPrice:         ref.NetPrice,
+		SoldAt:           ref.SoldAt,
+	}
+}
+
+type SalesReportGroup struct {
+	Key                 string  `bson:"_id"`
+	Count               int     `bson:"count"`
+	Revenue             float64 `bson:"revenue"`
+	AverageTicket       float64 `bson:"average_ticket"`
+	AverageDaysOnMarket float64 `bson:"average_days_on_market"`
+}
+
+func (ref SalesReportGroup) ToDomain() entity.SalesReportGroup {
+	return entity.SalesReportGroup{
+		Key:                 ref.Key,
+		Count:               ref.Count,
+		Revenue:             ref.Revenue,
+		AverageTicket:       ref.AverageTicket,
+		AverageDaysOnMarket: ref.AverageDaysOnMarket,
 	}
 }
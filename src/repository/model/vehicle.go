@@ -13,25 +13,39 @@ type Vehicle struct {
 	Year      int        `json:"year,omitempty" bson:"year,omitempty"`
 	Color     string     `json:"color,omitempty" bson:"color,omitempty"`
 	Price     float64    `json:"price,omitempty" bson:"price,omitempty"`
+	Status    string     `json:"status,omitempty" bson:"status,omitempty"`
+	SellerID  string     `json:"seller_id,omitempty" bson:"seller_id,omitempty"`
 	UserID    string     `json:"user_id,omitempty" bson:"user_id,omitempty"`
 	SoldAt    *time.Time `json:"sold_at,omitempty" bson:"sold_at,omitempty"`
+	Version   int64      `json:"version,omitempty" bson:"version,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	DeletedBy string     `json:"deleted_by,omitempty" bson:"deleted_by,omitempty"`
 	CreatedAt time.Time  `json:"created_at" bson:"created_at,omitempty"`
 	UpdatedAt time.Time  `json:"updated_at" bson:"updated_at,omitempty"`
 }
 
 func VehicleFromDomain(vehicle entity.Vehicle) Vehicle {
 	return Vehicle{
-		ID:     vehicle.ID,
-		Brand:  vehicle.Brand,
-		Model:  vehicle.Model,
-		Year:   vehicle.Year,
-		Color:  vehicle.Color,
-		Price:  vehicle.Price,
-		SoldAt: vehicle.SoldAt,
+		ID:        vehicle.ID,
+		Brand:     vehicle.Brand,
+		Model:     vehicle.Model,
+		Year:      vehicle.Year,
+		Color:     vehicle.Color,
+		Price:     vehicle.Price,
+		Status:    vehicle.Status,
+		SellerID:  vehicle.SellerID,
+		SoldAt:    vehicle.SoldAt,
+		Version:   vehicle.Version,
+		DeletedAt: vehicle.DeletedAt,
+		DeletedBy: vehicle.DeletedBy,
 	}
 }
 
 func (ref Vehicle) ToDomain() *entity.Vehicle {
+	// Vehicles created before versioning have no version and count as the
+	// first one.
+	version := max(ref.Version, 1)
+
 	return &entity.Vehicle{
 		ID:        ref.ID,
 		Brand:     ref.Brand,
@@ -39,7 +53,12 @@ func (ref Vehicle) ToDomain() *entity.Vehicle {
 		Year:      ref.Year,
 		Color:     ref.Color,
 		Price:     ref.Price,
+		Status:    ref.Status,
+		SellerID:  ref.SellerID,
 		SoldAt:    ref.SoldAt,
+		Version:   version,
+		DeletedAt: ref.DeletedAt,
+		DeletedBy: ref.DeletedBy,
 		CreatedAt: ref.CreatedAt,
 		UpdatedAt: ref.UpdatedAt,
 	}
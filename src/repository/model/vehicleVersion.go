@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type VehicleVersion struct {
+	ID         string    `json:"id,omitempty" bson:"_id,omitempty"`
+	VehicleID  string    `json:"vehicle_id" bson:"vehicle_id"`
+	Version    int64     `json:"version" bson:"version"`
+	Vehicle    Vehicle   `json:"vehicle" bson:"vehicle"`
+	RecordedAt time.Time `json:"recorded_at" bson:"recorded_at"`
+}
+
+func VehicleVersionFromDomain(version entity.VehicleVersion) VehicleVersion {
+	vehicle := VehicleFromDomain(version.Vehicle)
+	vehicle.CreatedAt = version.Vehicle.CreatedAt
+	vehicle.UpdatedAt = version.Vehicle.UpdatedAt
+
+	return VehicleVersion{
+		VehicleID:  version.Vehicle.ID,
+		Version:    version.Vehicle.Version,
+		Vehicle:    vehicle,
+		RecordedAt: version.RecordedAt,
+	}
+}
+
+func (ref VehicleVersion) ToDomain() *entity.VehicleVersion {
+	return &entity.VehicleVersion{
+		Vehicle:    *ref.Vehicle.ToDomain(),
+		RecordedAt: ref.RecordedAt,
+	}
+}
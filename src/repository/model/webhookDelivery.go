@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type WebhookDelivery struct {
+	ID             string     `json:"id,omitempty" bson:"_id,omitempty"`
+	SubscriptionID string     `json:"subscription_id" bson:"subscription_id"`
+	EventID        string     `json:"event_id" bson:"event_id"`
+	EventType      string     `json:"event_type" bson:"event_type"`
+	Payload        string     `json:"payload" bson:"payload"`
+	Status         string     `json:"status" bson:"status"`
+	Attempts       int        `json:"attempts" bson:"attempts"`
+	LastStatusCode int        `json:"last_status_code" bson:"last_status_code"`
+	LastError      string     `json:"last_error" bson:"last_error"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" bson:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at" bson:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" bson:"created_at,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at" bson:"updated_at,omitempty"`
+}
+
+func WebhookDeliveryFromDomain(delivery entity.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        string(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func (ref WebhookDelivery) ToDomain() *entity.WebhookDelivery {
+	return &entity.WebhookDelivery{
+		ID:             ref.ID,
+		SubscriptionID: ref.SubscriptionID,
+		EventID:        ref.EventID,
+		EventType:      ref.EventType,
+		Payload:        []byte(ref.Payload),
+		Status:         ref.Status,
+		Attempts:       ref.Attempts,
+		LastStatusCode: ref.LastStatusCode,
+		LastError:      ref.LastError,
+		NextAttemptAt:  ref.NextAttemptAt,
+		DeliveredAt:    ref.DeliveredAt,
+		CreatedAt:      ref.CreatedAt,
+		UpdatedAt:      ref.UpdatedAt,
+	}
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type WebhookSubscription struct {
+	ID         string    `json:"id,omitempty" bson:"_id,omitempty"`
+	URL        string    `json:"url" bson:"url"`
+	EventTypes []string  `json:"event_types" bson:"event_types"`
+	Secret     string    `json:"secret" bson:"secret"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at,omitempty"`
+}
+
+func WebhookSubscriptionFromDomain(subscription entity.WebhookSubscription) WebhookSubscription {
+	return WebhookSubscription{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Secret:     subscription.Secret,
+	}
+}
+
+func (ref WebhookSubscription) ToDomain() *entity.WebhookSubscription {
+	return &entity.WebhookSubscription{
+		ID:         ref.ID,
+		URL:        ref.URL,
+		EventTypes: ref.EventTypes,
+		Secret:     ref.Secret,
+		CreatedAt:  ref.CreatedAt,
+		UpdatedAt:  ref.UpdatedAt,
+	}
+}
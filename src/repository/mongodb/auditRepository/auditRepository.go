@@ -0,0 +1,55 @@
+package auditRepository
+
+import (
+	"context"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// auditRepository only ever inserts: records are never updated or deleted.
+type auditRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAuditRepository(collection *mongo.Collection) interfaces.AuditRepository {
+	return &auditRepository{
+		collection: collection,
+	}
+}
+
+func (ref *auditRepository) Append(ctx context.Context, record entity.AuditRecord) error {
+	document := model.AuditRecordFromDomain(record)
+	document.ID = ""
+
+	_, err := ref.collection.InsertOne(ctx, document)
+	return err
+}
+
+func (ref *auditRepository) Search(ctx context.Context, entityID string) ([]entity.AuditRecord, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := ref.collection.Find(ctx, bson.M{"entity_id": entityID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	records := make([]entity.AuditRecord, 0)
+
+	for cursor.Next(ctx) {
+		var document model.AuditRecord
+		if err = cursor.Decode(&document); err != nil {
+			return nil, err
+		}
+
+		records = append(records, *document.ToDomain())
+	}
+
+	return records, cursor.Err()
+}
@@ -0,0 +1,92 @@
+package commandMonitor
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/caiiomp/vehicle-resale-api/src/tracing"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type commandMonitor struct {
+	spans sync.Map
+}
+
+// NewCommandMonitor traces every command sent to MongoDB as an OpenTelemetry
+// client span, the way otelmongo does. Commands outside of a trace, such as
+// the readiness probe, are not traced. Command documents are not recorded,
+// since they carry customer data.
+func NewCommandMonitor() *event.CommandMonitor {
+	monitor := &commandMonitor{}
+
+	return &event.CommandMonitor{
+		Started:   monitor.started,
+		Succeeded: monitor.succeeded,
+		Failed:    monitor.failed,
+	}
+}
+
+func (ref *commandMonitor) started(ctx context.Context, evt *event.CommandStartedEvent) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+
+	attributes := []attribute.KeyValue{
+		attribute.String("db.system", "mongodb"),
+		attribute.String("db.name", evt.DatabaseName),
+		attribute.String("db.operation", evt.CommandName),
+	}
+
+	if collection := collectionName(evt.Command, evt.CommandName); collection != "" {
+		attributes = append(attributes, attribute.String("db.mongodb.collection", collection))
+	}
+
+	_, span := tracing.Start(ctx, "mongodb."+evt.CommandName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+
+	ref.spans.Store(key(evt.ConnectionID, evt.RequestID), span)
+}
+
+func (ref *commandMonitor) succeeded(ctx context.Context, evt *event.CommandSucceededEvent) {
+	ref.finish(evt.CommandFinishedEvent, nil)
+}
+
+func (ref *commandMonitor) failed(ctx context.Context, evt *event.CommandFailedEvent) {
+	ref.finish(evt.CommandFinishedEvent, errors.New(evt.Failure))
+}
+
+func (ref *commandMonitor) finish(evt event.CommandFinishedEvent, err error) {
+	value, ok := ref.spans.LoadAndDelete(key(evt.ConnectionID, evt.RequestID))
+	if !ok {
+		return
+	}
+
+	value.(tracing.Span).End(err)
+}
+
+func key(connectionID string, requestID int64) string {
+	return connectionID + "/" + strconv.FormatInt(requestID, 10)
+}
+
+// collectionName returns the collection a command targets, which is the value
+// of the command's own field for find, insert, update, aggregate and so on.
+func collectionName(command bson.Raw, commandName string) string {
+	value, err := command.LookupErr(commandName)
+	if err != nil {
+		return ""
+	}
+
+	collection, ok := value.StringValueOK()
+	if !ok {
+		return ""
+	}
+
+	return collection
+}
@@ -0,0 +1,69 @@
+package commandMonitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCommandMonitor(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+
+	provider := tracing.NewProvider(exporter, "vehicle-resale-api", 1)
+	tracing.SetProvider(provider)
+	defer tracing.SetProvider(nil)
+
+	monitor := NewCommandMonitor()
+
+	command, err := bson.Marshal(bson.D{{Key: "find", Value: "vehicles"}, {Key: "filter", Value: bson.D{}}})
+	require.NoError(t, err)
+
+	ctx, parent := tracing.Start(context.TODO(), "vehicleRepository.Search")
+
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command:      command,
+		DatabaseName: "vehicles-db",
+		CommandName:  "find",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+	})
+	monitor.Failed(ctx, &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", RequestID: 1, ConnectionID: "localhost:27017[-1]"},
+		Failure:              "connection reset",
+	})
+
+	// Commands outside of a trace are not recorded.
+	monitor.Started(context.TODO(), &event.CommandStartedEvent{CommandName: "ping", RequestID: 2, ConnectionID: "localhost:27017[-1]"})
+	monitor.Succeeded(context.TODO(), &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "ping", RequestID: 2, ConnectionID: "localhost:27017[-1]"},
+	})
+
+	parent.End(nil)
+
+	require.NoError(t, provider.ForceFlush(context.TODO()))
+
+	spans := exporter.GetSpans()
+
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "mongodb.find", spans[0].Name)
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "connection reset", spans[0].Status.Description)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("db.system", "mongodb"),
+		attribute.String("db.name", "vehicles-db"),
+		attribute.String("db.operation", "find"),
+		attribute.String("db.mongodb.collection", "vehicles"),
+	}, spans[0].Attributes)
+}
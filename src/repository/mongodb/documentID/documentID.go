@@ -0,0 +1,17 @@
+// Package documentID converts the ids given to the MongoDB repositories into
+// the ObjectIDs of their documents.
+package documentID
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// FromHex returns the ObjectID of id. A malformed id is converted to the nil
+// ObjectID, which no document is given, so that it matches nothing and is
+// reported as not found like an unknown id instead of failing the query.
+func FromHex(id string) primitive.ObjectID {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID
+	}
+
+	return objectID
+}
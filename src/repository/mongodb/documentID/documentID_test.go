@@ -0,0 +1,24 @@
+package documentID
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFromHex(t *testing.T) {
+	t.Run("should convert well formed id", func(t *testing.T) {
+		expected := primitive.NewObjectID()
+
+		actual := FromHex(expected.Hex())
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should convert malformed id to nil object id", func(t *testing.T) {
+		actual := FromHex("not-an-id")
+
+		assert.Equal(t, primitive.NilObjectID, actual)
+	})
+}
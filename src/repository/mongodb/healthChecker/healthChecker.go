@@ -0,0 +1,27 @@
+package healthChecker
+
+import (
+	"context"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type healthChecker struct {
+	client *mongo.Client
+}
+
+func NewHealthChecker(client *mongo.Client) interfaces.HealthChecker {
+	return &healthChecker{
+		client: client,
+	}
+}
+
+func (ref *healthChecker) Name() string {
+	return "mongodb"
+}
+
+func (ref *healthChecker) Check(ctx context.Context) error {
+	return ref.client.Ping(ctx, readpref.Primary())
+}
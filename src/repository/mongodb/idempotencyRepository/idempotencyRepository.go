@@ -0,0 +1,100 @@
+package idempotencyRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type idempotencyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewIdempotencyRepository(collection *mongo.Collection) interfaces.IdempotencyRepository {
+	return &idempotencyRepository{
+		collection: collection,
+	}
+}
+
+// Reserve upserts on the key only when the stored record has expired. When an
+// unexpired record exists the filter does not match it, the upsert collides
+// with its _id and that record is returned instead.
+func (ref *idempotencyRepository) Reserve(ctx context.Context, record entity.IdempotencyRecord, now time.Time) (*entity.IdempotencyRecord, error) {
+	filter := bson.M{
+		"_id":        record.Key,
+		"expires_at": bson.M{"$lte": now},
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"fingerprint":  record.Fingerprint,
+			"status":       record.Status,
+			"status_code":  record.StatusCode,
+			"content_type": record.ContentType,
+			"body":         string(record.Body),
+			"created_at":   record.CreatedAt,
+			"expires_at":   record.ExpiresAt,
+		},
+	}
+
+	_, err := ref.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err == nil {
+		return nil, nil
+	}
+
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, err
+	}
+
+	result := ref.collection.FindOne(ctx, bson.M{"_id": record.Key})
+	if err = result.Err(); err != nil {
+		return nil, err
+	}
+
+	var existing model.IdempotencyRecord
+	if err = result.Decode(&existing); err != nil {
+		return nil, err
+	}
+
+	return existing.ToDomain(), nil
+}
+
+func (ref *idempotencyRepository) Complete(ctx context.Context, record entity.IdempotencyRecord) error {
+	filter := bson.M{
+		"_id":         record.Key,
+		"fingerprint": record.Fingerprint,
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":       record.Status,
+			"status_code":  record.StatusCode,
+			"content_type": record.ContentType,
+			"body":         string(record.Body),
+			"expires_at":   record.ExpiresAt,
+		},
+	}
+
+	_, err := ref.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (ref *idempotencyRepository) Delete(ctx context.Context, key string) error {
+	_, err := ref.collection.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
+
+func (ref *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := ref.collection.DeleteMany(ctx, bson.M{"expires_at": bson.M{"$lte": now}})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.DeletedCount), nil
+}
@@ -0,0 +1,83 @@
+package importJobRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/documentID"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type importJobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewImportJobRepository(collection *mongo.Collection) interfaces.ImportJobRepository {
+	return &importJobRepository{
+		collection: collection,
+	}
+}
+
+func (ref *importJobRepository) Create(ctx context.Context, job entity.ImportJob) (*entity.ImportJob, error) {
+	record := model.ImportJobFromDomain(job)
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	created, err := ref.collection.InsertOne(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	id := created.InsertedID.(primitive.ObjectID)
+
+	return ref.findOne(ctx, bson.M{"_id": id})
+}
+
+func (ref *importJobRepository) GetByID(ctx context.Context, id string) (*entity.ImportJob, error) {
+	objectID := documentID.FromHex(id)
+
+	return ref.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (ref *importJobRepository) Update(ctx context.Context, id string, job entity.ImportJob) (*entity.ImportJob, error) {
+	record := model.ImportJobFromDomain(job)
+	record.ID = ""
+	record.UpdatedAt = time.Now()
+
+	objectID := documentID.FromHex(id)
+
+	update := bson.M{
+		"$set": record,
+	}
+
+	_, err := ref.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return ref.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (ref *importJobRepository) findOne(ctx context.Context, filter bson.M) (*entity.ImportJob, error) {
+	result := ref.collection.FindOne(ctx, filter)
+	if err := result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record model.ImportJob
+	if err := result.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
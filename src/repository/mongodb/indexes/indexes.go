@@ -0,0 +1,71 @@
+// Package indexes keeps the MongoDB indexes the repositories query by. They
+// are created at startup; creating an index that already exists with the same
+// keys and options does nothing, so every instance may do it.
+package indexes
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var indexes = map[string][]mongo.IndexModel{
+	// Idempotency keys are removed by the server once expires_at passes, in
+	// case the purge worker falls behind; reads still check expires_at, since
+	// the server only removes them about once a minute.
+	"idempotency_keys": {
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+		},
+	},
+	// jobRepository.ClaimNext looks for pending jobs due by run_at, oldest
+	// first, and for running jobs whose lock expired.
+	"jobs": {
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "run_at", Value: 1}},
+			Options: options.Index().SetName("status_run_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "locked_until", Value: 1}},
+			Options: options.Index().SetName("status_locked_until"),
+		},
+	},
+	// outboxRepository.ClaimPending takes pending messages oldest first,
+	// skipping those not available yet. Equality, sort and range keys come
+	// in that order, so the sort does not happen in memory.
+	"outbox": {
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "occurred_at", Value: 1},
+				{Key: "_id", Value: 1},
+				{Key: "available_at", Value: 1},
+			},
+			Options: options.Index().SetName("status_occurred_at_available_at"),
+		},
+	},
+	// webhookDeliveryRepository.Enqueue upserts on the subscription and
+	// event; the unique index keeps concurrent upserts from inserting the
+	// same delivery twice.
+	"webhook_deliveries": {
+		{
+			Keys:    bson.D{{Key: "subscription_id", Value: 1}, {Key: "event_id", Value: 1}},
+			Options: options.Index().SetName("subscription_id_event_id").SetUnique(true),
+		},
+	},
+}
+
+// Create creates the indexes of every collection in database.
+func Create(ctx context.Context, database *mongo.Database) error {
+	for collection, models := range indexes {
+		if _, err := database.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("could not create indexes of %s: %w", collection, err)
+		}
+	}
+
+	return nil
+}
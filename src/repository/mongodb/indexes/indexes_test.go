@@ -0,0 +1,39 @@
+//go:build integration
+
+package indexes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	database := testDatabase.New(t)
+
+	// Creating them again, as every instance does at startup, must not fail.
+	require.NoError(t, Create(ctx, database))
+	require.NoError(t, Create(ctx, database))
+
+	for collection, models := range indexes {
+		cursor, err := database.Collection(collection).Indexes().List(ctx)
+		require.NoError(t, err)
+
+		var created []bson.M
+		require.NoError(t, cursor.All(ctx, &created))
+
+		names := make([]string, len(created))
+		for i, index := range created {
+			names[i], _ = index["name"].(string)
+		}
+
+		for _, model := range models {
+			assert.Contains(t, names, *model.Options.Name, collection)
+		}
+	}
+}
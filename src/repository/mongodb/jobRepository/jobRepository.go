@@ -0,0 +1,182 @@
+package jobRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/documentID"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type jobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobRepository(collection *mongo.Collection) interfaces.JobRepository {
+	return &jobRepository{
+		collection: collection,
+	}
+}
+
+func (ref *jobRepository) Create(ctx context.Context, job entity.Job) (*entity.Job, error) {
+	record := model.JobFromDomain(job)
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	created, err := ref.collection.InsertOne(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	id := created.InsertedID.(primitive.ObjectID)
+
+	return ref.findOne(ctx, bson.M{"_id": id})
+}
+
+func (ref *jobRepository) GetByID(ctx context.Context, id string) (*entity.Job, error) {
+	objectID := documentID.FromHex(id)
+
+	return ref.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (ref *jobRepository) Update(ctx context.Context, id string, job entity.Job) (*entity.Job, error) {
+	record := model.JobFromDomain(job)
+	record.ID = ""
+	record.UpdatedAt = time.Now()
+
+	objectID := documentID.FromHex(id)
+
+	update := bson.M{
+		"$set": record,
+	}
+
+	_, err := ref.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return ref.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (ref *jobRepository) Cancel(ctx context.Context, id string, finishedAt time.Time) (*entity.Job, error) {
+	objectID := documentID.FromHex(id)
+
+	filter := bson.M{
+		"_id":    objectID,
+		"status": bson.M{"$in": bson.A{entity.JobStatusPending, entity.JobStatusRunning}},
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":       entity.JobStatusCanceled,
+			"locked_until": nil,
+			"finished_at":  finishedAt,
+			"updated_at":   finishedAt,
+		},
+	}
+
+	findOptions := options.FindOneAndUpdate().
+		SetReturnDocument(options.After)
+
+	result := ref.collection.FindOneAndUpdate(ctx, filter, update, findOptions)
+	if err := result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record model.Job
+	if err := result.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (ref *jobRepository) ClaimNext(ctx context.Context, types []string, now, lockedUntil time.Time) (*entity.Job, error) {
+	filter := bson.M{
+		"type": bson.M{"$in": types},
+		"$or": bson.A{
+			bson.M{"status": entity.JobStatusPending, "run_at": bson.M{"$lte": now}},
+			bson.M{"status": entity.JobStatusRunning, "locked_until": bson.M{"$lt": now}},
+		},
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":       entity.JobStatusRunning,
+			"locked_until": lockedUntil,
+			"started_at":   now,
+			"updated_at":   now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+
+	findOptions := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	result := ref.collection.FindOneAndUpdate(ctx, filter, update, findOptions)
+	if err := result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record model.Job
+	if err := result.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (ref *jobRepository) Heartbeat(ctx context.Context, id string, lockedUntil time.Time) (*entity.Job, error) {
+	objectID := documentID.FromHex(id)
+
+	filter := bson.M{
+		"_id":    objectID,
+		"status": entity.JobStatusRunning,
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"locked_until": lockedUntil,
+			"updated_at":   time.Now(),
+		},
+	}
+
+	_, err := ref.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return ref.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (ref *jobRepository) findOne(ctx context.Context, filter bson.M) (*entity.Job, error) {
+	result := ref.collection.FindOne(ctx, filter)
+	if err := result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record model.Job
+	if err := result.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
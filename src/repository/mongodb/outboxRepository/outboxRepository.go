@@ -0,0 +1,100 @@
+package outboxRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/documentID"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type outboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(collection *mongo.Collection) interfaces.OutboxRepository {
+	return &outboxRepository{
+		collection: collection,
+	}
+}
+
+func (ref *outboxRepository) Append(ctx context.Context, event entity.Event) error {
+	record := model.OutboxMessageFromDomain(event)
+	record.ID = ""
+
+	_, err := ref.collection.InsertOne(ctx, record)
+	return err
+}
+
+// ClaimPending claims one message at a time, so that concurrent relays never
+// claim the same message.
+func (ref *outboxRepository) ClaimPending(ctx context.Context, limit int, now, lockedUntil time.Time) ([]entity.OutboxMessage, error) {
+	filter := bson.M{
+		"status":       entity.OutboxStatusPending,
+		"available_at": bson.M{"$lte": now},
+	}
+
+	update := bson.M{
+		"$set": bson.M{"available_at": lockedUntil},
+		"$inc": bson.M{"attempts": 1},
+	}
+
+	findOptions := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	messages := make([]entity.OutboxMessage, 0, limit)
+
+	for len(messages) < limit {
+		result := ref.collection.FindOneAndUpdate(ctx, filter, update, findOptions)
+		if err := result.Err(); err != nil {
+			if err == mongo.ErrNoDocuments {
+				break
+			}
+			return nil, err
+		}
+
+		var record model.OutboxMessage
+		if err := result.Decode(&record); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, *record.ToDomain())
+	}
+
+	return messages, nil
+}
+
+func (ref *outboxRepository) MarkPublished(ctx context.Context, id string, publishedAt time.Time) error {
+	objectID := documentID.FromHex(id)
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":       entity.OutboxStatusPublished,
+			"published_at": publishedAt,
+			"last_error":   "",
+		},
+	}
+
+	_, err := ref.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (ref *outboxRepository) MarkFailed(ctx context.Context, id, lastError string, availableAt time.Time) error {
+	objectID := documentID.FromHex(id)
+
+	update := bson.M{
+		"$set": bson.M{
+			"last_error":   lastError,
+			"available_at": availableAt,
+		},
+	}
+
+	_, err := ref.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
@@ -0,0 +1,131 @@
+package paymentRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/documentID"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type paymentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPaymentRepository(collection *mongo.Collection) interfaces.PaymentRepository {
+	return &paymentRepository{
+		collection: collection,
+	}
+}
+
+func (ref *paymentRepository) Create(ctx context.Context, payment entity.Payment) (*entity.Payment, error) {
+	record := model.PaymentFromDomain(payment)
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	created, err := ref.collection.InsertOne(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	id := created.InsertedID.(primitive.ObjectID)
+
+	return ref.findOne(ctx, bson.M{"_id": id})
+}
+
+func (ref *paymentRepository) GetByID(ctx context.Context, id string) (*entity.Payment, error) {
+	objectID := documentID.FromHex(id)
+
+	return ref.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (ref *paymentRepository) GetByIntentID(ctx context.Context, intentID string) (*entity.Payment, error) {
+	return ref.findOne(ctx, bson.M{"intent_id": intentID})
+}
+
+func (ref *paymentRepository) SearchExpired(ctx context.Context, before time.Time) ([]entity.Payment, error) {
+	filter := bson.M{
+		"status":     entity.PaymentStatusPending,
+		"expires_at": bson.M{"$lt": before},
+	}
+
+	cursor, err := ref.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	payments := make([]entity.Payment, 0)
+
+	for cursor.Next(ctx) {
+		var record model.Payment
+		if err = cursor.Decode(&record); err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, *record.ToDomain())
+	}
+
+	return payments, cursor.Err()
+}
+
+func (ref *paymentRepository) Update(ctx context.Context, id string, payment entity.Payment) (*entity.Payment, error) {
+	objectID := documentID.FromHex(id)
+
+	return ref.update(ctx, bson.M{"_id": objectID}, payment)
+}
+
+func (ref *paymentRepository) UpdatePending(ctx context.Context, id string, payment entity.Payment) (*entity.Payment, error) {
+	objectID := documentID.FromHex(id)
+
+	return ref.update(ctx, bson.M{"_id": objectID, "status": entity.PaymentStatusPending}, payment)
+}
+
+func (ref *paymentRepository) update(ctx context.Context, filter bson.M, payment entity.Payment) (*entity.Payment, error) {
+	record := model.PaymentFromDomain(payment)
+	record.ID = ""
+	record.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": record,
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated model.Payment
+
+	err := ref.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.ToDomain(), nil
+}
+
+func (ref *paymentRepository) findOne(ctx context.Context, filter bson.M) (*entity.Payment, error) {
+	result := ref.collection.FindOne(ctx, filter)
+	if err := result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record model.Payment
+	if err := result.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
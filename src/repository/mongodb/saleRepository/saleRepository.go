@@ -9,15 +9,24 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const millisecondsPerDay = 24 * 60 * 60 * 1000
+
+// netPrice is the price of a sale less its trade-in credit, which sales stored
+// before trade-ins were accepted do not have.
+var netPrice = bson.M{"$subtract": bson.A{"$price", bson.M{"$ifNull": bson.A{"$trade_in_credit", 0}}}}
+
 type saleRepository struct {
-	collection *mongo.Collection
+	collection         *mongo.Collection
+	vehiclesCollection *mongo.Collection
 }
 
-func NewSaleRepository(collection *mongo.Collection) interfaces.SaleRepository {
+func NewSaleRepository(collection, vehiclesCollection *mongo.Collection) interfaces.SaleRepository {
 	return &saleRepository{
-		collection: collection,
+		collection:         collection,
+		vehiclesCollection: vehiclesCollection,
 	}
 }
 
@@ -42,10 +51,11 @@ func (ref *saleRepository) Create(ctx context.Context, sale entity.Sale) (*entit
 }
 
 func (ref *saleRepository) Search(ctx context.Context) ([]entity.Sale, error) {
-	cursor, err := ref.collection.Find(ctx, bson.M{})
+	cursor, err := ref.collection.Find(ctx, bson.M{}, findOptions())
 	if err != nil {
 		return nil, err
 	}
+	defer cursor.Close(ctx)
 
 	sales := make([]entity.Sale, 0)
 
@@ -58,5 +68,126 @@ func (ref *saleRepository) Search(ctx context.Context) ([]entity.Sale, error) {
 		sales = append(sales, *record.ToDomain())
 	}
 
-	return sales, nil
+	return sales, cursor.Err()
+}
+
+func (ref *saleRepository) Iterate(ctx context.Context, fn func(entity.Sale) error) error {
+	cursor, err := ref.collection.Find(ctx, bson.M{}, findOptions())
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var record model.Sale
+		if err = cursor.Decode(&record); err != nil {
+			return err
+		}
+
+		if err = fn(*record.ToDomain()); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+func (ref *saleRepository) Report(ctx context.Context, filter entity.SalesReportFilter) ([]entity.SalesReportGroup, error) {
+	match := bson.M{}
+
+	if filter.From != nil || filter.To != nil {
+		soldAt := bson.M{}
+
+		if filter.From != nil {
+			soldAt["$gte"] = *filter.From
+		}
+
+		if filter.To != nil {
+			soldAt["$lt"] = *filter.To
+		}
+
+		match["sold_at"] = soldAt
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": ref.vehiclesCollection.Name(),
+			"let": bson.M{
+				"vehicle_id": bson.M{"$convert": bson.M{
+					"input":   "$vehicle_id",
+					"to":      "objectId",
+					"onError": nil,
+					"onNull":  nil,
+				}},
+			},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$vehicle_id"}}}},
+			},
+			"as": "vehicle",
+		}}},
+		{{Key: "$unwind", Value: bson.M{
+			"path":                       "$vehicle",
+			"preserveNullAndEmptyArrays": true,
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":            reportGroupKey(filter.GroupBy),
+			"count":          bson.M{"$sum": 1},
+			"revenue":        bson.M{"$sum": netPrice},
+			"average_ticket": bson.M{"$avg": netPrice},
+			"average_days_on_market": bson.M{"$avg": bson.M{"$divide": bson.A{
+				bson.M{"$subtract": bson.A{"$sold_at", "$vehicle.created_at"}},
+				millisecondsPerDay,
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := ref.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	groups := make([]entity.SalesReportGroup, 0)
+
+	for cursor.Next(ctx) {
+		var record model.SalesReportGroup
+		if err = cursor.Decode(&record); err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, record.ToDomain())
+	}
+
+	return groups, cursor.Err()
+}
+
+// findOptions sorts sales in the order they were created, since ObjectIDs grow
+// with the time they were created.
+func findOptions() *options.FindOptions {
+	return options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+}
+
+func reportGroupKey(groupBy string) any {
+	switch groupBy {
+	case entity.SalesReportGroupByDay:
+		return bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$sold_at"}}
+	case entity.SalesReportGroupByWeek:
+		return bson.M{"$dateToString": bson.M{"format": "%G-W%V", "date": "$sold_at"}}
+	case entity.SalesReportGroupByMonth:
+		return bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$sold_at"}}
+	case entity.SalesReportGroupByBrand:
+		return bson.M{"$ifNull": bson.A{"$vehicle.brand", ""}}
+	case entity.SalesReportGroupByModel:
+		return bson.M{"$concat": bson.A{
+			bson.M{"$ifNull": bson.A{"$vehicle.brand", ""}},
+			" ",
+			bson.M{"$ifNull": bson.A{"$vehicle.model", ""}},
+		}}
+	case entity.SalesReportGroupBySeller:
+		return bson.M{"$ifNull": bson.A{"$vehicle.seller_id", ""}}
+	default:
+		return nil
+	}
 }
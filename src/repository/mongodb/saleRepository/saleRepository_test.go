@@ -0,0 +1,24 @@
+//go:build integration
+
+package saleRepository
+
+import (
+	"testing"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/conformance"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/testDatabase"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/vehicleRepository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSaleRepository(t *testing.T) {
+	database := testDatabase.New(t)
+
+	conformance.SaleRepository(t, func(t *testing.T) (interfaces.VehicleRepository, interfaces.SaleRepository) {
+		vehiclesCollection := database.Collection(primitive.NewObjectID().Hex())
+		salesCollection := database.Collection(primitive.NewObjectID().Hex())
+
+		return vehicleRepository.NewVehicleRepository(vehiclesCollection), NewSaleRepository(salesCollection, vehiclesCollection)
+	})
+}
@@ -0,0 +1,91 @@
+// Package testDatabase gives tests a MongoDB database of their own, so that
+// the repositories can be tested against a real server.
+package testDatabase
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const startTimeout = 30 * time.Second
+
+// New returns an empty database that is dropped when the test ends. It uses
+// the server in MONGO_TEST_URI or, when it is not set, starts an ephemeral
+// mongod found in the PATH. The test is skipped when neither is available.
+func New(t *testing.T) *mongo.Database {
+	t.Helper()
+
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		uri = startServer(t)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", uri, err)
+	}
+
+	t.Cleanup(func() {
+		_ = client.Disconnect(context.Background())
+	})
+
+	// A freshly started mongod takes a moment to accept connections.
+	for err = client.Ping(ctx, nil); err != nil; err = client.Ping(ctx, nil) {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("failed to reach %s: %v", uri, err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	database := client.Database("test_" + primitive.NewObjectID().Hex())
+
+	t.Cleanup(func() {
+		_ = database.Drop(context.Background())
+	})
+
+	return database
+}
+
+// startServer runs mongod on a free port and a temporary data directory,
+// stopping it when the test ends.
+func startServer(t *testing.T) string {
+	t.Helper()
+
+	path, err := exec.LookPath("mongod")
+	if err != nil {
+		t.Skip("MONGO_TEST_URI is not set and mongod is not in the PATH")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	cmd := exec.Command(path, "--dbpath", t.TempDir(), "--bind_ip", "127.0.0.1", "--port", fmt.Sprint(port))
+	if err = cmd.Start(); err != nil {
+		t.Fatalf("failed to start mongod: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	return fmt.Sprintf("mongodb://127.0.0.1:%d", port)
+}
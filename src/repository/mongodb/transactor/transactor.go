@@ -0,0 +1,35 @@
+package transactor
+
+import (
+	"context"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// transactor runs functions in MongoDB transactions, which require a replica
+// set. The driver picks the session up from the context, so repositories join
+// the transaction without knowing about it.
+type transactor struct {
+	client *mongo.Client
+}
+
+func NewTransactor(client *mongo.Client) interfaces.Transactor {
+	return &transactor{
+		client: client,
+	}
+}
+
+func (ref *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := ref.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (any, error) {
+		return nil, fn(sessionCtx)
+	})
+
+	return err
+}
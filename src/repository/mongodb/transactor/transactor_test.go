@@ -0,0 +1,61 @@
+//go:build integration
+
+package transactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestWithinTransaction(t *testing.T) {
+	ctx := context.TODO()
+
+	database := testDatabase.New(t)
+
+	var hello bson.M
+	require.NoError(t, database.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello))
+
+	if _, ok := hello["setName"]; !ok {
+		t.Skip("transactions require a replica set")
+	}
+
+	collection := database.Collection("records")
+	require.NoError(t, database.CreateCollection(ctx, collection.Name()))
+
+	transactor := NewTransactor(database.Client())
+
+	t.Run("should commit writes when fn succeeds", func(t *testing.T) {
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			_, err := collection.InsertOne(ctx, bson.M{"_id": "committed"})
+			return err
+		})
+		require.NoError(t, err)
+
+		count, err := collection.CountDocuments(ctx, bson.M{"_id": "committed"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should roll back writes when fn fails", func(t *testing.T) {
+		failure := errors.New("failure")
+
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := collection.InsertOne(ctx, bson.M{"_id": "rolled-back"}); err != nil {
+				return err
+			}
+
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		count, err := collection.CountDocuments(ctx, bson.M{"_id": "rolled-back"})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
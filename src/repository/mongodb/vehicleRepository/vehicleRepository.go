@@ -7,6 +7,7 @@ import (
 	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
 	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
 	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/documentID"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -25,6 +26,7 @@ func NewVehicleRepository(collection *mongo.Collection) interfaces.VehicleReposi
 
 func (ref *vehicleRepository) Create(ctx context.Context, vehicle entity.Vehicle) (*entity.Vehicle, error) {
 	record := model.VehicleFromDomain(vehicle)
+	record.Version = 1
 
 	now := time.Now()
 	record.CreatedAt = now
@@ -54,13 +56,10 @@ func (ref *vehicleRepository) Create(ctx context.Context, vehicle entity.Vehicle
 }
 
 func (ref *vehicleRepository) GetByID(ctx context.Context, id string) (*entity.Vehicle, error) {
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return nil, err
-	}
+	objectID := documentID.FromHex(id)
 
 	result := ref.collection.FindOne(ctx, bson.M{"_id": objectID})
-	if err = result.Err(); err != nil {
+	if err := result.Err(); err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
 		}
@@ -68,25 +67,15 @@ func (ref *vehicleRepository) GetByID(ctx context.Context, id string) (*entity.V
 	}
 
 	var record model.Vehicle
-	if err = result.Decode(&record); err != nil {
+	if err := result.Decode(&record); err != nil {
 		return nil, err
 	}
 
 	return record.ToDomain(), nil
 }
 
-func (ref *vehicleRepository) Search(ctx context.Context, isSold *bool) ([]entity.Vehicle, error) {
-	filter := bson.M{}
-
-	if isSold != nil {
-		filter = bson.M{
-			"sold_at": bson.M{"$eq": nil},
-		}
-
-		if *isSold {
-			filter["sold_at"] = bson.M{"$ne": nil}
-		}
-	}
+func (ref *vehicleRepository) Search(ctx context.Context, vehicleFilter entity.VehicleFilter) ([]entity.Vehicle, error) {
+	filter := searchFilter(vehicleFilter)
 
 	sort := bson.D{{Key: "price", Value: 1}}
 
@@ -114,36 +103,153 @@ func (ref *vehicleRepository) Search(ctx context.Context, isSold *bool) ([]entit
 	return records, nil
 }
 
+func (ref *vehicleRepository) Iterate(ctx context.Context, vehicleFilter entity.VehicleFilter, fn func(entity.Vehicle) error) error {
+	filter := searchFilter(vehicleFilter)
+
+	sort := bson.D{{Key: "price", Value: 1}}
+
+	findOptions := options.Find().SetSort(sort)
+
+	cursor, err := ref.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var record model.Vehicle
+		if err = cursor.Decode(&record); err != nil {
+			return err
+		}
+
+		if err = fn(*record.ToDomain()); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// Update increments the version of the vehicle. When vehicle.Version is set,
+// the vehicle is only updated if it is still at that version.
 func (ref *vehicleRepository) Update(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
 	record := model.VehicleFromDomain(vehicle)
+	record.Version = 0
 	record.UpdatedAt = time.Now()
 
-	objectID, err := primitive.ObjectIDFromHex(id)
+	return ref.update(ctx, id, vehicle.Version, bson.M{"$set": record})
+}
+
+// Replace is conditional on vehicle.Version like Update.
+func (ref *vehicleRepository) Replace(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	return ref.update(ctx, id, vehicle.Version, bson.M{
+		"$set": bson.M{
+			"brand":      vehicle.Brand,
+			"model":      vehicle.Model,
+			"year":       vehicle.Year,
+			"color":      vehicle.Color,
+			"price":      vehicle.Price,
+			"status":     vehicle.Status,
+			"updated_at": time.Now(),
+		},
+	})
+}
+
+func (ref *vehicleRepository) Delete(ctx context.Context, id string, version int64, deletedBy string) (*entity.Vehicle, error) {
+	now := time.Now()
+
+	return ref.update(ctx, id, version, bson.M{
+		"$set": bson.M{
+			"deleted_at": now,
+			"deleted_by": deletedBy,
+			"updated_at": now,
+		},
+	})
+}
+
+func (ref *vehicleRepository) Restore(ctx context.Context, id string, version int64) (*entity.Vehicle, error) {
+	return ref.update(ctx, id, version, bson.M{
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+	})
+}
+
+func (ref *vehicleRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	deleted, err := ref.collection.DeleteMany(ctx, bson.M{
+		"deleted_at": bson.M{"$lt": deletedBefore},
+	})
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	update := bson.M{
-		"$set": record,
-	}
+	return int(deleted.DeletedCount), nil
+}
 
-	_, err = ref.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
-	if err != nil {
-		return nil, err
+// update applies the update document and increments the version.
+func (ref *vehicleRepository) update(ctx context.Context, id string, version int64, update bson.M) (*entity.Vehicle, error) {
+	objectID := documentID.FromHex(id)
+
+	filter := bson.M{"_id": objectID}
+
+	switch {
+	case version == 1:
+		// Vehicles created before versioning have no version field.
+		filter["$or"] = bson.A{
+			bson.M{"version": 1},
+			bson.M{"version": bson.M{"$exists": false}},
+		}
+	case version > 1:
+		filter["version"] = version
 	}
 
-	result := ref.collection.FindOne(ctx, bson.M{"_id": objectID})
-	if err = result.Err(); err != nil {
-		if err == mongo.ErrNoDocuments {
+	update["$inc"] = bson.M{"version": 1}
+
+	// The updated document is returned by the same command, so that a
+	// concurrent update cannot slip in before it is read.
+	result := ref.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	if err := result.Err(); err != nil {
+		if err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+
+		if version == 0 {
 			return nil, nil
 		}
-		return nil, err
+
+		count, err := ref.collection.CountDocuments(ctx, bson.M{"_id": objectID})
+		if err != nil {
+			return nil, err
+		}
+
+		if count > 0 {
+			return nil, entity.ErrVehicleVersionMismatch
+		}
+
+		return nil, nil
 	}
 
 	var recordToReturn model.Vehicle
-	if err = result.Decode(&recordToReturn); err != nil {
+	if err := result.Decode(&recordToReturn); err != nil {
 		return nil, err
 	}
 
 	return recordToReturn.ToDomain(), nil
 }
+
+func searchFilter(vehicleFilter entity.VehicleFilter) bson.M {
+	filter := bson.M{}
+
+	if vehicleFilter.IsSold != nil {
+		filter["sold_at"] = bson.M{"$eq": nil}
+
+		if *vehicleFilter.IsSold {
+			filter["sold_at"] = bson.M{"$ne": nil}
+		}
+	}
+
+	if !vehicleFilter.IncludeDeleted {
+		filter["deleted_at"] = bson.M{"$eq": nil}
+	}
+
+	return filter
+}
@@ -0,0 +1,20 @@
+//go:build integration
+
+package vehicleRepository
+
+import (
+	"testing"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/conformance"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/testDatabase"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestVehicleRepository(t *testing.T) {
+	database := testDatabase.New(t)
+
+	conformance.VehicleRepository(t, func(t *testing.T) interfaces.VehicleRepository {
+		return NewVehicleRepository(database.Collection(primitive.NewObjectID().Hex()))
+	})
+}
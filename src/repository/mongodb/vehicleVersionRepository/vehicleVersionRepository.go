@@ -0,0 +1,81 @@
+package vehicleVersionRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// vehicleVersionRepository only ever inserts, like the audit repository.
+type vehicleVersionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewVehicleVersionRepository(collection *mongo.Collection) interfaces.VehicleVersionRepository {
+	return &vehicleVersionRepository{
+		collection: collection,
+	}
+}
+
+func (ref *vehicleVersionRepository) Append(ctx context.Context, version entity.VehicleVersion) error {
+	document := model.VehicleVersionFromDomain(version)
+	document.ID = ""
+
+	_, err := ref.collection.InsertOne(ctx, document)
+	return err
+}
+
+func (ref *vehicleVersionRepository) Search(ctx context.Context, vehicleID string) ([]entity.VehicleVersion, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "version", Value: 1}})
+
+	cursor, err := ref.collection.Find(ctx, bson.M{"vehicle_id": vehicleID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	versions := make([]entity.VehicleVersion, 0)
+
+	for cursor.Next(ctx) {
+		var document model.VehicleVersion
+		if err = cursor.Decode(&document); err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, *document.ToDomain())
+	}
+
+	return versions, cursor.Err()
+}
+
+func (ref *vehicleVersionRepository) GetAsOf(ctx context.Context, vehicleID string, asOf time.Time) (*entity.VehicleVersion, error) {
+	filter := bson.M{
+		"vehicle_id":  vehicleID,
+		"recorded_at": bson.M{"$lte": asOf},
+	}
+
+	findOptions := options.FindOne().
+		SetSort(bson.D{{Key: "version", Value: -1}})
+
+	result := ref.collection.FindOne(ctx, filter, findOptions)
+	if err := result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var document model.VehicleVersion
+	if err := result.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return document.ToDomain(), nil
+}
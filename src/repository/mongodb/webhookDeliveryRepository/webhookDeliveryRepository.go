@@ -0,0 +1,160 @@
+package webhookDeliveryRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/documentID"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookDeliveryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookDeliveryRepository(collection *mongo.Collection) interfaces.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		collection: collection,
+	}
+}
+
+// Enqueue upserts on the subscription and event, so that the delivery is
+// only inserted the first time. A concurrent upsert of the same delivery
+// that loses the race on the unique index finds it already inserted.
+func (ref *webhookDeliveryRepository) Enqueue(ctx context.Context, delivery entity.WebhookDelivery) error {
+	record := model.WebhookDeliveryFromDomain(delivery)
+	record.ID = ""
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	filter := bson.M{
+		"subscription_id": record.SubscriptionID,
+		"event_id":        record.EventID,
+	}
+
+	update := bson.M{
+		"$setOnInsert": record,
+	}
+
+	_, err := ref.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (ref *webhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	objectID := documentID.FromHex(id)
+
+	return ref.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (ref *webhookDeliveryRepository) SearchBySubscriptionID(ctx context.Context, subscriptionID string) ([]entity.WebhookDelivery, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := ref.collection.Find(ctx, bson.M{"subscription_id": subscriptionID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+
+	for cursor.Next(ctx) {
+		var record model.WebhookDelivery
+		if err = cursor.Decode(&record); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, *record.ToDomain())
+	}
+
+	return deliveries, cursor.Err()
+}
+
+// ClaimPending claims one delivery at a time, so that concurrent workers
+// never claim the same delivery.
+func (ref *webhookDeliveryRepository) ClaimPending(ctx context.Context, limit int, now, lockedUntil time.Time) ([]entity.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          entity.WebhookDeliveryStatusPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"next_attempt_at": lockedUntil,
+			"updated_at":      now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+
+	findOptions := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	deliveries := make([]entity.WebhookDelivery, 0, limit)
+
+	for len(deliveries) < limit {
+		result := ref.collection.FindOneAndUpdate(ctx, filter, update, findOptions)
+		if err := result.Err(); err != nil {
+			if err == mongo.ErrNoDocuments {
+				break
+			}
+			return nil, err
+		}
+
+		var record model.WebhookDelivery
+		if err := result.Decode(&record); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, *record.ToDomain())
+	}
+
+	return deliveries, nil
+}
+
+func (ref *webhookDeliveryRepository) Update(ctx context.Context, id string, delivery entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	record := model.WebhookDeliveryFromDomain(delivery)
+	record.ID = ""
+	record.UpdatedAt = time.Now()
+
+	objectID := documentID.FromHex(id)
+
+	update := bson.M{
+		"$set": record,
+	}
+
+	_, err := ref.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return ref.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (ref *webhookDeliveryRepository) findOne(ctx context.Context, filter bson.M) (*entity.WebhookDelivery, error) {
+	result := ref.collection.FindOne(ctx, filter)
+	if err := result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record model.WebhookDelivery
+	if err := result.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
@@ -0,0 +1,105 @@
+package webhookSubscriptionRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/mongodb/documentID"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookSubscriptionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookSubscriptionRepository(collection *mongo.Collection) interfaces.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{
+		collection: collection,
+	}
+}
+
+func (ref *webhookSubscriptionRepository) Create(ctx context.Context, subscription entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	record := model.WebhookSubscriptionFromDomain(subscription)
+	record.ID = ""
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	created, err := ref.collection.InsertOne(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	id := created.InsertedID.(primitive.ObjectID)
+
+	return ref.findOne(ctx, bson.M{"_id": id})
+}
+
+func (ref *webhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	objectID := documentID.FromHex(id)
+
+	return ref.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (ref *webhookSubscriptionRepository) Search(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	return ref.find(ctx, bson.M{})
+}
+
+func (ref *webhookSubscriptionRepository) SearchByEventType(ctx context.Context, eventType string) ([]entity.WebhookSubscription, error) {
+	return ref.find(ctx, bson.M{"event_types": eventType})
+}
+
+func (ref *webhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	objectID := documentID.FromHex(id)
+
+	_, err := ref.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	return err
+}
+
+func (ref *webhookSubscriptionRepository) find(ctx context.Context, filter bson.M) ([]entity.WebhookSubscription, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := ref.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := make([]entity.WebhookSubscription, 0)
+
+	for cursor.Next(ctx) {
+		var record model.WebhookSubscription
+		if err = cursor.Decode(&record); err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, *record.ToDomain())
+	}
+
+	return subscriptions, cursor.Err()
+}
+
+func (ref *webhookSubscriptionRepository) findOne(ctx context.Context, filter bson.M) (*entity.WebhookSubscription, error) {
+	result := ref.collection.FindOne(ctx, filter)
+	if err := result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record model.WebhookSubscription
+	if err := result.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
@@ -0,0 +1,67 @@
+package auditRepository
+
+import (
+	"context"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// auditRepository only ever inserts: records are never updated or deleted.
+type auditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepository(pool *pgxpool.Pool) interfaces.AuditRepository {
+	return &auditRepository{
+		pool: pool,
+	}
+}
+
+func (ref *auditRepository) Append(ctx context.Context, record entity.AuditRecord) error {
+	document := model.AuditRecordFromDomain(record)
+
+	_, err := transactor.Get(ctx, ref.pool).Exec(ctx, `
+		INSERT INTO audit (entity_type, entity_id, action, actor_id, actor_role, request_id, changes, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		document.EntityType, document.EntityID, document.Action, document.ActorID, document.ActorRole,
+		document.RequestID, document.Changes, document.OccurredAt,
+	)
+
+	return err
+}
+
+func (ref *auditRepository) Search(ctx context.Context, entityID string) ([]entity.AuditRecord, error) {
+	rows, err := transactor.Get(ctx, ref.pool).Query(ctx, `
+		SELECT id, entity_type, entity_id, action, actor_id, actor_role, request_id, changes, occurred_at
+		FROM audit
+		WHERE entity_id = $1
+		ORDER BY occurred_at, created_at`,
+		entityID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]entity.AuditRecord, 0)
+
+	for rows.Next() {
+		var document model.AuditRecord
+
+		err = rows.Scan(
+			&document.ID, &document.EntityType, &document.EntityID, &document.Action, &document.ActorID,
+			&document.ActorRole, &document.RequestID, &document.Changes, &document.OccurredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, *document.ToDomain())
+	}
+
+	return records, rows.Err()
+}
@@ -0,0 +1,61 @@
+//go:build integration
+
+package auditRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	repository := NewAuditRepository(testDatabase.New(t))
+
+	occurredAt := time.Now().UTC().Add(-time.Hour)
+
+	updated := entity.AuditRecord{
+		EntityType: "vehicle",
+		EntityID:   "vehicle-1",
+		Action:     "update",
+		ActorID:    "user-1",
+		ActorRole:  "admin",
+		RequestID:  "request-1",
+		Changes:    []entity.AuditChange{{Field: "price", Before: 50000.0, After: 45000.0}},
+		OccurredAt: occurredAt.Add(time.Minute),
+	}
+
+	created := entity.AuditRecord{EntityType: "vehicle", EntityID: "vehicle-1", Action: "create", ActorID: "user-1", OccurredAt: occurredAt}
+
+	require.NoError(t, repository.Append(ctx, updated))
+	require.NoError(t, repository.Append(ctx, created))
+	require.NoError(t, repository.Append(ctx, entity.AuditRecord{EntityType: "vehicle", EntityID: "vehicle-2", Action: "create", OccurredAt: occurredAt}))
+
+	t.Run("should search records of the entity from the oldest", func(t *testing.T) {
+		actual, err := repository.Search(ctx, "vehicle-1")
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+
+		assert.Equal(t, "create", actual[0].Action)
+		assert.Empty(t, actual[0].Changes)
+
+		assert.NotEmpty(t, actual[1].ID)
+		assert.Equal(t, "update", actual[1].Action)
+		assert.Equal(t, "admin", actual[1].ActorRole)
+		assert.Equal(t, "request-1", actual[1].RequestID)
+		assert.Equal(t, updated.Changes, actual[1].Changes)
+		assert.WithinDuration(t, updated.OccurredAt, actual[1].OccurredAt, time.Millisecond)
+	})
+
+	t.Run("should search nothing for unknown entity", func(t *testing.T) {
+		actual, err := repository.Search(ctx, "vehicle-3")
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
@@ -0,0 +1,25 @@
+// Package connection configures the connections of the PostgreSQL pools.
+package connection
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Configure makes the connections of config read timestamps in UTC, as the
+// other backends return them, instead of in the local time zone.
+func Configure(config *pgxpool.Config) {
+	config.AfterConnect = func(_ context.Context, conn *pgx.Conn) error {
+		conn.TypeMap().RegisterType(&pgtype.Type{
+			Name:  "timestamptz",
+			OID:   pgtype.TimestamptzOID,
+			Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,26 @@
+package healthChecker
+
+import (
+	"context"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type healthChecker struct {
+	pool *pgxpool.Pool
+}
+
+func NewHealthChecker(pool *pgxpool.Pool) interfaces.HealthChecker {
+	return &healthChecker{
+		pool: pool,
+	}
+}
+
+func (ref *healthChecker) Name() string {
+	return "postgres"
+}
+
+func (ref *healthChecker) Check(ctx context.Context) error {
+	return ref.pool.Ping(ctx)
+}
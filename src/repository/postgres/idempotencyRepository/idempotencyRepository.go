@@ -0,0 +1,98 @@
+package idempotencyRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type idempotencyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewIdempotencyRepository(pool *pgxpool.Pool) interfaces.IdempotencyRepository {
+	return &idempotencyRepository{
+		pool: pool,
+	}
+}
+
+// Reserve inserts the record, or replaces the stored one when it has expired.
+// When an unexpired record exists nothing is written and that record is
+// returned instead.
+func (ref *idempotencyRepository) Reserve(ctx context.Context, record entity.IdempotencyRecord, now time.Time) (*entity.IdempotencyRecord, error) {
+	document := model.IdempotencyRecordFromDomain(record)
+
+	querier := transactor.Get(ctx, ref.pool)
+
+	reserved, err := querier.Exec(ctx, `
+		INSERT INTO idempotency_keys (key, fingerprint, status, status_code, content_type, body, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			status = EXCLUDED.status,
+			status_code = EXCLUDED.status_code,
+			content_type = EXCLUDED.content_type,
+			body = EXCLUDED.body,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= $9`,
+		document.Key, document.Fingerprint, document.Status, document.StatusCode, document.ContentType,
+		document.Body, document.CreatedAt, document.ExpiresAt, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if reserved.RowsAffected() > 0 {
+		return nil, nil
+	}
+
+	var existing model.IdempotencyRecord
+
+	err = querier.QueryRow(ctx, `
+		SELECT key, fingerprint, status, status_code, content_type, body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = $1`,
+		document.Key,
+	).Scan(
+		&existing.Key, &existing.Fingerprint, &existing.Status, &existing.StatusCode, &existing.ContentType,
+		&existing.Body, &existing.CreatedAt, &existing.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return existing.ToDomain(), nil
+}
+
+func (ref *idempotencyRepository) Complete(ctx context.Context, record entity.IdempotencyRecord) error {
+	document := model.IdempotencyRecordFromDomain(record)
+
+	_, err := transactor.Get(ctx, ref.pool).Exec(ctx, `
+		UPDATE idempotency_keys SET status = $3, status_code = $4, content_type = $5, body = $6, expires_at = $7
+		WHERE key = $1 AND fingerprint = $2`,
+		document.Key, document.Fingerprint, document.Status, document.StatusCode, document.ContentType,
+		document.Body, document.ExpiresAt,
+	)
+
+	return err
+}
+
+func (ref *idempotencyRepository) Delete(ctx context.Context, key string) error {
+	_, err := transactor.Get(ctx, ref.pool).Exec(ctx, "DELETE FROM idempotency_keys WHERE key = $1", key)
+	return err
+}
+
+func (ref *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	deleted, err := transactor.Get(ctx, ref.pool).Exec(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= $1", now)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted.RowsAffected()), nil
+}
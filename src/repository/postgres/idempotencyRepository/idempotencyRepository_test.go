@@ -0,0 +1,128 @@
+//go:build integration
+
+package idempotencyRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should reserve key once until it expires", func(t *testing.T) {
+		repository := NewIdempotencyRepository(testDatabase.New(t))
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+
+		record := entity.IdempotencyRecord{
+			Key:         "key-1",
+			Fingerprint: "fingerprint-1",
+			Status:      entity.IdempotencyStatusInProgress,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(time.Minute),
+		}
+
+		existing, err := repository.Reserve(ctx, record, now)
+		require.NoError(t, err)
+		assert.Nil(t, existing)
+
+		retry := record
+		retry.Fingerprint = "fingerprint-2"
+		retry.ExpiresAt = now.Add(2 * time.Minute)
+
+		existing, err = repository.Reserve(ctx, retry, now)
+		require.NoError(t, err)
+		require.NotNil(t, existing)
+		assert.Equal(t, "fingerprint-1", existing.Fingerprint)
+		assert.Equal(t, entity.IdempotencyStatusInProgress, existing.Status)
+
+		existing, err = repository.Reserve(ctx, retry, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Nil(t, existing)
+
+		existing, err = repository.Reserve(ctx, record, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, existing)
+		assert.Equal(t, "fingerprint-2", existing.Fingerprint)
+	})
+
+	t.Run("should complete only the reservation with the same fingerprint", func(t *testing.T) {
+		repository := NewIdempotencyRepository(testDatabase.New(t))
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+
+		record := entity.IdempotencyRecord{
+			Key:         "key-1",
+			Fingerprint: "fingerprint-1",
+			Status:      entity.IdempotencyStatusInProgress,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(time.Minute),
+		}
+
+		_, err := repository.Reserve(ctx, record, now)
+		require.NoError(t, err)
+
+		other := record
+		other.Fingerprint = "fingerprint-2"
+		other.Status = entity.IdempotencyStatusCompleted
+
+		require.NoError(t, repository.Complete(ctx, other))
+
+		completed := record
+		completed.Status = entity.IdempotencyStatusCompleted
+		completed.StatusCode = 201
+		completed.ContentType = "application/json"
+		completed.Body = []byte(`{"id":"vehicle-1"}`)
+		completed.ExpiresAt = now.Add(time.Hour)
+
+		require.NoError(t, repository.Complete(ctx, completed))
+
+		existing, err := repository.Reserve(ctx, record, now)
+		require.NoError(t, err)
+		require.NotNil(t, existing)
+
+		assert.Equal(t, "fingerprint-1", existing.Fingerprint)
+		assert.True(t, existing.IsCompleted())
+		assert.Equal(t, 201, existing.StatusCode)
+		assert.Equal(t, "application/json", existing.ContentType)
+		assert.Equal(t, `{"id":"vehicle-1"}`, string(existing.Body))
+		assert.WithinDuration(t, completed.ExpiresAt, existing.ExpiresAt, time.Millisecond)
+	})
+
+	t.Run("should delete keys", func(t *testing.T) {
+		repository := NewIdempotencyRepository(testDatabase.New(t))
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+
+		for _, record := range []entity.IdempotencyRecord{
+			{Key: "key-1", Status: entity.IdempotencyStatusInProgress, CreatedAt: now, ExpiresAt: now.Add(-time.Second)},
+			{Key: "key-2", Status: entity.IdempotencyStatusInProgress, CreatedAt: now, ExpiresAt: now.Add(-time.Minute)},
+			{Key: "key-3", Status: entity.IdempotencyStatusInProgress, CreatedAt: now, ExpiresAt: now.Add(time.Minute)},
+			{Key: "key-4", Status: entity.IdempotencyStatusInProgress, CreatedAt: now, ExpiresAt: now.Add(time.Minute)},
+		} {
+			_, err := repository.Reserve(ctx, record, now.Add(-time.Hour))
+			require.NoError(t, err)
+		}
+
+		deleted, err := repository.DeleteExpired(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+
+		require.NoError(t, repository.Delete(ctx, "key-3"))
+
+		existing, err := repository.Reserve(ctx, entity.IdempotencyRecord{Key: "key-3", Status: entity.IdempotencyStatusInProgress, CreatedAt: now, ExpiresAt: now.Add(time.Minute)}, now)
+		require.NoError(t, err)
+		assert.Nil(t, existing)
+
+		existing, err = repository.Reserve(ctx, entity.IdempotencyRecord{Key: "key-4", Status: entity.IdempotencyStatusInProgress, CreatedAt: now, ExpiresAt: now.Add(time.Minute)}, now)
+		require.NoError(t, err)
+		assert.NotNil(t, existing)
+	})
+}
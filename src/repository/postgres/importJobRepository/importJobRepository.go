@@ -0,0 +1,101 @@
+package importJobRepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/rowID"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const columns = "id, job_id, user_id, dry_run, status, total, valid, processed, imported, errors, created_at, updated_at, finished_at"
+
+type importJobRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewImportJobRepository(pool *pgxpool.Pool) interfaces.ImportJobRepository {
+	return &importJobRepository{
+		pool: pool,
+	}
+}
+
+func (ref *importJobRepository) Create(ctx context.Context, job entity.ImportJob) (*entity.ImportJob, error) {
+	record := model.ImportJobFromDomain(job)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		INSERT INTO import_jobs (job_id, user_id, dry_run, status, total, valid, processed, imported, errors, created_at, updated_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10, $11)
+		RETURNING `+columns,
+		record.JobID, record.UserID, record.DryRun, record.Status, record.Total, record.Valid, record.Processed,
+		record.Imported, record.Errors, time.Now(), record.FinishedAt,
+	)
+
+	return scan(row)
+}
+
+func (ref *importJobRepository) GetByID(ctx context.Context, id string) (*entity.ImportJob, error) {
+	id = rowID.FromString(id)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, "SELECT "+columns+" FROM import_jobs WHERE id = $1", id)
+
+	return scanOne(row)
+}
+
+// Update replaces the import job, keeping only its id and creation time.
+func (ref *importJobRepository) Update(ctx context.Context, id string, job entity.ImportJob) (*entity.ImportJob, error) {
+	id = rowID.FromString(id)
+
+	record := model.ImportJobFromDomain(job)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		UPDATE import_jobs SET
+			job_id = $2,
+			user_id = $3,
+			dry_run = $4,
+			status = $5,
+			total = $6,
+			valid = $7,
+			processed = $8,
+			imported = $9,
+			errors = $10,
+			updated_at = $11,
+			finished_at = $12
+		WHERE id = $1
+		RETURNING `+columns,
+		id, record.JobID, record.UserID, record.DryRun, record.Status, record.Total, record.Valid, record.Processed,
+		record.Imported, record.Errors, time.Now(), record.FinishedAt,
+	)
+
+	return scanOne(row)
+}
+
+// scanOne is scan for queries that may match no import job.
+func scanOne(row pgx.Row) (*entity.ImportJob, error) {
+	job, err := scan(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return job, err
+}
+
+func scan(row pgx.Row) (*entity.ImportJob, error) {
+	var record model.ImportJob
+
+	err := row.Scan(
+		&record.ID, &record.JobID, &record.UserID, &record.DryRun, &record.Status, &record.Total, &record.Valid,
+		&record.Processed, &record.Imported, &record.Errors, &record.CreatedAt, &record.UpdatedAt, &record.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
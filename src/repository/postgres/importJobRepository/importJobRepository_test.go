@@ -0,0 +1,76 @@
+//go:build integration
+
+package importJobRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportJobRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should create, find and update import job", func(t *testing.T) {
+		repository := NewImportJobRepository(testDatabase.New(t))
+
+		created, err := repository.Create(ctx, entity.ImportJob{
+			UserID: "user-1",
+			DryRun: true,
+			Status: entity.ImportJobStatusPending,
+			Total:  3,
+			Valid:  2,
+			Errors: []entity.ImportRowError{{Row: 2, Error: "year is required"}},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, created)
+
+		assert.NotEmpty(t, created.ID)
+		assert.Equal(t, []entity.ImportRowError{{Row: 2, Error: "year is required"}}, created.Errors)
+		assert.Nil(t, created.FinishedAt)
+
+		actual, err := repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created, actual)
+
+		finishedAt := time.Now().UTC()
+
+		job := *created
+		job.JobID = "job-1"
+		job.Status = entity.ImportJobStatusCompleted
+		job.Processed = 3
+		job.Imported = 2
+		job.FinishedAt = &finishedAt
+
+		actual, err = repository.Update(ctx, created.ID, job)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, "job-1", actual.JobID)
+		assert.Equal(t, entity.ImportJobStatusCompleted, actual.Status)
+		assert.Equal(t, 3, actual.Processed)
+		assert.Equal(t, 2, actual.Imported)
+		assert.Equal(t, created.Errors, actual.Errors)
+		assert.WithinDuration(t, finishedAt, *actual.FinishedAt, time.Millisecond)
+		assert.Equal(t, created.CreatedAt, actual.CreatedAt)
+	})
+
+	t.Run("should not find import job with unknown or malformed id", func(t *testing.T) {
+		repository := NewImportJobRepository(testDatabase.New(t))
+
+		for _, id := range []string{"00000000-0000-0000-0000-000000000000", "not-an-id"} {
+			actual, err := repository.GetByID(ctx, id)
+			assert.Nil(t, actual)
+			assert.Nil(t, err)
+
+			actual, err = repository.Update(ctx, id, entity.ImportJob{Status: entity.ImportJobStatusFailed})
+			assert.Nil(t, actual)
+			assert.Nil(t, err)
+		}
+	})
+}
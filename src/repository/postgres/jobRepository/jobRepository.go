@@ -0,0 +1,154 @@
+package jobRepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/rowID"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const columns = "id, type, user_id, payload, status, attempts, max_attempts, last_error, run_at, locked_until, started_at, finished_at, created_at, updated_at"
+
+type jobRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewJobRepository(pool *pgxpool.Pool) interfaces.JobRepository {
+	return &jobRepository{
+		pool: pool,
+	}
+}
+
+func (ref *jobRepository) Create(ctx context.Context, job entity.Job) (*entity.Job, error) {
+	record := model.JobFromDomain(job)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		INSERT INTO jobs (type, user_id, payload, status, attempts, max_attempts, last_error, run_at, locked_until, started_at, finished_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		RETURNING `+columns,
+		record.Type, record.UserID, record.Payload, record.Status, record.Attempts, record.MaxAttempts, record.LastError,
+		record.RunAt, record.LockedUntil, record.StartedAt, record.FinishedAt, time.Now(),
+	)
+
+	return scan(row)
+}
+
+func (ref *jobRepository) GetByID(ctx context.Context, id string) (*entity.Job, error) {
+	id = rowID.FromString(id)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, "SELECT "+columns+" FROM jobs WHERE id = $1", id)
+
+	return scanOne(row)
+}
+
+// Update replaces the job, keeping only its id and creation time.
+func (ref *jobRepository) Update(ctx context.Context, id string, job entity.Job) (*entity.Job, error) {
+	id = rowID.FromString(id)
+
+	record := model.JobFromDomain(job)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		UPDATE jobs SET
+			type = $2,
+			user_id = $3,
+			payload = $4,
+			status = $5,
+			attempts = $6,
+			max_attempts = $7,
+			last_error = $8,
+			run_at = $9,
+			locked_until = $10,
+			started_at = $11,
+			finished_at = $12,
+			updated_at = $13
+		WHERE id = $1
+		RETURNING `+columns,
+		id, record.Type, record.UserID, record.Payload, record.Status, record.Attempts, record.MaxAttempts, record.LastError,
+		record.RunAt, record.LockedUntil, record.StartedAt, record.FinishedAt, time.Now(),
+	)
+
+	return scanOne(row)
+}
+
+func (ref *jobRepository) Cancel(ctx context.Context, id string, finishedAt time.Time) (*entity.Job, error) {
+	id = rowID.FromString(id)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		UPDATE jobs SET status = $2, locked_until = NULL, finished_at = $3, updated_at = $3
+		WHERE id = $1 AND status IN ($4, $5)
+		RETURNING `+columns,
+		id, entity.JobStatusCanceled, finishedAt, entity.JobStatusPending, entity.JobStatusRunning,
+	)
+
+	return scanOne(row)
+}
+
+// ClaimNext skips the jobs other workers are claiming at the same time, so
+// that concurrent workers never claim the same job.
+func (ref *jobRepository) ClaimNext(ctx context.Context, types []string, now, lockedUntil time.Time) (*entity.Job, error) {
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		UPDATE jobs SET status = $2, attempts = attempts + 1, locked_until = $4, started_at = $3, updated_at = $3
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE type = ANY($5)
+			AND ((status = $1 AND run_at <= $3) OR (status = $2 AND locked_until < $3))
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+columns,
+		entity.JobStatusPending, entity.JobStatusRunning, now, lockedUntil, types,
+	)
+
+	return scanOne(row)
+}
+
+// Heartbeat only extends the lock of a running job, but returns the job in
+// any state.
+func (ref *jobRepository) Heartbeat(ctx context.Context, id string, lockedUntil time.Time) (*entity.Job, error) {
+	id = rowID.FromString(id)
+
+	querier := transactor.Get(ctx, ref.pool)
+
+	_, err := querier.Exec(ctx, `
+		UPDATE jobs SET locked_until = $3, updated_at = $4
+		WHERE id = $1 AND status = $2`,
+		id, entity.JobStatusRunning, lockedUntil, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanOne(querier.QueryRow(ctx, "SELECT "+columns+" FROM jobs WHERE id = $1", id))
+}
+
+// scanOne is scan for queries that may match no job.
+func scanOne(row pgx.Row) (*entity.Job, error) {
+	job, err := scan(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return job, err
+}
+
+func scan(row pgx.Row) (*entity.Job, error) {
+	var record model.Job
+
+	err := row.Scan(
+		&record.ID, &record.Type, &record.UserID, &record.Payload, &record.Status, &record.Attempts, &record.MaxAttempts, &record.LastError,
+		&record.RunAt, &record.LockedUntil, &record.StartedAt, &record.FinishedAt, &record.CreatedAt, &record.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
@@ -0,0 +1,191 @@
+//go:build integration
+
+package jobRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should create, find and update job", func(t *testing.T) {
+		repository := NewJobRepository(testDatabase.New(t))
+
+		created, err := repository.Create(ctx, entity.Job{
+			Type:        "vehicle_import",
+			UserID:      "user-1",
+			Payload:     []byte(`{"import_id":"import-1"}`),
+			Status:      entity.JobStatusPending,
+			MaxAttempts: 3,
+			RunAt:       time.Now(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, created)
+
+		assert.NotEmpty(t, created.ID)
+		assert.Equal(t, "user-1", created.UserID)
+		assert.Equal(t, `{"import_id":"import-1"}`, string(created.Payload))
+
+		actual, err := repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created, actual)
+
+		finishedAt := time.Now().UTC()
+
+		job := *created
+		job.Status = entity.JobStatusFailed
+		job.LastError = "invalid payload"
+		job.FinishedAt = &finishedAt
+
+		actual, err = repository.Update(ctx, created.ID, job)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, entity.JobStatusFailed, actual.Status)
+		assert.Equal(t, "invalid payload", actual.LastError)
+		assert.WithinDuration(t, finishedAt, *actual.FinishedAt, time.Millisecond)
+		assert.Equal(t, created.CreatedAt, actual.CreatedAt)
+	})
+
+	t.Run("should not find job with unknown or malformed id", func(t *testing.T) {
+		repository := NewJobRepository(testDatabase.New(t))
+
+		for _, id := range []string{"00000000-0000-0000-0000-000000000000", "not-an-id"} {
+			actual, err := repository.GetByID(ctx, id)
+			assert.Nil(t, actual)
+			assert.Nil(t, err)
+
+			actual, err = repository.Update(ctx, id, entity.Job{Status: entity.JobStatusCanceled})
+			assert.Nil(t, actual)
+			assert.Nil(t, err)
+		}
+	})
+
+	t.Run("should cancel pending or running jobs only", func(t *testing.T) {
+		repository := NewJobRepository(testDatabase.New(t))
+
+		now := time.Now()
+
+		pending, err := repository.Create(ctx, entity.Job{Type: "vehicle_import", Status: entity.JobStatusPending, RunAt: now})
+		require.NoError(t, err)
+
+		actual, err := repository.Cancel(ctx, pending.ID, now)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, entity.JobStatusCanceled, actual.Status)
+		assert.Nil(t, actual.LockedUntil)
+		assert.WithinDuration(t, now, *actual.FinishedAt, time.Millisecond)
+
+		succeeded, err := repository.Create(ctx, entity.Job{Type: "vehicle_import", Status: entity.JobStatusSucceeded, RunAt: now})
+		require.NoError(t, err)
+
+		actual, err = repository.Cancel(ctx, succeeded.ID, now)
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+
+		actual, err = repository.GetByID(ctx, succeeded.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.JobStatusSucceeded, actual.Status)
+
+		actual, err = repository.Cancel(ctx, "not-an-id", now)
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should claim due jobs of the given types oldest first", func(t *testing.T) {
+		repository := NewJobRepository(testDatabase.New(t))
+
+		now := time.Now()
+
+		newer, err := repository.Create(ctx, entity.Job{Type: "vehicle_import", Status: entity.JobStatusPending, RunAt: now.Add(-time.Second)})
+		require.NoError(t, err)
+
+		older, err := repository.Create(ctx, entity.Job{Type: "vehicle_import", Status: entity.JobStatusPending, RunAt: now.Add(-time.Minute)})
+		require.NoError(t, err)
+
+		_, err = repository.Create(ctx, entity.Job{Type: "vehicle_import", Status: entity.JobStatusPending, RunAt: now.Add(time.Minute)})
+		require.NoError(t, err)
+
+		_, err = repository.Create(ctx, entity.Job{Type: "report", Status: entity.JobStatusPending, RunAt: now.Add(-time.Hour)})
+		require.NoError(t, err)
+
+		lockedUntil := now.Add(time.Minute)
+
+		actual, err := repository.ClaimNext(ctx, []string{"vehicle_import"}, now, lockedUntil)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, older.ID, actual.ID)
+		assert.Equal(t, entity.JobStatusRunning, actual.Status)
+		assert.Equal(t, 1, actual.Attempts)
+		assert.WithinDuration(t, lockedUntil, *actual.LockedUntil, time.Millisecond)
+
+		actual, err = repository.ClaimNext(ctx, []string{"vehicle_import"}, now, lockedUntil)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, newer.ID, actual.ID)
+
+		actual, err = repository.ClaimNext(ctx, []string{"vehicle_import"}, now, lockedUntil)
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should claim running jobs whose lock expired", func(t *testing.T) {
+		repository := NewJobRepository(testDatabase.New(t))
+
+		now := time.Now()
+
+		created, err := repository.Create(ctx, entity.Job{Type: "vehicle_import", Status: entity.JobStatusPending, RunAt: now.Add(-time.Minute)})
+		require.NoError(t, err)
+
+		_, err = repository.ClaimNext(ctx, []string{"vehicle_import"}, now, now.Add(time.Second))
+		require.NoError(t, err)
+
+		actual, err := repository.ClaimNext(ctx, []string{"vehicle_import"}, now.Add(time.Minute), now.Add(2*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, created.ID, actual.ID)
+		assert.Equal(t, 2, actual.Attempts)
+	})
+
+	t.Run("should extend the lock of running jobs only", func(t *testing.T) {
+		repository := NewJobRepository(testDatabase.New(t))
+
+		now := time.Now()
+
+		created, err := repository.Create(ctx, entity.Job{Type: "vehicle_import", Status: entity.JobStatusPending, RunAt: now.Add(-time.Minute)})
+		require.NoError(t, err)
+
+		_, err = repository.ClaimNext(ctx, []string{"vehicle_import"}, now, now.Add(time.Second))
+		require.NoError(t, err)
+
+		lockedUntil := now.Add(time.Minute)
+
+		actual, err := repository.Heartbeat(ctx, created.ID, lockedUntil)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.WithinDuration(t, lockedUntil, *actual.LockedUntil, time.Millisecond)
+
+		job := *actual
+		job.Status = entity.JobStatusCanceled
+
+		_, err = repository.Update(ctx, created.ID, job)
+		require.NoError(t, err)
+
+		actual, err = repository.Heartbeat(ctx, created.ID, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, entity.JobStatusCanceled, actual.Status)
+		assert.WithinDuration(t, lockedUntil, *actual.LockedUntil, time.Millisecond)
+	})
+}
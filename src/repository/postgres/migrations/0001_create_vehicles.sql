@@ -0,0 +1,20 @@
+CREATE TABLE vehicles (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    brand      text NOT NULL DEFAULT '',
+    model      text NOT NULL DEFAULT '',
+    year       integer NOT NULL DEFAULT 0,
+    color      text NOT NULL DEFAULT '',
+    price      numeric(14, 2) NOT NULL DEFAULT 0,
+    status     text NOT NULL DEFAULT '',
+    seller_id  text NOT NULL DEFAULT '',
+    sold_at    timestamptz,
+    version    bigint NOT NULL DEFAULT 1,
+    deleted_at timestamptz,
+    deleted_by text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+
+CREATE INDEX vehicles_price_idx ON vehicles (price);
+
+CREATE INDEX vehicles_deleted_at_idx ON vehicles (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,8 @@
+-- vehicle holds the vehicle as it was at version, as JSON.
+CREATE TABLE vehicle_versions (
+    vehicle_id  text NOT NULL,
+    version     bigint NOT NULL,
+    vehicle     jsonb NOT NULL,
+    recorded_at timestamptz NOT NULL,
+    PRIMARY KEY (vehicle_id, version)
+);
@@ -0,0 +1,17 @@
+-- vehicle_id has no foreign key: sales outlive purged vehicles and older
+-- sales refer to vehicles by ids of other backends.
+CREATE TABLE sales (
+    id                  uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    vehicle_id          text NOT NULL,
+    user_id             text NOT NULL DEFAULT '',
+    price               numeric(14, 2) NOT NULL DEFAULT 0,
+    trade_in_vehicle_id text NOT NULL DEFAULT '',
+    trade_in_credit     numeric(14, 2) NOT NULL DEFAULT 0,
+    net_price           numeric(14, 2) NOT NULL DEFAULT 0,
+    sold_at             timestamptz NOT NULL,
+    created_at          timestamptz NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX sales_created_at_idx ON sales (created_at, id);
+
+CREATE INDEX sales_sold_at_idx ON sales (sold_at);
@@ -0,0 +1,19 @@
+CREATE TABLE payments (
+    id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    vehicle_id    text NOT NULL DEFAULT '',
+    user_id       text NOT NULL DEFAULT '',
+    price         numeric(14, 2) NOT NULL DEFAULT 0,
+    trade_in      jsonb,
+    amount        numeric(14, 2) NOT NULL DEFAULT 0,
+    status        text NOT NULL DEFAULT '',
+    intent_id     text NOT NULL DEFAULT '',
+    client_secret text NOT NULL DEFAULT '',
+    sale_id       text NOT NULL DEFAULT '',
+    expires_at    timestamptz,
+    created_at    timestamptz NOT NULL,
+    updated_at    timestamptz NOT NULL
+);
+
+CREATE INDEX payments_intent_id_idx ON payments (intent_id);
+
+CREATE INDEX payments_pending_expires_at_idx ON payments (expires_at) WHERE status = 'pending';
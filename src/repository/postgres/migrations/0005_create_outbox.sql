@@ -0,0 +1,15 @@
+CREATE TABLE outbox (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    type         text NOT NULL,
+    entity_id    text NOT NULL DEFAULT '',
+    payload      text NOT NULL DEFAULT '',
+    request_id   text NOT NULL DEFAULT '',
+    occurred_at  timestamptz NOT NULL,
+    status       text NOT NULL,
+    attempts     integer NOT NULL DEFAULT 0,
+    last_error   text NOT NULL DEFAULT '',
+    available_at timestamptz NOT NULL,
+    published_at timestamptz
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (available_at, occurred_at, id) WHERE status = 'pending';
@@ -0,0 +1,16 @@
+-- Audit records are only ever inserted. created_at keeps the order records of
+-- the same instant were appended in.
+CREATE TABLE audit (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type text NOT NULL DEFAULT '',
+    entity_id   text NOT NULL,
+    action      text NOT NULL DEFAULT '',
+    actor_id    text NOT NULL DEFAULT '',
+    actor_role  text NOT NULL DEFAULT '',
+    request_id  text NOT NULL DEFAULT '',
+    changes     jsonb NOT NULL DEFAULT '[]',
+    occurred_at timestamptz NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX audit_entity_id_idx ON audit (entity_id, occurred_at, created_at);
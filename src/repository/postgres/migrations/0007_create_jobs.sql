@@ -0,0 +1,19 @@
+CREATE TABLE jobs (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    type         text NOT NULL,
+    payload      text NOT NULL DEFAULT '',
+    status       text NOT NULL,
+    attempts     integer NOT NULL DEFAULT 0,
+    max_attempts integer NOT NULL DEFAULT 0,
+    last_error   text NOT NULL DEFAULT '',
+    run_at       timestamptz NOT NULL,
+    locked_until timestamptz,
+    started_at   timestamptz,
+    finished_at  timestamptz,
+    created_at   timestamptz NOT NULL,
+    updated_at   timestamptz NOT NULL
+);
+
+CREATE INDEX jobs_pending_run_at_idx ON jobs (run_at) WHERE status = 'pending';
+
+CREATE INDEX jobs_running_locked_until_idx ON jobs (locked_until) WHERE status = 'running';
@@ -0,0 +1,15 @@
+CREATE TABLE import_jobs (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id      text NOT NULL DEFAULT '',
+    user_id     text NOT NULL DEFAULT '',
+    dry_run     boolean NOT NULL DEFAULT false,
+    status      text NOT NULL DEFAULT '',
+    total       integer NOT NULL DEFAULT 0,
+    valid       integer NOT NULL DEFAULT 0,
+    processed   integer NOT NULL DEFAULT 0,
+    imported    integer NOT NULL DEFAULT 0,
+    errors      jsonb NOT NULL DEFAULT '[]',
+    created_at  timestamptz NOT NULL,
+    updated_at  timestamptz NOT NULL,
+    finished_at timestamptz
+);
@@ -0,0 +1,14 @@
+-- Expired keys are deleted by the purge job; until then a reservation
+-- replaces them.
+CREATE TABLE idempotency_keys (
+    key          text PRIMARY KEY,
+    fingerprint  text NOT NULL DEFAULT '',
+    status       text NOT NULL,
+    status_code  integer NOT NULL DEFAULT 0,
+    content_type text NOT NULL DEFAULT '',
+    body         text NOT NULL DEFAULT '',
+    created_at   timestamptz NOT NULL,
+    expires_at   timestamptz NOT NULL
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
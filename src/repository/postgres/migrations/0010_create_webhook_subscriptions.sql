@@ -0,0 +1,10 @@
+CREATE TABLE webhook_subscriptions (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    url         text NOT NULL,
+    event_types text[] NOT NULL,
+    secret      text NOT NULL DEFAULT '',
+    created_at  timestamptz NOT NULL,
+    updated_at  timestamptz NOT NULL
+);
+
+CREATE INDEX webhook_subscriptions_event_types_idx ON webhook_subscriptions USING gin (event_types);
@@ -0,0 +1,23 @@
+-- subscription_id has no foreign key: deliveries outlive deleted
+-- subscriptions, like in MongoDB. Each event is delivered once per
+-- subscription.
+CREATE TABLE webhook_deliveries (
+    id               uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id  text NOT NULL,
+    event_id         text NOT NULL,
+    event_type       text NOT NULL DEFAULT '',
+    payload          text NOT NULL DEFAULT '',
+    status           text NOT NULL,
+    attempts         integer NOT NULL DEFAULT 0,
+    last_status_code integer NOT NULL DEFAULT 0,
+    last_error       text NOT NULL DEFAULT '',
+    next_attempt_at  timestamptz NOT NULL,
+    delivered_at     timestamptz,
+    created_at       timestamptz NOT NULL,
+    updated_at       timestamptz NOT NULL,
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_subscription_id_idx ON webhook_deliveries (subscription_id, created_at);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at, id) WHERE status = 'pending';
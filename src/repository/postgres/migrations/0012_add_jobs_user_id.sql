@@ -0,0 +1 @@
+ALTER TABLE jobs ADD COLUMN user_id text NOT NULL DEFAULT '';
@@ -0,0 +1,103 @@
+package outboxRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/rowID"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const columns = "id, type, entity_id, payload, request_id, occurred_at, status, attempts, last_error, available_at, published_at"
+
+type outboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) interfaces.OutboxRepository {
+	return &outboxRepository{
+		pool: pool,
+	}
+}
+
+func (ref *outboxRepository) Append(ctx context.Context, event entity.Event) error {
+	record := model.OutboxMessageFromDomain(event)
+
+	_, err := transactor.Get(ctx, ref.pool).Exec(ctx, `
+		INSERT INTO outbox (type, entity_id, payload, request_id, occurred_at, status, available_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		record.Type, record.EntityID, record.Payload, record.RequestID, record.OccurredAt, record.Status, record.AvailableAt,
+	)
+
+	return err
+}
+
+// ClaimPending skips the messages other relays are claiming at the same time,
+// so that concurrent relays never claim the same message.
+func (ref *outboxRepository) ClaimPending(ctx context.Context, limit int, now, lockedUntil time.Time) ([]entity.OutboxMessage, error) {
+	rows, err := transactor.Get(ctx, ref.pool).Query(ctx, `
+		WITH claimed AS (
+			UPDATE outbox SET available_at = $3, attempts = attempts + 1
+			WHERE id IN (
+				SELECT id FROM outbox
+				WHERE status = $1 AND available_at <= $2
+				ORDER BY occurred_at, id
+				LIMIT $4
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING `+columns+`
+		)
+		SELECT `+columns+` FROM claimed ORDER BY occurred_at, id`,
+		entity.OutboxStatusPending, now, lockedUntil, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := make([]entity.OutboxMessage, 0, limit)
+
+	for rows.Next() {
+		var record model.OutboxMessage
+
+		err = rows.Scan(
+			&record.ID, &record.Type, &record.EntityID, &record.Payload, &record.RequestID, &record.OccurredAt,
+			&record.Status, &record.Attempts, &record.LastError, &record.AvailableAt, &record.PublishedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, *record.ToDomain())
+	}
+
+	return messages, rows.Err()
+}
+
+func (ref *outboxRepository) MarkPublished(ctx context.Context, id string, publishedAt time.Time) error {
+	id = rowID.FromString(id)
+
+	_, err := transactor.Get(ctx, ref.pool).Exec(ctx, `
+		UPDATE outbox SET status = $2, published_at = $3, last_error = ''
+		WHERE id = $1`,
+		id, entity.OutboxStatusPublished, publishedAt,
+	)
+
+	return err
+}
+
+func (ref *outboxRepository) MarkFailed(ctx context.Context, id, lastError string, availableAt time.Time) error {
+	id = rowID.FromString(id)
+
+	_, err := transactor.Get(ctx, ref.pool).Exec(ctx, `
+		UPDATE outbox SET last_error = $2, available_at = $3
+		WHERE id = $1`,
+		id, lastError, availableAt,
+	)
+
+	return err
+}
@@ -0,0 +1,69 @@
+//go:build integration
+
+package outboxRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should claim pending messages oldest first until they are published", func(t *testing.T) {
+		repository := NewOutboxRepository(testDatabase.New(t))
+
+		now := time.Now()
+
+		newer := entity.Event{Type: "vehicle.created", EntityID: "vehicle-2", Payload: []byte(`{"id":"vehicle-2"}`), OccurredAt: now.Add(-time.Second)}
+		older := entity.Event{Type: "vehicle.created", EntityID: "vehicle-1", Payload: []byte(`{"id":"vehicle-1"}`), OccurredAt: now.Add(-time.Minute)}
+
+		require.NoError(t, repository.Append(ctx, newer))
+		require.NoError(t, repository.Append(ctx, older))
+
+		claimed, err := repository.ClaimPending(ctx, 10, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, claimed, 2)
+
+		assert.Equal(t, "vehicle-1", claimed[0].Event.EntityID)
+		assert.Equal(t, `{"id":"vehicle-1"}`, string(claimed[0].Event.Payload))
+		assert.Equal(t, 1, claimed[0].Attempts)
+		assert.Equal(t, "vehicle-2", claimed[1].Event.EntityID)
+
+		// Locked until a minute from now.
+		actual, err := repository.ClaimPending(ctx, 10, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+
+		require.NoError(t, repository.MarkPublished(ctx, claimed[0].Event.ID, now))
+		require.NoError(t, repository.MarkFailed(ctx, claimed[1].Event.ID, "unavailable", now))
+
+		actual, err = repository.ClaimPending(ctx, 10, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+
+		assert.Equal(t, "vehicle-2", actual[0].Event.EntityID)
+		assert.Equal(t, "unavailable", actual[0].LastError)
+		assert.Equal(t, 2, actual[0].Attempts)
+	})
+
+	t.Run("should claim at most limit messages", func(t *testing.T) {
+		repository := NewOutboxRepository(testDatabase.New(t))
+
+		now := time.Now()
+
+		for range 3 {
+			require.NoError(t, repository.Append(ctx, entity.Event{Type: "vehicle.created", OccurredAt: now.Add(-time.Second)}))
+		}
+
+		actual, err := repository.ClaimPending(ctx, 2, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Len(t, actual, 2)
+	})
+}
@@ -0,0 +1,161 @@
+package paymentRepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/rowID"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const columns = "id, vehicle_id, user_id, price, trade_in, amount, status, intent_id, client_secret, sale_id, expires_at, created_at, updated_at"
+
+type paymentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPaymentRepository(pool *pgxpool.Pool) interfaces.PaymentRepository {
+	return &paymentRepository{
+		pool: pool,
+	}
+}
+
+func (ref *paymentRepository) Create(ctx context.Context, payment entity.Payment) (*entity.Payment, error) {
+	record := model.PaymentFromDomain(payment)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		INSERT INTO payments (vehicle_id, user_id, price, trade_in, amount, status, intent_id, client_secret, sale_id, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+		RETURNING `+columns,
+		record.VehicleID, record.UserID, record.Price, record.TradeIn, record.Amount, record.Status,
+		record.IntentID, record.ClientSecret, record.SaleID, timestamp(record.ExpiresAt), time.Now(),
+	)
+
+	return scan(row)
+}
+
+func (ref *paymentRepository) GetByID(ctx context.Context, id string) (*entity.Payment, error) {
+	id = rowID.FromString(id)
+
+	return ref.findOne(ctx, "id = $1", id)
+}
+
+func (ref *paymentRepository) GetByIntentID(ctx context.Context, intentID string) (*entity.Payment, error) {
+	return ref.findOne(ctx, "intent_id = $1", intentID)
+}
+
+func (ref *paymentRepository) SearchExpired(ctx context.Context, before time.Time) ([]entity.Payment, error) {
+	rows, err := transactor.Get(ctx, ref.pool).Query(ctx, `
+		SELECT `+columns+` FROM payments
+		WHERE status = $1 AND expires_at < $2`,
+		entity.PaymentStatusPending, before,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := make([]entity.Payment, 0)
+
+	for rows.Next() {
+		payment, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, *payment)
+	}
+
+	return payments, rows.Err()
+}
+
+// Update sets the non-zero fields of payment, like the MongoDB repository.
+func (ref *paymentRepository) Update(ctx context.Context, id string, payment entity.Payment) (*entity.Payment, error) {
+	return ref.update(ctx, id, payment, nil)
+}
+
+func (ref *paymentRepository) UpdatePending(ctx context.Context, id string, payment entity.Payment) (*entity.Payment, error) {
+	status := entity.PaymentStatusPending
+
+	return ref.update(ctx, id, payment, &status)
+}
+
+// update changes the payment only while it has status, when status is set.
+func (ref *paymentRepository) update(ctx context.Context, id string, payment entity.Payment, status *string) (*entity.Payment, error) {
+	id = rowID.FromString(id)
+
+	record := model.PaymentFromDomain(payment)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		UPDATE payments SET
+			vehicle_id = COALESCE(NULLIF($2::text, ''), vehicle_id),
+			user_id = COALESCE(NULLIF($3::text, ''), user_id),
+			price = COALESCE(NULLIF($4::numeric, 0), price),
+			trade_in = COALESCE($5::jsonb, trade_in),
+			amount = COALESCE(NULLIF($6::numeric, 0), amount),
+			status = COALESCE(NULLIF($7::text, ''), status),
+			intent_id = COALESCE(NULLIF($8::text, ''), intent_id),
+			client_secret = COALESCE(NULLIF($9::text, ''), client_secret),
+			sale_id = COALESCE(NULLIF($10::text, ''), sale_id),
+			expires_at = COALESCE($11::timestamptz, expires_at),
+			updated_at = $12
+		WHERE id = $1 AND ($13::text IS NULL OR status = $13)
+		RETURNING `+columns,
+		id, record.VehicleID, record.UserID, record.Price, record.TradeIn, record.Amount, record.Status,
+		record.IntentID, record.ClientSecret, record.SaleID, timestamp(record.ExpiresAt), time.Now(), status,
+	)
+
+	updated, err := scan(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return updated, err
+}
+
+func (ref *paymentRepository) findOne(ctx context.Context, condition string, argument any) (*entity.Payment, error) {
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, "SELECT "+columns+" FROM payments WHERE "+condition+" LIMIT 1", argument)
+
+	payment, err := scan(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return payment, err
+}
+
+func scan(row pgx.Row) (*entity.Payment, error) {
+	var (
+		record    model.Payment
+		expiresAt *time.Time
+	)
+
+	err := row.Scan(
+		&record.ID, &record.VehicleID, &record.UserID, &record.Price, &record.TradeIn, &record.Amount, &record.Status,
+		&record.IntentID, &record.ClientSecret, &record.SaleID, &expiresAt, &record.CreatedAt, &record.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt != nil {
+		record.ExpiresAt = *expiresAt
+	}
+
+	return record.ToDomain(), nil
+}
+
+// timestamp stores a zero time as NULL, the way MongoDB leaves it out.
+func timestamp(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
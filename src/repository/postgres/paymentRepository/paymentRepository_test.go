@@ -0,0 +1,100 @@
+//go:build integration
+
+package paymentRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should create and find payment", func(t *testing.T) {
+		repository := NewPaymentRepository(testDatabase.New(t))
+
+		created, err := repository.Create(ctx, entity.Payment{
+			VehicleID: "vehicle-1",
+			UserID:    "user-1",
+			Price:     50000,
+			TradeIn:   &entity.TradeIn{Brand: "Fiat", Model: "Uno", Year: 2010, Color: "Branco", Valuation: 10000},
+			Amount:    40000,
+			Status:    entity.PaymentStatusPending,
+			IntentID:  "intent-1",
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, created)
+
+		assert.NotEmpty(t, created.ID)
+		assert.Equal(t, 10000.0, created.TradeIn.Valuation)
+
+		actual, err := repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created, actual)
+
+		actual, err = repository.GetByIntentID(ctx, "intent-1")
+		require.NoError(t, err)
+		assert.Equal(t, created, actual)
+
+		actual, err = repository.GetByIntentID(ctx, "intent-2")
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should update only the given fields", func(t *testing.T) {
+		repository := NewPaymentRepository(testDatabase.New(t))
+
+		created, err := repository.Create(ctx, entity.Payment{VehicleID: "vehicle-1", Amount: 50000, Status: entity.PaymentStatusPending})
+		require.NoError(t, err)
+
+		actual, err := repository.Update(ctx, created.ID, entity.Payment{Status: entity.PaymentStatusSucceeded, SaleID: "sale-1"})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, entity.PaymentStatusSucceeded, actual.Status)
+		assert.Equal(t, "sale-1", actual.SaleID)
+		assert.Equal(t, "vehicle-1", actual.VehicleID)
+		assert.Equal(t, 50000.0, actual.Amount)
+		assert.Nil(t, actual.TradeIn)
+	})
+
+	t.Run("should update only pending payments", func(t *testing.T) {
+		repository := NewPaymentRepository(testDatabase.New(t))
+
+		created, err := repository.Create(ctx, entity.Payment{VehicleID: "vehicle-1", Status: entity.PaymentStatusPending})
+		require.NoError(t, err)
+
+		actual, err := repository.UpdatePending(ctx, created.ID, entity.Payment{Status: entity.PaymentStatusSucceeded})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, entity.PaymentStatusSucceeded, actual.Status)
+
+		actual, err = repository.UpdatePending(ctx, created.ID, entity.Payment{Status: entity.PaymentStatusFailed})
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should search pending payments expired before the given time", func(t *testing.T) {
+		repository := NewPaymentRepository(testDatabase.New(t))
+
+		expired, err := repository.Create(ctx, entity.Payment{Status: entity.PaymentStatusPending, ExpiresAt: time.Now().Add(-time.Minute)})
+		require.NoError(t, err)
+
+		_, err = repository.Create(ctx, entity.Payment{Status: entity.PaymentStatusPending, ExpiresAt: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+
+		_, err = repository.Create(ctx, entity.Payment{Status: entity.PaymentStatusSucceeded, ExpiresAt: time.Now().Add(-time.Minute)})
+		require.NoError(t, err)
+
+		actual, err := repository.SearchExpired(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, []entity.Payment{*expired}, actual)
+	})
+}
@@ -0,0 +1,16 @@
+// Package rowID converts the ids given to the PostgreSQL repositories into the
+// UUIDs of their rows.
+package rowID
+
+import "github.com/google/uuid"
+
+// FromString returns id when it is a UUID. A malformed id is converted to the
+// nil UUID, which no row is given, so that it matches nothing and is reported
+// as not found like an unknown id instead of being rejected by the server.
+func FromString(id string) string {
+	if uuid.Validate(id) != nil {
+		return uuid.Nil.String()
+	}
+
+	return id
+}
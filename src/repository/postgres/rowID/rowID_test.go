@@ -0,0 +1,24 @@
+package rowID
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromString(t *testing.T) {
+	t.Run("should keep well formed id", func(t *testing.T) {
+		expected := uuid.NewString()
+
+		actual := FromString(expected)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should convert malformed id to nil uuid", func(t *testing.T) {
+		actual := FromString("not-an-id")
+
+		assert.Equal(t, uuid.Nil.String(), actual)
+	})
+}
@@ -0,0 +1,145 @@
+package saleRepository
+
+import (
+	"context"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const columns = "id, vehicle_id, user_id, price, trade_in_vehicle_id, trade_in_credit, net_price, sold_at"
+
+type saleRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewSaleRepository(pool *pgxpool.Pool) interfaces.SaleRepository {
+	return &saleRepository{
+		pool: pool,
+	}
+}
+
+func (ref *saleRepository) Create(ctx context.Context, sale entity.Sale) (*entity.Sale, error) {
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		INSERT INTO sales (vehicle_id, user_id, price, trade_in_vehicle_id, trade_in_credit, net_price, sold_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+columns,
+		sale.VehicleID, sale.UserID, sale.Price, sale.TradeInVehicleID, sale.TradeInCredit, sale.NetPrice, sale.SoldAt,
+	)
+
+	return scan(row)
+}
+
+func (ref *saleRepository) Search(ctx context.Context) ([]entity.Sale, error) {
+	sales := make([]entity.Sale, 0)
+
+	err := ref.Iterate(ctx, func(sale entity.Sale) error {
+		sales = append(sales, sale)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sales, nil
+}
+
+// Iterate goes through the sales in the order they were created.
+func (ref *saleRepository) Iterate(ctx context.Context, fn func(entity.Sale) error) error {
+	rows, err := transactor.Get(ctx, ref.pool).Query(ctx, "SELECT "+columns+" FROM sales ORDER BY created_at, id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		sale, err := scan(rows)
+		if err != nil {
+			return err
+		}
+
+		if err = fn(*sale); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Report groups the sales like the MongoDB repository: sales of vehicles that
+// do not exist are counted with empty vehicle fields and left out of the
+// average days on market, and keys are sorted byte by byte.
+func (ref *saleRepository) Report(ctx context.Context, filter entity.SalesReportFilter) ([]entity.SalesReportGroup, error) {
+	rows, err := transactor.Get(ctx, ref.pool).Query(ctx, `
+		SELECT
+			(`+reportGroupKey(filter.GroupBy)+`) COLLATE "C" AS key,
+			count(*),
+			COALESCE(sum(s.price - s.trade_in_credit), 0),
+			COALESCE(avg(s.price - s.trade_in_credit), 0),
+			COALESCE(avg(extract(epoch FROM s.sold_at - v.created_at) / 86400), 0)
+		FROM sales s
+		LEFT JOIN vehicles v ON v.id::text = s.vehicle_id
+		WHERE ($1::timestamptz IS NULL OR s.sold_at >= $1)
+		AND ($2::timestamptz IS NULL OR s.sold_at < $2)
+		GROUP BY key
+		ORDER BY key`,
+		filter.From, filter.To,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]entity.SalesReportGroup, 0)
+
+	for rows.Next() {
+		var group entity.SalesReportGroup
+
+		err = rows.Scan(&group.Key, &group.Count, &group.Revenue, &group.AverageTicket, &group.AverageDaysOnMarket)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, rows.Err()
+}
+
+// reportGroupKey returns the SQL expression of the key the sales are grouped
+// by, formatting dates in UTC.
+func reportGroupKey(groupBy string) string {
+	switch groupBy {
+	case entity.SalesReportGroupByDay:
+		return `to_char(s.sold_at AT TIME ZONE 'UTC', 'YYYY-MM-DD')`
+	case entity.SalesReportGroupByWeek:
+		return `to_char(s.sold_at AT TIME ZONE 'UTC', 'IYYY-"W"IW')`
+	case entity.SalesReportGroupByMonth:
+		return `to_char(s.sold_at AT TIME ZONE 'UTC', 'YYYY-MM')`
+	case entity.SalesReportGroupByBrand:
+		return `COALESCE(v.brand, '')`
+	case entity.SalesReportGroupByModel:
+		return `COALESCE(v.brand, '') || ' ' || COALESCE(v.model, '')`
+	case entity.SalesReportGroupBySeller:
+		return `COALESCE(v.seller_id, '')`
+	default:
+		return `''`
+	}
+}
+
+func scan(row pgx.Row) (*entity.Sale, error) {
+	var sale entity.Sale
+
+	err := row.Scan(
+		&sale.ID, &sale.VehicleID, &sale.UserID, &sale.Price,
+		&sale.TradeInVehicleID, &sale.TradeInCredit, &sale.NetPrice, &sale.SoldAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sale, nil
+}
@@ -0,0 +1,108 @@
+//go:build integration
+
+package saleRepository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/conformance"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/vehicleRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaleRepository(t *testing.T) {
+	conformance.SaleRepository(t, func(t *testing.T) (interfaces.VehicleRepository, interfaces.SaleRepository) {
+		pool := testDatabase.New(t)
+
+		return vehicleRepository.NewVehicleRepository(pool), NewSaleRepository(pool)
+	})
+}
+
+func TestPurchaseTransaction(t *testing.T) {
+	ctx := context.TODO()
+
+	// purchase sells the vehicle like the payment service does.
+	purchase := func(ctx context.Context, vehicles interfaces.VehicleRepository, sales interfaces.SaleRepository, vehicle *entity.Vehicle) error {
+		soldAt := time.Now()
+
+		if _, err := sales.Create(ctx, entity.Sale{VehicleID: vehicle.ID, UserID: "user-1", Price: vehicle.Price, SoldAt: soldAt}); err != nil {
+			return err
+		}
+
+		_, err := vehicles.Update(ctx, vehicle.ID, entity.Vehicle{Status: entity.VehicleStatusSold, SoldAt: &soldAt, Version: vehicle.Version})
+		return err
+	}
+
+	t.Run("should commit the sale and the sold vehicle together", func(t *testing.T) {
+		pool := testDatabase.New(t)
+		vehicles, sales := vehicleRepository.NewVehicleRepository(pool), NewSaleRepository(pool)
+
+		vehicle, err := vehicles.Create(ctx, entity.Vehicle{Brand: "Ford", Price: 50000, Status: entity.VehicleStatusReserved})
+		require.NoError(t, err)
+
+		err = transactor.NewTransactor(pool).WithinTransaction(ctx, func(ctx context.Context) error {
+			return purchase(ctx, vehicles, sales, vehicle)
+		})
+		require.NoError(t, err)
+
+		sold, err := vehicles.GetByID(ctx, vehicle.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.VehicleStatusSold, sold.Status)
+		assert.NotNil(t, sold.SoldAt)
+
+		actual, err := sales.Search(ctx)
+		require.NoError(t, err)
+		assert.Len(t, actual, 1)
+	})
+
+	t.Run("should roll back the sale when the vehicle changed meanwhile", func(t *testing.T) {
+		pool := testDatabase.New(t)
+		vehicles, sales := vehicleRepository.NewVehicleRepository(pool), NewSaleRepository(pool)
+
+		vehicle, err := vehicles.Create(ctx, entity.Vehicle{Brand: "Ford", Price: 50000, Status: entity.VehicleStatusReserved})
+		require.NoError(t, err)
+
+		_, err = vehicles.Update(ctx, vehicle.ID, entity.Vehicle{Price: 45000})
+		require.NoError(t, err)
+
+		err = transactor.NewTransactor(pool).WithinTransaction(ctx, func(ctx context.Context) error {
+			return purchase(ctx, vehicles, sales, vehicle)
+		})
+		assert.ErrorIs(t, err, entity.ErrVehicleVersionMismatch)
+
+		actual, err := sales.Search(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+
+	t.Run("should join the transaction it is called in", func(t *testing.T) {
+		pool := testDatabase.New(t)
+		vehicles := vehicleRepository.NewVehicleRepository(pool)
+		transactor := transactor.NewTransactor(pool)
+
+		failure := errors.New("failure")
+
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				_, err := vehicles.Create(ctx, entity.Vehicle{Brand: "Ford", Price: 50000})
+				return err
+			})
+			require.NoError(t, err)
+
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		actual, err := vehicles.Search(ctx, entity.VehicleFilter{IncludeDeleted: true})
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
@@ -0,0 +1,90 @@
+// Package testDatabase gives tests a PostgreSQL schema of their own, so that
+// the repositories can be tested against a real server.
+package testDatabase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/connection"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/migrations"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const connectTimeout = 30 * time.Second
+
+// New returns a pool whose connections use a new, migrated schema that is
+// dropped when the test ends. It uses the server in POSTGRES_TEST_URL, for
+// instance one started with docker compose, and skips the test when it is not
+// set.
+func New(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	url := os.Getenv("POSTGRES_TEST_URL")
+	if url == "" {
+		t.Skip("POSTGRES_TEST_URL is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	schema := "test_" + randomHex(t)
+
+	conn, err := pgx.Connect(ctx, url)
+	if err != nil {
+		t.Fatalf("failed to connect to postgres: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	if _, err = conn.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("failed to create schema %s: %v", schema, err)
+	}
+
+	t.Cleanup(func() {
+		conn, err := pgx.Connect(context.Background(), url)
+		if err != nil {
+			return
+		}
+		defer conn.Close(context.Background())
+
+		_, _ = conn.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+	})
+
+	config, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatalf("failed to parse POSTGRES_TEST_URL: %v", err)
+	}
+
+	connection.Configure(config)
+
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	t.Cleanup(pool.Close)
+
+	if err = migrations.Migrate(ctx, pool); err != nil {
+		t.Fatalf("failed to migrate schema %s: %v", schema, err)
+	}
+
+	return pool
+}
+
+func randomHex(t *testing.T) string {
+	t.Helper()
+
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		t.Fatalf("failed to generate schema name: %v", err)
+	}
+
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,54 @@
+package transactor
+
+import (
+	"context"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type transactionKey struct{}
+
+// Querier is what repositories run their statements on: the pool, or the
+// transaction they were called in.
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+}
+
+// transactor runs functions in PostgreSQL transactions. The transaction is
+// carried in the context, like MongoDB sessions, so repositories join it
+// through Get without knowing about it.
+type transactor struct {
+	pool *pgxpool.Pool
+}
+
+func NewTransactor(pool *pgxpool.Pool) interfaces.Transactor {
+	return &transactor{
+		pool: pool,
+	}
+}
+
+// WithinTransaction commits when fn succeeds and rolls back otherwise. Called
+// within a transaction, fn joins it.
+func (ref *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(transactionKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	return pgx.BeginFunc(ctx, ref.pool, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, transactionKey{}, tx))
+	})
+}
+
+// Get returns the transaction ctx was started in, or pool outside of one.
+func Get(ctx context.Context, pool *pgxpool.Pool) Querier {
+	if tx, ok := ctx.Value(transactionKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return pool
+}
@@ -0,0 +1,215 @@
+package vehicleRepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/rowID"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const columns = "id, brand, model, year, color, price, status, seller_id, sold_at, version, deleted_at, deleted_by, created_at, updated_at"
+
+type vehicleRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewVehicleRepository(pool *pgxpool.Pool) interfaces.VehicleRepository {
+	return &vehicleRepository{
+		pool: pool,
+	}
+}
+
+func (ref *vehicleRepository) Create(ctx context.Context, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	createdAt := now()
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		INSERT INTO vehicles (brand, model, year, color, price, status, seller_id, sold_at, deleted_at, deleted_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+		RETURNING `+columns,
+		vehicle.Brand, vehicle.Model, vehicle.Year, vehicle.Color, vehicle.Price, vehicle.Status,
+		vehicle.SellerID, vehicle.SoldAt, vehicle.DeletedAt, vehicle.DeletedBy, createdAt,
+	)
+
+	return scan(row)
+}
+
+func (ref *vehicleRepository) GetByID(ctx context.Context, id string) (*entity.Vehicle, error) {
+	id = rowID.FromString(id)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, "SELECT "+columns+" FROM vehicles WHERE id = $1", id)
+
+	vehicle, err := scan(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return vehicle, err
+}
+
+func (ref *vehicleRepository) Search(ctx context.Context, vehicleFilter entity.VehicleFilter) ([]entity.Vehicle, error) {
+	vehicles := make([]entity.Vehicle, 0)
+
+	err := ref.Iterate(ctx, vehicleFilter, func(vehicle entity.Vehicle) error {
+		vehicles = append(vehicles, vehicle)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return vehicles, nil
+}
+
+func (ref *vehicleRepository) Iterate(ctx context.Context, vehicleFilter entity.VehicleFilter, fn func(entity.Vehicle) error) error {
+	rows, err := transactor.Get(ctx, ref.pool).Query(ctx, `
+		SELECT `+columns+` FROM vehicles
+		WHERE ($1::boolean IS NULL OR (sold_at IS NOT NULL) = $1)
+		AND ($2::boolean OR deleted_at IS NULL)
+		ORDER BY price, created_at`,
+		vehicleFilter.IsSold, vehicleFilter.IncludeDeleted,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		vehicle, err := scan(rows)
+		if err != nil {
+			return err
+		}
+
+		if err = fn(*vehicle); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Update sets the non-zero fields of vehicle and increments the version. When
+// vehicle.Version is set, the vehicle is only updated if it is still at that
+// version.
+func (ref *vehicleRepository) Update(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	return ref.update(ctx, id, vehicle.Version, `
+		brand = COALESCE(NULLIF($3::text, ''), brand),
+		model = COALESCE(NULLIF($4::text, ''), model),
+		year = COALESCE(NULLIF($5::integer, 0), year),
+		color = COALESCE(NULLIF($6::text, ''), color),
+		price = COALESCE(NULLIF($7::numeric, 0), price),
+		status = COALESCE(NULLIF($8::text, ''), status),
+		seller_id = COALESCE(NULLIF($9::text, ''), seller_id),
+		sold_at = COALESCE($10::timestamptz, sold_at),
+		deleted_at = COALESCE($11::timestamptz, deleted_at),
+		deleted_by = COALESCE(NULLIF($12::text, ''), deleted_by),
+		updated_at = $13`,
+		vehicle.Brand, vehicle.Model, vehicle.Year, vehicle.Color, vehicle.Price, vehicle.Status,
+		vehicle.SellerID, vehicle.SoldAt, vehicle.DeletedAt, vehicle.DeletedBy, now(),
+	)
+}
+
+// Replace is conditional on vehicle.Version like Update.
+func (ref *vehicleRepository) Replace(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	return ref.update(ctx, id, vehicle.Version, `
+		brand = $3,
+		model = $4,
+		year = $5,
+		color = $6,
+		price = $7,
+		status = $8,
+		updated_at = $9`,
+		vehicle.Brand, vehicle.Model, vehicle.Year, vehicle.Color, vehicle.Price, vehicle.Status, now(),
+	)
+}
+
+func (ref *vehicleRepository) Delete(ctx context.Context, id string, version int64, deletedBy string) (*entity.Vehicle, error) {
+	return ref.update(ctx, id, version, `
+		deleted_at = $3,
+		deleted_by = $4,
+		updated_at = $3`,
+		now(), deletedBy,
+	)
+}
+
+func (ref *vehicleRepository) Restore(ctx context.Context, id string, version int64) (*entity.Vehicle, error) {
+	return ref.update(ctx, id, version, `
+		deleted_at = NULL,
+		deleted_by = '',
+		updated_at = $3`,
+		now(),
+	)
+}
+
+func (ref *vehicleRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	deleted, err := transactor.Get(ctx, ref.pool).Exec(ctx, "DELETE FROM vehicles WHERE deleted_at < $1", deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted.RowsAffected()), nil
+}
+
+// update applies the assignments in set, whose arguments start at $3, and
+// increments the version.
+func (ref *vehicleRepository) update(ctx context.Context, id string, version int64, set string, arguments ...any) (*entity.Vehicle, error) {
+	id = rowID.FromString(id)
+
+	querier := transactor.Get(ctx, ref.pool)
+
+	row := querier.QueryRow(ctx, `
+		UPDATE vehicles SET `+set+`, version = version + 1
+		WHERE id = $1 AND ($2::bigint = 0 OR version = $2)
+		RETURNING `+columns,
+		append([]any{id, version}, arguments...)...,
+	)
+
+	vehicle, err := scan(row)
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return vehicle, err
+	}
+
+	if version == 0 {
+		return nil, nil
+	}
+
+	var exists bool
+
+	err = querier.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM vehicles WHERE id = $1)", id).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, entity.ErrVehicleVersionMismatch
+	}
+
+	return nil, nil
+}
+
+func scan(row pgx.Row) (*entity.Vehicle, error) {
+	var record model.Vehicle
+
+	err := row.Scan(
+		&record.ID, &record.Brand, &record.Model, &record.Year, &record.Color, &record.Price, &record.Status,
+		&record.SellerID, &record.SoldAt, &record.Version, &record.DeletedAt, &record.DeletedBy,
+		&record.CreatedAt, &record.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+// now is rounded to milliseconds, the precision MongoDB and the memory
+// repository store, so the backends order and compare timestamps alike.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
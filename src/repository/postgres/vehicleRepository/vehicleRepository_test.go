@@ -0,0 +1,17 @@
+//go:build integration
+
+package vehicleRepository
+
+import (
+	"testing"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/conformance"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+)
+
+func TestVehicleRepository(t *testing.T) {
+	conformance.VehicleRepository(t, func(t *testing.T) interfaces.VehicleRepository {
+		return NewVehicleRepository(testDatabase.New(t))
+	})
+}
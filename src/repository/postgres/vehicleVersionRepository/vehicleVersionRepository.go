@@ -0,0 +1,90 @@
+package vehicleVersionRepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// vehicleVersionRepository only ever inserts, like the audit repository.
+type vehicleVersionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewVehicleVersionRepository(pool *pgxpool.Pool) interfaces.VehicleVersionRepository {
+	return &vehicleVersionRepository{
+		pool: pool,
+	}
+}
+
+func (ref *vehicleVersionRepository) Append(ctx context.Context, version entity.VehicleVersion) error {
+	record := model.VehicleVersionFromDomain(version)
+
+	_, err := transactor.Get(ctx, ref.pool).Exec(ctx, `
+		INSERT INTO vehicle_versions (vehicle_id, version, vehicle, recorded_at)
+		VALUES ($1, $2, $3, $4)`,
+		record.VehicleID, record.Version, record.Vehicle, record.RecordedAt,
+	)
+
+	return err
+}
+
+func (ref *vehicleVersionRepository) Search(ctx context.Context, vehicleID string) ([]entity.VehicleVersion, error) {
+	rows, err := transactor.Get(ctx, ref.pool).Query(ctx, `
+		SELECT vehicle, recorded_at FROM vehicle_versions
+		WHERE vehicle_id = $1
+		ORDER BY version`,
+		vehicleID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make([]entity.VehicleVersion, 0)
+
+	for rows.Next() {
+		version, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, *version)
+	}
+
+	return versions, rows.Err()
+}
+
+func (ref *vehicleVersionRepository) GetAsOf(ctx context.Context, vehicleID string, asOf time.Time) (*entity.VehicleVersion, error) {
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		SELECT vehicle, recorded_at FROM vehicle_versions
+		WHERE vehicle_id = $1 AND recorded_at <= $2
+		ORDER BY version DESC
+		LIMIT 1`,
+		vehicleID, asOf,
+	)
+
+	version, err := scan(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return version, err
+}
+
+func scan(row pgx.Row) (*entity.VehicleVersion, error) {
+	var record model.VehicleVersion
+
+	if err := row.Scan(&record.Vehicle, &record.RecordedAt); err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
@@ -0,0 +1,57 @@
+//go:build integration
+
+package vehicleVersionRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVehicleVersionRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	repository := NewVehicleVersionRepository(testDatabase.New(t))
+
+	createdAt := time.Now().UTC().Add(-time.Hour)
+
+	first := entity.VehicleVersion{
+		Vehicle:    entity.Vehicle{ID: "vehicle-1", Brand: "Ford", Price: 50000, Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+		RecordedAt: createdAt,
+	}
+
+	second := entity.VehicleVersion{
+		Vehicle:    entity.Vehicle{ID: "vehicle-1", Brand: "Ford", Price: 45000, Version: 2, CreatedAt: createdAt, UpdatedAt: createdAt.Add(time.Minute)},
+		RecordedAt: createdAt.Add(time.Minute),
+	}
+
+	require.NoError(t, repository.Append(ctx, second))
+	require.NoError(t, repository.Append(ctx, first))
+	require.NoError(t, repository.Append(ctx, entity.VehicleVersion{Vehicle: entity.Vehicle{ID: "vehicle-2", Version: 1}, RecordedAt: createdAt}))
+
+	t.Run("should search versions from the oldest", func(t *testing.T) {
+		actual, err := repository.Search(ctx, "vehicle-1")
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+
+		assert.Equal(t, 50000.0, actual[0].Vehicle.Price)
+		assert.Equal(t, 45000.0, actual[1].Vehicle.Price)
+		assert.WithinDuration(t, second.RecordedAt, actual[1].RecordedAt, time.Millisecond)
+	})
+
+	t.Run("should get the version recorded as of the given time", func(t *testing.T) {
+		actual, err := repository.GetAsOf(ctx, "vehicle-1", createdAt.Add(30*time.Second))
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, int64(1), actual.Vehicle.Version)
+
+		actual, err = repository.GetAsOf(ctx, "vehicle-1", createdAt.Add(-time.Second))
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+}
@@ -0,0 +1,167 @@
+package webhookDeliveryRepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/rowID"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const columns = "id, subscription_id, event_id, event_type, payload, status, attempts, last_status_code, last_error, next_attempt_at, delivered_at, created_at, updated_at"
+
+type webhookDeliveryRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookDeliveryRepository(pool *pgxpool.Pool) interfaces.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		pool: pool,
+	}
+}
+
+// Enqueue relies on the unique subscription and event, so that the delivery
+// is only inserted the first time, even by concurrent calls.
+func (ref *webhookDeliveryRepository) Enqueue(ctx context.Context, delivery entity.WebhookDelivery) error {
+	record := model.WebhookDeliveryFromDomain(delivery)
+
+	_, err := transactor.Get(ctx, ref.pool).Exec(ctx, `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, attempts, last_status_code, last_error, next_attempt_at, delivered_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`,
+		record.SubscriptionID, record.EventID, record.EventType, record.Payload, record.Status, record.Attempts,
+		record.LastStatusCode, record.LastError, record.NextAttemptAt, record.DeliveredAt, time.Now(),
+	)
+
+	return err
+}
+
+func (ref *webhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	id = rowID.FromString(id)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, "SELECT "+columns+" FROM webhook_deliveries WHERE id = $1", id)
+
+	return scanOne(row)
+}
+
+func (ref *webhookDeliveryRepository) SearchBySubscriptionID(ctx context.Context, subscriptionID string) ([]entity.WebhookDelivery, error) {
+	rows, err := transactor.Get(ctx, ref.pool).Query(ctx, `
+		SELECT `+columns+` FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC, id DESC`,
+		subscriptionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+
+	for rows.Next() {
+		delivery, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// ClaimPending skips the deliveries other workers are claiming at the same
+// time, so that concurrent workers never claim the same delivery.
+func (ref *webhookDeliveryRepository) ClaimPending(ctx context.Context, limit int, now, lockedUntil time.Time) ([]entity.WebhookDelivery, error) {
+	rows, err := transactor.Get(ctx, ref.pool).Query(ctx, `
+		WITH claimed AS (
+			UPDATE webhook_deliveries SET next_attempt_at = $3, attempts = attempts + 1, updated_at = $2
+			WHERE id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE status = $1 AND next_attempt_at <= $2
+				ORDER BY next_attempt_at, id
+				LIMIT $4
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING `+columns+`
+		)
+		SELECT `+columns+` FROM claimed ORDER BY created_at, id`,
+		entity.WebhookDeliveryStatusPending, now, lockedUntil, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]entity.WebhookDelivery, 0, limit)
+
+	for rows.Next() {
+		delivery, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// Update replaces the delivery, keeping only its id and creation time.
+func (ref *webhookDeliveryRepository) Update(ctx context.Context, id string, delivery entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	id = rowID.FromString(id)
+
+	record := model.WebhookDeliveryFromDomain(delivery)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		UPDATE webhook_deliveries SET
+			subscription_id = $2,
+			event_id = $3,
+			event_type = $4,
+			payload = $5,
+			status = $6,
+			attempts = $7,
+			last_status_code = $8,
+			last_error = $9,
+			next_attempt_at = $10,
+			delivered_at = $11,
+			updated_at = $12
+		WHERE id = $1
+		RETURNING `+columns,
+		id, record.SubscriptionID, record.EventID, record.EventType, record.Payload, record.Status, record.Attempts,
+		record.LastStatusCode, record.LastError, record.NextAttemptAt, record.DeliveredAt, time.Now(),
+	)
+
+	return scanOne(row)
+}
+
+// scanOne is scan for queries that may match no delivery.
+func scanOne(row pgx.Row) (*entity.WebhookDelivery, error) {
+	delivery, err := scan(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return delivery, err
+}
+
+func scan(row pgx.Row) (*entity.WebhookDelivery, error) {
+	var record model.WebhookDelivery
+
+	err := row.Scan(
+		&record.ID, &record.SubscriptionID, &record.EventID, &record.EventType, &record.Payload, &record.Status,
+		&record.Attempts, &record.LastStatusCode, &record.LastError, &record.NextAttemptAt, &record.DeliveredAt,
+		&record.CreatedAt, &record.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
@@ -0,0 +1,141 @@
+//go:build integration
+
+package webhookDeliveryRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliveryRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should enqueue each event once per subscription", func(t *testing.T) {
+		repository := NewWebhookDeliveryRepository(testDatabase.New(t))
+
+		delivery := entity.WebhookDelivery{
+			SubscriptionID: "subscription-1",
+			EventID:        "event-1",
+			EventType:      "VehicleCreated",
+			Payload:        []byte(`{"id":"vehicle-1"}`),
+			Status:         entity.WebhookDeliveryStatusPending,
+			NextAttemptAt:  time.Now(),
+		}
+
+		require.NoError(t, repository.Enqueue(ctx, delivery))
+		require.NoError(t, repository.Enqueue(ctx, delivery))
+
+		other := delivery
+		other.SubscriptionID = "subscription-2"
+
+		require.NoError(t, repository.Enqueue(ctx, other))
+
+		actual, err := repository.SearchBySubscriptionID(ctx, "subscription-1")
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+
+		assert.NotEmpty(t, actual[0].ID)
+		assert.Equal(t, "event-1", actual[0].EventID)
+		assert.Equal(t, `{"id":"vehicle-1"}`, string(actual[0].Payload))
+
+		found, err := repository.GetByID(ctx, actual[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, &actual[0], found)
+	})
+
+	t.Run("should search deliveries of the subscription newest first", func(t *testing.T) {
+		repository := NewWebhookDeliveryRepository(testDatabase.New(t))
+
+		for _, eventID := range []string{"event-1", "event-2"} {
+			require.NoError(t, repository.Enqueue(ctx, entity.WebhookDelivery{
+				SubscriptionID: "subscription-1",
+				EventID:        eventID,
+				Status:         entity.WebhookDeliveryStatusPending,
+				NextAttemptAt:  time.Now(),
+			}))
+		}
+
+		actual, err := repository.SearchBySubscriptionID(ctx, "subscription-1")
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+
+		assert.Equal(t, "event-2", actual[0].EventID)
+		assert.Equal(t, "event-1", actual[1].EventID)
+	})
+
+	t.Run("should claim due pending deliveries until they are updated", func(t *testing.T) {
+		repository := NewWebhookDeliveryRepository(testDatabase.New(t))
+
+		now := time.Now()
+
+		for _, delivery := range []entity.WebhookDelivery{
+			{EventID: "event-3", NextAttemptAt: now.Add(time.Minute)},
+			{EventID: "event-2", NextAttemptAt: now.Add(-time.Second)},
+			{EventID: "event-1", NextAttemptAt: now.Add(-time.Minute)},
+		} {
+			delivery.SubscriptionID = "subscription-1"
+			delivery.Status = entity.WebhookDeliveryStatusPending
+
+			require.NoError(t, repository.Enqueue(ctx, delivery))
+		}
+
+		claimed, err := repository.ClaimPending(ctx, 1, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+
+		assert.Equal(t, "event-1", claimed[0].EventID)
+		assert.Equal(t, 1, claimed[0].Attempts)
+
+		actual, err := repository.ClaimPending(ctx, 10, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, "event-2", actual[0].EventID)
+
+		// Both are locked until a minute from now.
+		actual, err = repository.ClaimPending(ctx, 10, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+
+		deliveredAt := now.UTC()
+
+		delivery := claimed[0]
+		delivery.Status = entity.WebhookDeliveryStatusDelivered
+		delivery.LastStatusCode = 200
+		delivery.DeliveredAt = &deliveredAt
+
+		updated, err := repository.Update(ctx, delivery.ID, delivery)
+		require.NoError(t, err)
+		require.NotNil(t, updated)
+
+		assert.Equal(t, entity.WebhookDeliveryStatusDelivered, updated.Status)
+		assert.Equal(t, 200, updated.LastStatusCode)
+		assert.Equal(t, 1, updated.Attempts)
+		assert.WithinDuration(t, deliveredAt, *updated.DeliveredAt, time.Millisecond)
+		assert.Equal(t, claimed[0].CreatedAt, updated.CreatedAt)
+
+		actual, err = repository.ClaimPending(ctx, 10, now.Add(2*time.Minute), now.Add(3*time.Minute))
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.ElementsMatch(t, []string{"event-2", "event-3"}, []string{actual[0].EventID, actual[1].EventID})
+	})
+
+	t.Run("should not find delivery with unknown or malformed id", func(t *testing.T) {
+		repository := NewWebhookDeliveryRepository(testDatabase.New(t))
+
+		for _, id := range []string{"00000000-0000-0000-0000-000000000000", "not-an-id"} {
+			actual, err := repository.GetByID(ctx, id)
+			assert.Nil(t, actual)
+			assert.Nil(t, err)
+
+			actual, err = repository.Update(ctx, id, entity.WebhookDelivery{Status: entity.WebhookDeliveryStatusDead})
+			assert.Nil(t, actual)
+			assert.Nil(t, err)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package webhookSubscriptionRepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/model"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/rowID"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/transactor"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const columns = "id, url, event_types, secret, created_at, updated_at"
+
+type webhookSubscriptionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookSubscriptionRepository(pool *pgxpool.Pool) interfaces.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{
+		pool: pool,
+	}
+}
+
+func (ref *webhookSubscriptionRepository) Create(ctx context.Context, subscription entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	record := model.WebhookSubscriptionFromDomain(subscription)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, `
+		INSERT INTO webhook_subscriptions (url, event_types, secret, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		RETURNING `+columns,
+		record.URL, record.EventTypes, record.Secret, time.Now(),
+	)
+
+	return scan(row)
+}
+
+func (ref *webhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	id = rowID.FromString(id)
+
+	row := transactor.Get(ctx, ref.pool).QueryRow(ctx, "SELECT "+columns+" FROM webhook_subscriptions WHERE id = $1", id)
+
+	subscription, err := scan(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return subscription, err
+}
+
+func (ref *webhookSubscriptionRepository) Search(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	return ref.find(ctx, "TRUE")
+}
+
+func (ref *webhookSubscriptionRepository) SearchByEventType(ctx context.Context, eventType string) ([]entity.WebhookSubscription, error) {
+	return ref.find(ctx, "event_types @> ARRAY[$1::text]", eventType)
+}
+
+func (ref *webhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	id = rowID.FromString(id)
+
+	_, err := transactor.Get(ctx, ref.pool).Exec(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	return err
+}
+
+func (ref *webhookSubscriptionRepository) find(ctx context.Context, condition string, arguments ...any) ([]entity.WebhookSubscription, error) {
+	rows, err := transactor.Get(ctx, ref.pool).Query(ctx, `
+		SELECT `+columns+` FROM webhook_subscriptions
+		WHERE `+condition+`
+		ORDER BY created_at, id`,
+		arguments...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]entity.WebhookSubscription, 0)
+
+	for rows.Next() {
+		subscription, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, *subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+func scan(row pgx.Row) (*entity.WebhookSubscription, error) {
+	var record model.WebhookSubscription
+
+	err := row.Scan(&record.ID, &record.URL, &record.EventTypes, &record.Secret, &record.CreatedAt, &record.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.ToDomain(), nil
+}
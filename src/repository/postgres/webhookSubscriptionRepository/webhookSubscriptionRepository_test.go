@@ -0,0 +1,74 @@
+//go:build integration
+
+package webhookSubscriptionRepository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/postgres/testDatabase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSubscriptionRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should create, find and delete subscription", func(t *testing.T) {
+		repository := NewWebhookSubscriptionRepository(testDatabase.New(t))
+
+		created, err := repository.Create(ctx, entity.WebhookSubscription{
+			URL:        "https://example.com/webhooks",
+			EventTypes: []string{"VehicleCreated", "VehicleSold"},
+			Secret:     "secret",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, created)
+
+		assert.NotEmpty(t, created.ID)
+		assert.Equal(t, []string{"VehicleCreated", "VehicleSold"}, created.EventTypes)
+
+		actual, err := repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created, actual)
+
+		require.NoError(t, repository.Delete(ctx, created.ID))
+
+		actual, err = repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should not find subscription with malformed id", func(t *testing.T) {
+		repository := NewWebhookSubscriptionRepository(testDatabase.New(t))
+
+		actual, err := repository.GetByID(ctx, "not-an-id")
+		assert.Nil(t, actual)
+		assert.Nil(t, err)
+
+		assert.Nil(t, repository.Delete(ctx, "not-an-id"))
+	})
+
+	t.Run("should search subscriptions in creation order", func(t *testing.T) {
+		repository := NewWebhookSubscriptionRepository(testDatabase.New(t))
+
+		created, err := repository.Create(ctx, entity.WebhookSubscription{URL: "https://example.com/created", EventTypes: []string{"VehicleCreated"}})
+		require.NoError(t, err)
+
+		sold, err := repository.Create(ctx, entity.WebhookSubscription{URL: "https://example.com/sold", EventTypes: []string{"VehicleSold", "SaleCreated"}})
+		require.NoError(t, err)
+
+		actual, err := repository.Search(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []entity.WebhookSubscription{*created, *sold}, actual)
+
+		actual, err = repository.SearchByEventType(ctx, "SaleCreated")
+		require.NoError(t, err)
+		assert.Equal(t, []entity.WebhookSubscription{*sold}, actual)
+
+		actual, err = repository.SearchByEventType(ctx, "VehicleDeleted")
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
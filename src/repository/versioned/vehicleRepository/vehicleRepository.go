@@ -0,0 +1,86 @@
+// Package vehicleRepository holds a decorator that keeps a snapshot of every
+// version of the vehicles saved through it.
+package vehicleRepository
+
+import (
+	"context"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+type vehicleRepository struct {
+	next                     interfaces.VehicleRepository
+	vehicleVersionRepository interfaces.VehicleVersionRepository
+}
+
+// NewVehicleRepository appends the vehicle to vehicleVersionRepository after
+// every change made through the wrapped repository. The snapshot is written
+// with the same context, so that it is part of the caller's transaction, and
+// a failure to write it fails the change.
+func NewVehicleRepository(next interfaces.VehicleRepository, vehicleVersionRepository interfaces.VehicleVersionRepository) interfaces.VehicleRepository {
+	return &vehicleRepository{
+		next:                     next,
+		vehicleVersionRepository: vehicleVersionRepository,
+	}
+}
+
+func (ref *vehicleRepository) Create(ctx context.Context, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	created, err := ref.next.Create(ctx, vehicle)
+	return ref.record(ctx, created, err)
+}
+
+func (ref *vehicleRepository) GetByID(ctx context.Context, id string) (*entity.Vehicle, error) {
+	return ref.next.GetByID(ctx, id)
+}
+
+func (ref *vehicleRepository) Search(ctx context.Context, filter entity.VehicleFilter) ([]entity.Vehicle, error) {
+	return ref.next.Search(ctx, filter)
+}
+
+func (ref *vehicleRepository) Iterate(ctx context.Context, filter entity.VehicleFilter, fn func(entity.Vehicle) error) error {
+	return ref.next.Iterate(ctx, filter, fn)
+}
+
+func (ref *vehicleRepository) Update(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	updated, err := ref.next.Update(ctx, id, vehicle)
+	return ref.record(ctx, updated, err)
+}
+
+func (ref *vehicleRepository) Replace(ctx context.Context, id string, vehicle entity.Vehicle) (*entity.Vehicle, error) {
+	replaced, err := ref.next.Replace(ctx, id, vehicle)
+	return ref.record(ctx, replaced, err)
+}
+
+func (ref *vehicleRepository) Delete(ctx context.Context, id string, version int64, deletedBy string) (*entity.Vehicle, error) {
+	deleted, err := ref.next.Delete(ctx, id, version, deletedBy)
+	return ref.record(ctx, deleted, err)
+}
+
+func (ref *vehicleRepository) Restore(ctx context.Context, id string, version int64) (*entity.Vehicle, error) {
+	restored, err := ref.next.Restore(ctx, id, version)
+	return ref.record(ctx, restored, err)
+}
+
+// Purge keeps the versions of the removed vehicles, like their audit records.
+func (ref *vehicleRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return ref.next.Purge(ctx, deletedBefore)
+}
+
+// record snapshots the result of a change, timed by its UpdatedAt.
+func (ref *vehicleRepository) record(ctx context.Context, vehicle *entity.Vehicle, err error) (*entity.Vehicle, error) {
+	if err != nil || vehicle == nil {
+		return vehicle, err
+	}
+
+	err = ref.vehicleVersionRepository.Append(ctx, entity.VehicleVersion{
+		Vehicle:    *vehicle,
+		RecordedAt: vehicle.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return vehicle, nil
+}
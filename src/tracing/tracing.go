@@ -0,0 +1,109 @@
+// Package tracing records OpenTelemetry spans and propagates them with the W3C
+// Trace Context headers. Spans are exported over OTLP/HTTP to a collector or
+// written to stdout, by the provider installed with SetProvider.
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/caiiomp/vehicle-resale-api"
+
+var propagator = propagation.TraceContext{}
+
+// enabled tells whether a provider is installed.
+var enabled atomic.Bool
+
+// Span is an OpenTelemetry span that ends with the error of its operation.
+type Span struct {
+	trace.Span
+}
+
+// End records err, when it is not nil, as the status of the span and ends it.
+func (ref Span) End(err error) {
+	if err != nil {
+		ref.RecordError(err)
+		ref.SetStatus(codes.Error, err.Error())
+	}
+
+	ref.Span.End()
+}
+
+// Start begins a span as a child of the span in ctx. When tracing is disabled
+// it returns ctx unchanged and a span that records nothing.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, Span) {
+	if !enabled.Load() {
+		return ctx, Span{Span: noop.Span{}}
+	}
+
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+	return ctx, Span{Span: span}
+}
+
+// NewProvider exports spans in batches from a background goroutine, so that
+// ending a span never waits on the exporter. New traces are sampled at
+// sampleRatio; spans with a parent follow the parent's decision.
+func NewProvider(exporter sdktrace.SpanExporter, serviceName string, sampleRatio float64) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+}
+
+// NewOTLPExporter sends spans to the collector at endpoint, appending the
+// /v1/traces path when it is missing.
+func NewOTLPExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+
+	return otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+}
+
+// NewStdoutExporter writes one JSON object per span, for local debugging.
+func NewStdoutExporter(writer io.Writer) (sdktrace.SpanExporter, error) {
+	return stdouttrace.New(stdouttrace.WithWriter(writer))
+}
+
+// SetProvider installs the provider used by Start, along with the W3C Trace
+// Context propagator for libraries that propagate spans themselves. A nil
+// provider disables tracing.
+func SetProvider(provider trace.TracerProvider) {
+	enabled.Store(provider != nil)
+
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+}
+
+// Inject writes the current span context to the traceparent and tracestate
+// headers of an outgoing request.
+func Inject(ctx context.Context, header http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Extract reads the traceparent and tracestate headers of an incoming request.
+// Invalid headers are ignored and a new trace is started instead.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
@@ -0,0 +1,139 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// newRecorder installs a provider that keeps the spans in memory.
+func newRecorder(t *testing.T, sampleRatio float64) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+
+	provider := NewProvider(exporter, "vehicle-resale-api", sampleRatio)
+	SetProvider(provider)
+
+	t.Cleanup(func() {
+		SetProvider(nil)
+	})
+
+	return provider, exporter
+}
+
+func TestStart(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should record nothing when tracing is disabled", func(t *testing.T) {
+		SetProvider(nil)
+
+		actualCtx, span := Start(ctx, "operation")
+
+		assert.Equal(t, ctx, actualCtx)
+		assert.False(t, span.IsRecording())
+
+		span.End(errors.New("unexpected error"))
+	})
+
+	t.Run("should export child spans of the incoming trace", func(t *testing.T) {
+		provider, exporter := newRecorder(t, 0)
+
+		header := http.Header{}
+		header.Set("traceparent", traceparent)
+
+		parentCtx, parent := Start(Extract(ctx, header), "GET /vehicles", trace.WithSpanKind(trace.SpanKindServer))
+		_, child := Start(parentCtx, "vehicleRepository.Search", trace.WithAttributes(attribute.Int("limit", 10)))
+
+		child.End(errors.New("unexpected error"))
+		parent.End(nil)
+
+		require.NoError(t, provider.ForceFlush(ctx))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+
+		assert.Equal(t, "vehicleRepository.Search", spans[0].Name)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+		assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Equal(t, "unexpected error", spans[0].Status.Description)
+		assert.Equal(t, []attribute.KeyValue{attribute.Int("limit", 10)}, spans[0].Attributes)
+		assert.Equal(t, "exception", spans[0].Events[0].Name)
+
+		assert.Equal(t, "GET /vehicles", spans[1].Name)
+		assert.Equal(t, trace.SpanKindServer, spans[1].SpanKind)
+		assert.Equal(t, "00f067aa0ba902b7", spans[1].Parent.SpanID().String())
+		assert.True(t, spans[1].Parent.IsRemote())
+		assert.Equal(t, codes.Unset, spans[1].Status.Code)
+	})
+
+	t.Run("should not export spans of unsampled traces", func(t *testing.T) {
+		provider, exporter := newRecorder(t, 0)
+
+		_, span := Start(ctx, "operation")
+		span.End(nil)
+
+		require.NoError(t, provider.ForceFlush(ctx))
+
+		assert.Empty(t, exporter.GetSpans())
+	})
+}
+
+func TestPropagation(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should inject extracted trace context", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("traceparent", traceparent)
+		header.Set("tracestate", "vendor=value")
+
+		outgoing := http.Header{}
+		Inject(Extract(ctx, header), outgoing)
+
+		assert.Equal(t, traceparent, outgoing.Get("traceparent"))
+		assert.Equal(t, "vendor=value", outgoing.Get("tracestate"))
+	})
+
+	t.Run("should not inject without trace context", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("traceparent", "00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+
+		outgoing := http.Header{}
+		Inject(Extract(ctx, header), outgoing)
+
+		assert.Empty(t, outgoing)
+	})
+}
+
+func TestNewOTLPExporter(t *testing.T) {
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	exporter, err := NewOTLPExporter(context.TODO(), server.URL+"/")
+	require.NoError(t, err)
+
+	provider := NewProvider(exporter, "vehicle-resale-api", 1)
+
+	_, span := provider.Tracer("test").Start(context.TODO(), "operation")
+	span.End()
+
+	require.NoError(t, provider.Shutdown(context.TODO()))
+
+	assert.Equal(t, []string{"/v1/traces"}, paths)
+}
@@ -0,0 +1,304 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	interfaces "github.com/caiiomp/vehicle-resale-api/src/core/_interfaces"
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+)
+
+// Handler runs a job. The context is canceled when the job is canceled or
+// when the pool shuts down before the job finishes.
+type Handler func(ctx context.Context, job entity.Job) error
+
+type Config struct {
+	Concurrency  int
+	PollInterval time.Duration
+	LockTimeout  time.Duration
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+}
+
+var DefaultConfig = Config{
+	Concurrency:  4,
+	PollInterval: time.Second,
+	LockTimeout:  time.Minute,
+	RetryBackoff: 5 * time.Second,
+	MaxBackoff:   10 * time.Minute,
+}
+
+type periodicTask struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// Pool claims jobs from the repository and runs them with a fixed number of
+// workers. Jobs are retried with exponential backoff until they reach their
+// maximum attempts. A job whose worker died is claimed again once its lock
+// expires, so handlers must tolerate running more than once.
+type Pool struct {
+	jobRepository interfaces.JobRepository
+	config        Config
+	handlers      map[string]Handler
+	tasks         []periodicTask
+
+	ctx        context.Context
+	stop       context.CancelFunc
+	jobsCtx    context.Context
+	cancelJobs context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+func NewPool(jobRepository interfaces.JobRepository, config Config) *Pool {
+	if config.Concurrency <= 0 {
+		config.Concurrency = DefaultConfig.Concurrency
+	}
+
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultConfig.PollInterval
+	}
+
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = DefaultConfig.LockTimeout
+	}
+
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = DefaultConfig.RetryBackoff
+	}
+
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultConfig.MaxBackoff
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+
+	return &Pool{
+		jobRepository: jobRepository,
+		config:        config,
+		handlers:      map[string]Handler{},
+		ctx:           ctx,
+		stop:          stop,
+		jobsCtx:       jobsCtx,
+		cancelJobs:    cancelJobs,
+	}
+}
+
+// Handle registers the handler of a job type. It must be called before Start.
+func (ref *Pool) Handle(jobType string, handler Handler) {
+	ref.handlers[jobType] = handler
+}
+
+// Every runs a task on a fixed interval while the pool is running. Periodic
+// tasks are not persisted; they run in every instance of the application.
+// It must be called before Start.
+func (ref *Pool) Every(name string, interval time.Duration, task func(ctx context.Context) error) {
+	ref.tasks = append(ref.tasks, periodicTask{
+		name:     name,
+		interval: interval,
+		run:      task,
+	})
+}
+
+func (ref *Pool) Start() {
+	types := make([]string, 0, len(ref.handlers))
+	for jobType := range ref.handlers {
+		types = append(types, jobType)
+	}
+
+	if len(types) > 0 {
+		for range ref.config.Concurrency {
+			ref.wg.Add(1)
+			go ref.work(types)
+		}
+	}
+
+	for _, task := range ref.tasks {
+		ref.wg.Add(1)
+		go ref.schedule(task)
+	}
+}
+
+// Shutdown stops claiming new jobs and waits for the running ones. When ctx
+// expires first, the running jobs are canceled and put back in the queue.
+func (ref *Pool) Shutdown(ctx context.Context) error {
+	ref.stop()
+
+	done := make(chan struct{})
+	go func() {
+		ref.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		ref.cancelJobs()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (ref *Pool) work(types []string) {
+	defer ref.wg.Done()
+
+	for {
+		if ref.ctx.Err() != nil {
+			return
+		}
+
+		now := time.Now()
+
+		job, err := ref.jobRepository.ClaimNext(ref.ctx, types, now, now.Add(ref.config.LockTimeout))
+		if err != nil && ref.ctx.Err() == nil {
+			slog.Error("could not claim job", "error", err)
+		}
+
+		if job == nil {
+			select {
+			case <-ref.ctx.Done():
+				return
+			case <-time.After(ref.config.PollInterval):
+			}
+			continue
+		}
+
+		ref.run(*job)
+	}
+}
+
+func (ref *Pool) run(job entity.Job) {
+	ctx, cancel := context.WithCancel(ref.jobsCtx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ref.call(ctx, job)
+	}()
+
+	heartbeat := time.NewTicker(ref.config.LockTimeout / 3)
+	defer heartbeat.Stop()
+
+	canceled := false
+
+	for {
+		select {
+		case err := <-done:
+			ref.finish(job, err, canceled)
+			return
+		case <-heartbeat.C:
+			current, err := ref.jobRepository.Heartbeat(context.Background(), job.ID, time.Now().Add(ref.config.LockTimeout))
+			if err != nil {
+				slog.Warn("could not extend job lock", "job_id", job.ID, "error", err)
+				continue
+			}
+
+			if current == nil || current.Status == entity.JobStatusCanceled {
+				canceled = true
+				cancel()
+			}
+		}
+	}
+}
+
+func (ref *Pool) call(ctx context.Context, job entity.Job) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+
+	return ref.handlers[job.Type](ctx, job)
+}
+
+func (ref *Pool) finish(job entity.Job, err error, canceled bool) {
+	ctx := context.Background()
+
+	// The job may have been canceled after the last heartbeat.
+	current, getErr := ref.jobRepository.GetByID(ctx, job.ID)
+	if getErr != nil {
+		slog.Error("could not get job", "job_id", job.ID, "error", getErr)
+		return
+	}
+
+	if canceled || current == nil || current.Status == entity.JobStatusCanceled {
+		return
+	}
+
+	now := time.Now()
+	job.LockedUntil = nil
+
+	switch {
+	case err == nil:
+		job.Status = entity.JobStatusSucceeded
+		job.LastError = ""
+		job.FinishedAt = &now
+	case ref.jobsCtx.Err() != nil:
+		// Interrupted by shutdown, the attempt does not count.
+		job.Status = entity.JobStatusPending
+		job.Attempts--
+		job.RunAt = now
+		job.LastError = err.Error()
+	case job.Attempts >= job.MaxAttempts:
+		job.Status = entity.JobStatusFailed
+		job.LastError = err.Error()
+		job.FinishedAt = &now
+	default:
+		job.Status = entity.JobStatusPending
+		job.RunAt = now.Add(ref.backoff(job.Attempts))
+		job.LastError = err.Error()
+	}
+
+	attrs := []any{"job_id", job.ID, "job_type", job.Type, "attempts", job.Attempts}
+
+	switch job.Status {
+	case entity.JobStatusSucceeded:
+		slog.Info("job succeeded", attrs...)
+	case entity.JobStatusFailed:
+		slog.Error("job failed", append(attrs, "error", job.LastError)...)
+	default:
+		slog.Warn("job will be retried", append(attrs, "run_at", job.RunAt, "error", job.LastError)...)
+	}
+
+	if _, err = ref.jobRepository.Update(ctx, job.ID, job); err != nil {
+		slog.Error("could not update job", "job_id", job.ID, "error", err)
+	}
+}
+
+func (ref *Pool) backoff(attempts int) time.Duration {
+	backoff := ref.config.RetryBackoff
+
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+
+		if backoff >= ref.config.MaxBackoff {
+			return ref.config.MaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+func (ref *Pool) schedule(task periodicTask) {
+	defer ref.wg.Done()
+
+	ticker := time.NewTicker(task.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ref.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := task.run(ref.jobsCtx); err != nil {
+				slog.Error("periodic task failed", "task", task.name, "error", err)
+			}
+		}
+	}
+}
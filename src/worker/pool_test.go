@@ -0,0 +1,250 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/jobRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	Concurrency:  2,
+	PollInterval: 5 * time.Millisecond,
+	LockTimeout:  30 * time.Millisecond,
+	RetryBackoff: time.Millisecond,
+	MaxBackoff:   5 * time.Millisecond,
+}
+
+func waitJobStatus(t *testing.T, pool *Pool, id, status string) *entity.Job {
+	var job *entity.Job
+
+	require.Eventually(t, func() bool {
+		job, _ = pool.jobRepository.GetByID(context.TODO(), id)
+		return job != nil && job.Status == status
+	}, time.Second, 5*time.Millisecond)
+
+	return job
+}
+
+func TestPool(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("should run job successfully", func(t *testing.T) {
+		repository := jobRepository.NewJobRepository()
+
+		pool := NewPool(repository, testConfig)
+
+		var payload atomic.Value
+
+		pool.Handle("test", func(ctx context.Context, job entity.Job) error {
+			payload.Store(string(job.Payload))
+			return nil
+		})
+
+		created, err := repository.Create(ctx, entity.Job{
+			Type:        "test",
+			Payload:     []byte(`{"key":"value"}`),
+			Status:      entity.JobStatusPending,
+			MaxAttempts: 3,
+			RunAt:       time.Now(),
+		})
+		require.NoError(t, err)
+
+		pool.Start()
+		defer pool.Shutdown(ctx)
+
+		job := waitJobStatus(t, pool, created.ID, entity.JobStatusSucceeded)
+
+		assert.Equal(t, 1, job.Attempts)
+		assert.NotNil(t, job.FinishedAt)
+		assert.Nil(t, job.LockedUntil)
+		assert.Equal(t, `{"key":"value"}`, payload.Load())
+	})
+
+	t.Run("should retry job until max attempts", func(t *testing.T) {
+		repository := jobRepository.NewJobRepository()
+
+		pool := NewPool(repository, testConfig)
+
+		var calls atomic.Int32
+
+		pool.Handle("test", func(ctx context.Context, job entity.Job) error {
+			calls.Add(1)
+			return errors.New("unexpected error")
+		})
+
+		created, err := repository.Create(ctx, entity.Job{
+			Type:        "test",
+			Status:      entity.JobStatusPending,
+			MaxAttempts: 3,
+			RunAt:       time.Now(),
+		})
+		require.NoError(t, err)
+
+		pool.Start()
+		defer pool.Shutdown(ctx)
+
+		job := waitJobStatus(t, pool, created.ID, entity.JobStatusFailed)
+
+		assert.Equal(t, 3, job.Attempts)
+		assert.Equal(t, "unexpected error", job.LastError)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("should recover from panicking job", func(t *testing.T) {
+		repository := jobRepository.NewJobRepository()
+
+		pool := NewPool(repository, testConfig)
+
+		pool.Handle("test", func(ctx context.Context, job entity.Job) error {
+			panic("boom")
+		})
+
+		created, err := repository.Create(ctx, entity.Job{
+			Type:        "test",
+			Status:      entity.JobStatusPending,
+			MaxAttempts: 1,
+			RunAt:       time.Now(),
+		})
+		require.NoError(t, err)
+
+		pool.Start()
+		defer pool.Shutdown(ctx)
+
+		job := waitJobStatus(t, pool, created.ID, entity.JobStatusFailed)
+
+		assert.Equal(t, "job panicked: boom", job.LastError)
+	})
+
+	t.Run("should cancel running job", func(t *testing.T) {
+		repository := jobRepository.NewJobRepository()
+
+		pool := NewPool(repository, testConfig)
+
+		started := make(chan struct{})
+		stopped := make(chan error, 1)
+
+		pool.Handle("test", func(ctx context.Context, job entity.Job) error {
+			close(started)
+			<-ctx.Done()
+			stopped <- ctx.Err()
+			return ctx.Err()
+		})
+
+		created, err := repository.Create(ctx, entity.Job{
+			Type:        "test",
+			Status:      entity.JobStatusPending,
+			MaxAttempts: 3,
+			RunAt:       time.Now(),
+		})
+		require.NoError(t, err)
+
+		pool.Start()
+		defer pool.Shutdown(ctx)
+
+		<-started
+
+		job, err := repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+
+		job.Status = entity.JobStatusCanceled
+
+		_, err = repository.Update(ctx, created.ID, *job)
+		require.NoError(t, err)
+
+		select {
+		case err = <-stopped:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(time.Second):
+			t.Fatal("job was not canceled")
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		job, err = repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, entity.JobStatusCanceled, job.Status)
+	})
+
+	t.Run("should requeue running job when shutdown times out", func(t *testing.T) {
+		repository := jobRepository.NewJobRepository()
+
+		pool := NewPool(repository, testConfig)
+
+		started := make(chan struct{})
+
+		pool.Handle("test", func(ctx context.Context, job entity.Job) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		created, err := repository.Create(ctx, entity.Job{
+			Type:        "test",
+			Status:      entity.JobStatusPending,
+			MaxAttempts: 3,
+			RunAt:       time.Now(),
+		})
+		require.NoError(t, err)
+
+		pool.Start()
+
+		<-started
+
+		shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		err = pool.Shutdown(shutdownCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		job, err := repository.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, entity.JobStatusPending, job.Status)
+		assert.Equal(t, 0, job.Attempts)
+	})
+
+	t.Run("should run periodic task until shutdown", func(t *testing.T) {
+		pool := NewPool(jobRepository.NewJobRepository(), testConfig)
+
+		var runs atomic.Int32
+
+		pool.Every("test", 5*time.Millisecond, func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		})
+
+		pool.Start()
+
+		require.Eventually(t, func() bool {
+			return runs.Load() >= 2
+		}, time.Second, 5*time.Millisecond)
+
+		err := pool.Shutdown(ctx)
+		assert.NoError(t, err)
+
+		stoppedAt := runs.Load()
+		time.Sleep(20 * time.Millisecond)
+
+		assert.Equal(t, stoppedAt, runs.Load())
+	})
+}
+
+func Test_backoff(t *testing.T) {
+	pool := NewPool(nil, Config{
+		RetryBackoff: time.Second,
+		MaxBackoff:   10 * time.Second,
+	})
+
+	assert.Equal(t, time.Second, pool.backoff(1))
+	assert.Equal(t, 2*time.Second, pool.backoff(2))
+	assert.Equal(t, 8*time.Second, pool.backoff(4))
+	assert.Equal(t, 10*time.Second, pool.backoff(5))
+}
@@ -0,0 +1,165 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/auditApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jwtSecretKey = "integration-secret"
+
+func signToken(t *testing.T, userID, role string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID,
+		"role":    role,
+	})
+
+	signed, err := token.SignedString([]byte(jwtSecretKey))
+	require.NoError(t, err)
+
+	return "Bearer " + signed
+}
+
+func TestAudit(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+
+	// Auth and Admin are skipped in test mode, so these requests run with
+	// real tokens.
+	mode := gin.Mode()
+	gin.SetMode(gin.ReleaseMode)
+	defer gin.SetMode(mode)
+
+	authMiddleware := middleware.NewAuthMiddleware(jwtSecretKey)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, authMiddleware, newIdempotencyMiddleware(), vehicleService)
+	auditApi.RegisterAuditRoutes(app, authMiddleware, auditService)
+
+	sellerToken := signToken(t, "seller-123", "")
+	adminToken := signToken(t, "admin-123", "admin")
+
+	payload := map[string]any{
+		"brand": "Ford",
+		"model": "Ka",
+		"year":  2022,
+		"color": "Preto",
+		"price": 50000,
+	}
+
+	rawPayload, _ := json.Marshal(payload)
+	body := bytes.NewReader(rawPayload)
+
+	req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", sellerToken)
+	req.Header.Set("X-Request-ID", "create-request")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var created responses.Vehicle
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &created))
+
+	rawPayload, _ = json.Marshal(map[string]any{"price": 45000})
+	body = bytes.NewReader(rawPayload)
+
+	req, _ = http.NewRequest(http.MethodPatch, "/vehicles/"+created.ID, body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", adminToken)
+	req.Header.Set("X-Request-ID", "update-request")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	t.Run("should list audit records of a vehicle for admins", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/audit?entity_id=%s", created.ID), nil)
+		req.Header.Set("Authorization", adminToken)
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var records []responses.AuditRecord
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &records))
+		require.Len(t, records, 2)
+
+		assert.Equal(t, "vehicle", records[0].EntityType)
+		assert.Equal(t, created.ID, records[0].EntityID)
+		assert.Equal(t, "create", records[0].Action)
+		assert.Equal(t, "seller-123", records[0].ActorID)
+		assert.Equal(t, "create-request", records[0].RequestID)
+		assert.NotEmpty(t, records[0].Changes)
+
+		assert.Equal(t, "update", records[1].Action)
+		assert.Equal(t, "admin-123", records[1].ActorID)
+		assert.Equal(t, "admin", records[1].ActorRole)
+		assert.Equal(t, "update-request", records[1].RequestID)
+		assert.Equal(t, []responses.AuditChange{
+			{Field: "price", Before: float64(50000), After: float64(45000)},
+		}, records[1].Changes)
+	})
+
+	t.Run("should not list audit records for users without the admin role", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/audit?entity_id=%s", created.ID), nil)
+		req.Header.Set("Authorization", sellerToken)
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("should not list audit records without entity id", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/audit", nil)
+		req.Header.Set("Authorization", adminToken)
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
@@ -0,0 +1,197 @@
+//go:build integration
+
+package integration
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/payment"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/sale"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/paymentApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/saleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/saleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportVehicles(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, nil, nil, auditService, transactor, outboxService)
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+
+	payload := map[string]any{
+		"brand": "Ford",
+		"model": "Ka",
+		"year":  2022,
+		"color": "Preto",
+		"price": 50000,
+	}
+
+	rawPayload, _ := json.Marshal(payload)
+	body := bytes.NewReader(rawPayload)
+
+	req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	var response responses.Vehicle
+	err := json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	req, _ = http.NewRequest(http.MethodGet, "/vehicles/export?is_sold=false&format=csv", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="vehicles.csv"`, resp.Header().Get("Content-Disposition"))
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"id", "brand", "model", "year", "color", "price", "status", "seller_id", "sold_at", "created_at", "updated_at"}, records[0])
+	assert.Equal(t, []string{response.ID, "Ford", "Ka", "2022", "Preto", "50000", "available"}, records[1][:7])
+
+	req, _ = http.NewRequest(http.MethodGet, "/vehicles/export?format=pdf", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestExportSales(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	saleRepository := saleRepository.NewSaleRepository(vehicleRepository)
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	saleService := sale.NewSaleService(saleRepository, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	saleApi.RegisterSaleRoutes(app, saleService)
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+
+	payload := map[string]any{
+		"brand": "Ford",
+		"model": "Ka",
+		"year":  2022,
+		"color": "Preto",
+		"price": 50000,
+	}
+
+	rawPayload, _ := json.Marshal(payload)
+	body := bytes.NewReader(rawPayload)
+
+	req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	var response responses.Vehicle
+	err := json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	req, _ = http.NewRequest(http.MethodPost, "/vehicles/"+response.ID+"/buy", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+
+	var paymentResponse responses.Payment
+	err = json.Unmarshal(resp.Body.Bytes(), &paymentResponse)
+	require.NoError(t, err)
+
+	resp = sendPaymentWebhook(app, paymentResponse.IntentID, "succeeded")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/sales/export?format=xlsx", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", resp.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="sales.xlsx"`, resp.Header().Get("Content-Disposition"))
+
+	archive, err := zip.NewReader(bytes.NewReader(resp.Body.Bytes()), int64(resp.Body.Len()))
+	require.NoError(t, err)
+
+	var sheet *zip.File
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			sheet = file
+		}
+	}
+	require.NotNil(t, sheet)
+
+	content, err := sheet.Open()
+	require.NoError(t, err)
+	defer content.Close()
+
+	var buffer bytes.Buffer
+	_, err = buffer.ReadFrom(content)
+	require.NoError(t, err)
+
+	assert.Contains(t, buffer.String(), `<row r="2">`)
+	assert.Contains(t, buffer.String(), response.ID)
+	assert.NotContains(t, buffer.String(), `<row r="3">`)
+}
@@ -0,0 +1,106 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/health"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/healthApi"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubChecker struct {
+	name string
+	err  error
+}
+
+func (ref stubChecker) Name() string {
+	return ref.name
+}
+
+func (ref stubChecker) Check(ctx context.Context) error {
+	return ref.err
+}
+
+func TestHealth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should report liveness and readiness until shutdown", func(t *testing.T) {
+		healthService := health.NewHealthService(time.Second, stubChecker{name: "mongodb"})
+
+		app := presentation.SetupServer()
+		healthApi.RegisterHealthRoutes(app, healthService)
+
+		req, _ := http.NewRequest(http.MethodGet, "/healthz", nil)
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		req, _ = http.NewRequest(http.MethodGet, "/readyz", nil)
+		resp = httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var response responses.HealthReport
+		err := json.Unmarshal(resp.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "up", response.Status)
+		require.Len(t, response.Checks, 1)
+		assert.Equal(t, "mongodb", response.Checks[0].Name)
+		assert.Equal(t, "up", response.Checks[0].Status)
+
+		healthService.Shutdown()
+
+		req, _ = http.NewRequest(http.MethodGet, "/readyz", nil)
+		resp = httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+		req, _ = http.NewRequest(http.MethodGet, "/healthz", nil)
+		resp = httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("should not be ready when a dependency is down", func(t *testing.T) {
+		healthService := health.NewHealthService(time.Second, stubChecker{name: "mongodb", err: errors.New("connection refused")})
+
+		app := presentation.SetupServer()
+		healthApi.RegisterHealthRoutes(app, healthService)
+
+		req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+		var response responses.HealthReport
+		err := json.Unmarshal(resp.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "down", response.Status)
+		require.Len(t, response.Checks, 1)
+		assert.Equal(t, "connection refused", response.Checks[0].Error)
+	})
+}
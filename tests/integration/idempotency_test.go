@@ -0,0 +1,138 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/idempotency"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/idempotencyRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIdempotencyMiddleware() middleware.IdempotencyMiddleware {
+	return middleware.NewIdempotencyMiddleware(idempotency.NewIdempotencyService(idempotencyRepository.NewIdempotencyRepository(), time.Hour))
+}
+
+func sendIdempotent(app http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.IdempotencyKeyHeader, key)
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+
+	ctx := context.TODO()
+
+	t.Run("should replay vehicle creation", func(t *testing.T) {
+		body := `{"brand":"Ford","model":"Ka","year":2022,"color":"Preto","price":50000}`
+
+		first := sendIdempotent(app, "/vehicles", "create-1", body)
+		require.Equal(t, http.StatusCreated, first.Code)
+		assert.Empty(t, first.Header().Get(middleware.IdempotentReplayedHeader))
+
+		retry := sendIdempotent(app, "/vehicles", "create-1", body)
+		require.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get(middleware.IdempotentReplayedHeader))
+		assert.Equal(t, "application/json; charset=utf-8", retry.Header().Get("Content-Type"))
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+
+		vehicles, err := vehicleService.Search(ctx, entity.VehicleFilter{})
+		require.NoError(t, err)
+		assert.Len(t, vehicles, 1)
+	})
+
+	t.Run("should reject key reused with a different body", func(t *testing.T) {
+		resp := sendIdempotent(app, "/vehicles", "create-2", `{"brand":"Fiat","model":"Uno","year":2010,"color":"Branco","price":20000}`)
+		require.Equal(t, http.StatusCreated, resp.Code)
+
+		resp = sendIdempotent(app, "/vehicles", "create-2", `{"brand":"Fiat","model":"Uno","year":2010,"color":"Branco","price":25000}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.JSONEq(t, `{"error":"idempotency key was already used with a different request"}`, resp.Body.String())
+	})
+
+	t.Run("should replay vehicle purchase", func(t *testing.T) {
+		first, err := vehicleService.Create(ctx, entity.Vehicle{Brand: "VW", Model: "Gol", Year: 2020, Price: 40000})
+		require.NoError(t, err)
+
+		second, err := vehicleService.Create(ctx, entity.Vehicle{Brand: "VW", Model: "Fox", Year: 2021, Price: 45000})
+		require.NoError(t, err)
+
+		resp := sendIdempotent(app, "/vehicles/"+first.ID+"/buy", "buy-1", "")
+		require.Equal(t, http.StatusAccepted, resp.Code)
+
+		retry := sendIdempotent(app, "/vehicles/"+first.ID+"/buy", "buy-1", "")
+		require.Equal(t, http.StatusAccepted, retry.Code)
+		assert.Equal(t, resp.Body.String(), retry.Body.String())
+
+		// The path is part of the request, so buying another vehicle with the
+		// same key is rejected instead of replaying the first purchase.
+		other := sendIdempotent(app, "/vehicles/"+second.ID+"/buy", "buy-1", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, other.Code)
+
+		vehicle, err := vehicleService.GetByID(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.VehicleStatusAvailable, vehicle.Status)
+	})
+
+	t.Run("should not keep responses of requests without key", func(t *testing.T) {
+		body := `{"brand":"Renault","model":"Kwid","year":2023,"color":"Azul","price":60000}`
+
+		for range 2 {
+			req, _ := http.NewRequest(http.MethodPost, "/vehicles", bytes.NewReader([]byte(body)))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp := httptest.NewRecorder()
+
+			app.ServeHTTP(resp, req)
+
+			require.Equal(t, http.StatusCreated, resp.Code)
+		}
+
+		vehicles, err := vehicleService.Search(ctx, entity.VehicleFilter{})
+		require.NoError(t, err)
+		assert.Len(t, vehicles, 6)
+	})
+}
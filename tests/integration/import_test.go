@@ -0,0 +1,225 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/job"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicleImport"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/jobApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/importJobRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/jobRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/worker"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var workerConfig = worker.Config{
+	Concurrency:  1,
+	PollInterval: 5 * time.Millisecond,
+}
+
+func waitImportJob(t *testing.T, app *gin.Engine, jobID string) responses.ImportJob {
+	var response responses.ImportJob
+
+	require.Eventually(t, func() bool {
+		req, _ := http.NewRequest(http.MethodGet, "/imports/"+jobID, nil)
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		if resp.Code != http.StatusOK {
+			return false
+		}
+
+		err := json.Unmarshal(resp.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		return response.Status == "completed"
+	}, time.Second, 10*time.Millisecond)
+
+	return response
+}
+
+func TestImportVehicles(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	importJobRepository := importJobRepository.NewImportJobRepository()
+	jobRepository := jobRepository.NewJobRepository()
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, nil, nil, auditService, transactor, outboxService)
+	jobService := job.NewJobService(jobRepository, 3)
+	vehicleImportService := vehicleImport.NewVehicleImportService(importJobRepository, jobService, vehicleService)
+
+	workerPool := worker.NewPool(jobRepository, workerConfig)
+	workerPool.Handle(vehicleImport.JobType, vehicleImportService.Process)
+	workerPool.Start()
+	defer workerPool.Shutdown(context.Background())
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	vehicleApi.RegisterVehicleImportRoutes(app, middleware.AuthMiddleware{}, vehicleImportService)
+	jobApi.RegisterJobRoutes(app, middleware.AuthMiddleware{}, jobService)
+
+	file := "brand,model,year,color,price\n" +
+		"Ford,Ka,2022,Preto,50000\n" +
+		"Fiat,Uno,doze,Branco,15000\n" +
+		"Chevrolet,Onix,2020,Prata,60000\n"
+
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "vehicles.csv")
+	_, _ = part.Write([]byte(file))
+	_ = writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/vehicles/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+
+	var response responses.ImportJob
+	err := json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, response.Total)
+	assert.Equal(t, 2, response.Valid)
+
+	response = waitImportJob(t, app, response.ID)
+
+	assert.Equal(t, 2, response.Imported)
+	assert.Equal(t, []responses.ImportRowError{{Row: 2, Error: `invalid year "doze"`}}, response.Errors)
+	assert.NotNil(t, response.FinishedAt)
+
+	req, _ = http.NewRequest(http.MethodGet, "/jobs/"+response.JobID, nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var jobResponse responses.Job
+	err = json.Unmarshal(resp.Body.Bytes(), &jobResponse)
+	require.NoError(t, err)
+
+	assert.Equal(t, "vehicle_import", jobResponse.Type)
+	assert.Equal(t, "succeeded", jobResponse.Status)
+	assert.Equal(t, 1, jobResponse.Attempts)
+
+	req, _ = http.NewRequest(http.MethodPost, "/jobs/"+response.JobID+"/cancel", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/vehicles", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	var vehicles []responses.Vehicle
+	err = json.Unmarshal(resp.Body.Bytes(), &vehicles)
+	require.NoError(t, err)
+
+	assert.Len(t, vehicles, 2)
+}
+
+func TestImportVehiclesDryRun(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	importJobRepository := importJobRepository.NewImportJobRepository()
+	jobRepository := jobRepository.NewJobRepository()
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, nil, nil, auditService, transactor, outboxService)
+	jobService := job.NewJobService(jobRepository, 3)
+	vehicleImportService := vehicleImport.NewVehicleImportService(importJobRepository, jobService, vehicleService)
+
+	workerPool := worker.NewPool(jobRepository, workerConfig)
+	workerPool.Handle(vehicleImport.JobType, vehicleImportService.Process)
+	workerPool.Start()
+	defer workerPool.Shutdown(context.Background())
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	vehicleApi.RegisterVehicleImportRoutes(app, middleware.AuthMiddleware{}, vehicleImportService)
+	jobApi.RegisterJobRoutes(app, middleware.AuthMiddleware{}, jobService)
+
+	file := `{"brand":"Ford","model":"Ka","year":2022,"color":"Preto","price":50000}` + "\n" +
+		`{"brand":"Fiat","model":"Uno","year":2012,"color":"Branco"}` + "\n"
+
+	req, _ := http.NewRequest(http.MethodPost, "/vehicles/import?dry_run=true", strings.NewReader(file))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+
+	var response responses.ImportJob
+	err := json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.True(t, response.DryRun)
+
+	response = waitImportJob(t, app, response.ID)
+
+	assert.Equal(t, 2, response.Total)
+	assert.Equal(t, 1, response.Valid)
+	assert.Equal(t, 0, response.Imported)
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, 2, response.Errors[0].Row)
+
+	req, _ = http.NewRequest(http.MethodGet, "/vehicles", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	var vehicles []responses.Vehicle
+	err = json.Unmarshal(resp.Body.Bytes(), &vehicles)
+	require.NoError(t, err)
+
+	assert.Empty(t, vehicles)
+}
@@ -0,0 +1,130 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/logging"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readLogs(t *testing.T, buffer *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+
+	scanner := bufio.NewScanner(buffer)
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestLogging(t *testing.T) {
+	var buffer bytes.Buffer
+
+	logger, err := logging.New(&buffer, "json", "info")
+	require.NoError(t, err)
+
+	defaultLogger := slog.Default()
+	slog.SetDefault(logger)
+	defer slog.SetDefault(defaultLogger)
+
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+
+	t.Run("should log request and domain event with the request id", func(t *testing.T) {
+		buffer.Reset()
+
+		payload := map[string]any{
+			"brand": "Ford",
+			"model": "Ka",
+			"year":  2022,
+			"color": "Preto",
+			"price": 50000,
+		}
+
+		rawPayload, _ := json.Marshal(payload)
+		body := bytes.NewReader(rawPayload)
+
+		req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", "client-request-id")
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, "client-request-id", resp.Header().Get("X-Request-ID"))
+
+		lines := readLogs(t, &buffer)
+		require.Len(t, lines, 2)
+
+		assert.Equal(t, "vehicle created", lines[0]["msg"])
+		assert.Equal(t, "client-request-id", lines[0]["request_id"])
+		assert.NotEmpty(t, lines[0]["vehicle_id"])
+
+		assert.Equal(t, "http request", lines[1]["msg"])
+		assert.Equal(t, "client-request-id", lines[1]["request_id"])
+		assert.Equal(t, "/vehicles", lines[1]["route"])
+		assert.Equal(t, float64(http.StatusCreated), lines[1]["status"])
+	})
+
+	t.Run("should generate request id when header is invalid", func(t *testing.T) {
+		buffer.Reset()
+
+		req, _ := http.NewRequest(http.MethodGet, "/vehicles/does-not-exist", nil)
+		req.Header.Set("X-Request-ID", "invalid request id")
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		requestID := resp.Header().Get("X-Request-ID")
+
+		assert.NotEmpty(t, requestID)
+		assert.NotEqual(t, "invalid request id", requestID)
+
+		lines := readLogs(t, &buffer)
+		require.Len(t, lines, 1)
+
+		assert.Equal(t, requestID, lines[0]["request_id"])
+	})
+}
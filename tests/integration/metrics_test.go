@@ -0,0 +1,144 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/payment"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/metrics"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/metricsApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/paymentApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	instrumentedSaleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/instrumented/saleRepository"
+	instrumentedVehicleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/instrumented/vehicleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/saleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	memoryVehicleRepository := vehicleRepository.NewVehicleRepository()
+	vehicleRepository := instrumentedVehicleRepository.NewVehicleRepository(memoryVehicleRepository)
+	saleRepository := instrumentedSaleRepository.NewSaleRepository(saleRepository.NewSaleRepository(memoryVehicleRepository))
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer(middleware.Metrics)
+
+	app.GET("/panic", func(ctx *gin.Context) {
+		panic("handler failed")
+	})
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+	metricsApi.RegisterMetricsRoutes(app)
+
+	// Counters are global, so only their increase is asserted.
+	vehiclesCreated := testutil.ToFloat64(metrics.VehiclesCreated)
+	purchasesSucceeded := testutil.ToFloat64(metrics.Purchases.WithLabelValues("succeeded"))
+	revenue := testutil.ToFloat64(metrics.SalesRevenue)
+
+	payload := map[string]any{
+		"brand": "Ford",
+		"model": "Ka",
+		"year":  2022,
+		"color": "Preto",
+		"price": 50000,
+	}
+
+	rawPayload, _ := json.Marshal(payload)
+	body := bytes.NewReader(rawPayload)
+
+	req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var response responses.Vehicle
+	err := json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	req, _ = http.NewRequest(http.MethodPost, "/vehicles/"+response.ID+"/buy", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusAccepted, resp.Code)
+
+	var paymentResponse responses.Payment
+	err = json.Unmarshal(resp.Body.Bytes(), &paymentResponse)
+	require.NoError(t, err)
+
+	resp = sendPaymentWebhook(app, paymentResponse.IntentID, "succeeded")
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/does-not-exist/123", nil)
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/panic", nil)
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+
+	assert.Equal(t, vehiclesCreated+1, testutil.ToFloat64(metrics.VehiclesCreated))
+	assert.Equal(t, purchasesSucceeded+1, testutil.ToFloat64(metrics.Purchases.WithLabelValues("succeeded")))
+	assert.Equal(t, revenue+50000, testutil.ToFloat64(metrics.SalesRevenue))
+
+	req, _ = http.NewRequest(http.MethodGet, "/metrics", nil)
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	assert.Contains(t, resp.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+	assert.Contains(t, resp.Body.String(), `http_requests_total{method="POST",route="/vehicles/:vehicle_id/buy",status="202"}`)
+	assert.Contains(t, resp.Body.String(), `http_requests_total{method="GET",route="unmatched",status="404"}`)
+	assert.Contains(t, resp.Body.String(), `http_requests_total{method="GET",route="/panic",status="500"}`)
+	assert.Contains(t, resp.Body.String(), `http_request_duration_seconds_bucket{method="POST",route="/vehicles",status="201",le="+Inf"}`)
+	assert.Contains(t, resp.Body.String(), `repository_operation_duration_seconds_count{method="Create",outcome="success",repository="vehicle"}`)
+	assert.Contains(t, resp.Body.String(), `repository_operation_duration_seconds_count{method="Create",outcome="success",repository="sale"}`)
+	assert.Contains(t, resp.Body.String(), "# TYPE purchases_total counter")
+}
@@ -0,0 +1,202 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/payment"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/paymentApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/webhookPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/saleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxPublishesPurchaseEvents(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	saleRepository := saleRepository.NewSaleRepository(vehicleRepository)
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	eventPublisher := memoryPublisher.NewPublisher()
+
+	events, unsubscribe := eventPublisher.Subscribe(10)
+	defer unsubscribe()
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), eventPublisher, 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+
+	payload := map[string]any{
+		"brand": "Ford",
+		"model": "Ka",
+		"year":  2022,
+		"color": "Preto",
+		"price": 50000,
+	}
+
+	rawPayload, _ := json.Marshal(payload)
+	body := bytes.NewReader(rawPayload)
+
+	req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "create-request")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var vehicleResponse responses.Vehicle
+	err := json.Unmarshal(resp.Body.Bytes(), &vehicleResponse)
+	require.NoError(t, err)
+
+	req, _ = http.NewRequest(http.MethodPost, "/vehicles/"+vehicleResponse.ID+"/buy", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusAccepted, resp.Code)
+
+	var paymentResponse responses.Payment
+	err = json.Unmarshal(resp.Body.Bytes(), &paymentResponse)
+	require.NoError(t, err)
+
+	resp = sendPaymentWebhook(app, paymentResponse.IntentID, "succeeded")
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	err = json.Unmarshal(resp.Body.Bytes(), &paymentResponse)
+	require.NoError(t, err)
+
+	assert.Empty(t, events, "events must only be published by the relay")
+
+	ctx := context.TODO()
+
+	published, err := outboxService.Relay(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, published)
+
+	created := <-events
+	assert.Equal(t, entity.EventVehicleCreated, created.Type)
+	assert.Equal(t, vehicleResponse.ID, created.EntityID)
+	assert.Equal(t, "create-request", created.RequestID)
+	assert.NotEmpty(t, created.ID)
+
+	var vehicleData responses.Vehicle
+	require.NoError(t, json.Unmarshal(created.Payload, &vehicleData))
+	assert.Equal(t, "available", vehicleData.Status)
+	assert.Equal(t, 50000.0, vehicleData.Price)
+
+	reserved := <-events
+	assert.Equal(t, entity.EventVehicleUpdated, reserved.Type)
+	assert.Equal(t, vehicleResponse.ID, reserved.EntityID)
+
+	require.NoError(t, json.Unmarshal(reserved.Payload, &vehicleData))
+	assert.Equal(t, "reserved", vehicleData.Status)
+
+	saleCreated := <-events
+	assert.Equal(t, entity.EventSaleCreated, saleCreated.Type)
+	assert.Equal(t, paymentResponse.SaleID, saleCreated.EntityID)
+
+	sold := <-events
+	assert.Equal(t, entity.EventVehicleSold, sold.Type)
+	assert.Equal(t, vehicleResponse.ID, sold.EntityID)
+
+	require.NoError(t, json.Unmarshal(sold.Payload, &vehicleData))
+	assert.Equal(t, "sold", vehicleData.Status)
+	assert.NotNil(t, vehicleData.SoldAt)
+
+	published, err = outboxService.Relay(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+}
+
+func TestOutboxRetriesUntilPublished(t *testing.T) {
+	var attempts atomic.Int32
+
+	received := make(chan publisher.Envelope, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+
+		var envelope publisher.Envelope
+		_ = json.Unmarshal(body, &envelope)
+		received <- envelope
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), webhookPublisher.NewPublisher(server.URL, server.Client()), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, nil, nil, auditService, transactor, outboxService)
+
+	ctx := context.TODO()
+
+	created, err := vehicleService.Create(ctx, entity.Vehicle{Brand: "Ford", Model: "Ka", Year: 2022, Price: 50000})
+	require.NoError(t, err)
+
+	published, err := outboxService.Relay(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	// The failed event becomes available again after the first backoff.
+	time.Sleep(1100 * time.Millisecond)
+
+	published, err = outboxService.Relay(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+
+	envelope := <-received
+	assert.Equal(t, entity.EventVehicleCreated, envelope.Type)
+	assert.Equal(t, created.ID, envelope.EntityID)
+	assert.Equal(t, int32(2), attempts.Load())
+}
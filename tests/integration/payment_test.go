@@ -0,0 +1,323 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/payment"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/paymentApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/saleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const webhookSecret = "webhook-secret"
+
+func sendPaymentWebhook(app *gin.Engine, intentID, status string) *httptest.ResponseRecorder {
+	rawPayload, _ := json.Marshal(map[string]any{
+		"intent_id": intentID,
+		"status":    status,
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/payments/webhook", bytes.NewReader(rawPayload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Payment-Signature", paymentGateway.Sign(rawPayload, webhookSecret))
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func TestFailedPaymentReleasesVehicle(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	saleRepository := saleRepository.NewSaleRepository(vehicleRepository)
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+
+	payload := map[string]any{
+		"brand": "Ford",
+		"model": "Ka",
+		"year":  2022,
+		"color": "Preto",
+		"price": 50000,
+	}
+
+	rawPayload, _ := json.Marshal(payload)
+	body := bytes.NewReader(rawPayload)
+
+	req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	var response responses.Vehicle
+	err := json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	vehicleID := response.ID
+
+	req, _ = http.NewRequest(http.MethodPost, "/vehicles/"+vehicleID+"/buy", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+
+	var paymentResponse responses.Payment
+	err = json.Unmarshal(resp.Body.Bytes(), &paymentResponse)
+	require.NoError(t, err)
+
+	resp = sendPaymentWebhook(app, paymentResponse.IntentID, "failed")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	err = json.Unmarshal(resp.Body.Bytes(), &paymentResponse)
+	require.NoError(t, err)
+
+	assert.Equal(t, "failed", paymentResponse.Status)
+	assert.Empty(t, paymentResponse.SaleID)
+
+	req, _ = http.NewRequest(http.MethodGet, "/vehicles/"+vehicleID, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	err = json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "available", response.Status)
+	assert.Nil(t, response.SoldAt)
+
+	req, _ = http.NewRequest(http.MethodGet, "/payments/"+paymentResponse.ID, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var fetched responses.Payment
+	err = json.Unmarshal(resp.Body.Bytes(), &fetched)
+	require.NoError(t, err)
+
+	assert.Equal(t, "failed", fetched.Status)
+	assert.Empty(t, fetched.ClientSecret)
+}
+
+func TestGetPaymentOfAnotherUser(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, nil, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	// Payments are only shown to their buyer, so these requests run with
+	// real tokens.
+	mode := gin.Mode()
+	gin.SetMode(gin.ReleaseMode)
+	defer gin.SetMode(mode)
+
+	app := presentation.SetupServer()
+
+	authMiddleware := middleware.NewAuthMiddleware(jwtSecretKey)
+
+	vehicleApi.RegisterVehicleRoutes(app, authMiddleware, newIdempotencyMiddleware(), vehicleService)
+	paymentApi.RegisterPaymentRoutes(app, authMiddleware, paymentService)
+
+	created, err := vehicleService.Create(context.TODO(), entity.Vehicle{Brand: "Ford", Model: "Ka", Year: 2022, Color: "Preto", Price: 50000})
+	require.NoError(t, err)
+
+	send := func(method, path, token string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", token)
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		return resp
+	}
+
+	resp := send(http.MethodPost, "/vehicles/"+created.ID+"/buy", signToken(t, "buyer-123", ""))
+	require.Equal(t, http.StatusAccepted, resp.Code)
+
+	var paymentResponse responses.Payment
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &paymentResponse))
+	assert.NotEmpty(t, paymentResponse.ClientSecret)
+
+	resp = send(http.MethodGet, "/payments/"+paymentResponse.ID, signToken(t, "other-123", ""))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.NotContains(t, resp.Body.String(), "secret_")
+
+	resp = send(http.MethodGet, "/payments/"+paymentResponse.ID, signToken(t, "buyer-123", ""))
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.NotContains(t, resp.Body.String(), "client_secret")
+
+	resp = send(http.MethodPost, "/vehicles/"+created.ID+"/buy", signToken(t, "other-123", ""))
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp = send(http.MethodPost, "/vehicles/"+primitive.NewObjectID().Hex()+"/buy", signToken(t, "other-123", ""))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestDuplicatePaymentWebhookCreatesOneSale(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	saleRepository := saleRepository.NewSaleRepository(vehicleRepository)
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+
+	ctx := context.TODO()
+
+	created, err := vehicleService.Create(ctx, entity.Vehicle{Brand: "Ford", Model: "Ka", Year: 2022, Color: "Preto", Price: 50000})
+	require.NoError(t, err)
+
+	purchase, err := vehicleService.Buy(ctx, created.ID, "buyer-123", nil)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for range 5 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			resp := sendPaymentWebhook(app, purchase.IntentID, "succeeded")
+			assert.Equal(t, http.StatusOK, resp.Code)
+		}()
+	}
+
+	wg.Wait()
+
+	sales, err := saleRepository.Search(ctx)
+	require.NoError(t, err)
+	assert.Len(t, sales, 1)
+
+	resp := sendPaymentWebhook(app, purchase.IntentID, "failed")
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var paymentResponse responses.Payment
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &paymentResponse))
+	assert.Equal(t, "succeeded", paymentResponse.Status)
+	assert.Equal(t, sales[0].ID, paymentResponse.SaleID)
+}
+
+func TestPaymentWebhookWithInvalidSignature(t *testing.T) {
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	paymentService := payment.NewPaymentService(paymentRepository, nil, nil, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+
+	rawPayload, _ := json.Marshal(map[string]any{
+		"intent_id": "pi_123",
+		"status":    "succeeded",
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/payments/webhook", bytes.NewReader(rawPayload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Payment-Signature", "invalid")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestPaymentWebhookForUnknownIntent(t *testing.T) {
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	paymentService := payment.NewPaymentService(paymentRepository, nil, nil, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+
+	resp := sendPaymentWebhook(app, "pi_unknown", "succeeded")
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
@@ -0,0 +1,214 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/payment"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/sale"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/paymentApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/reportApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/saleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSalesReport(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	saleRepository := saleRepository.NewSaleRepository(vehicleRepository)
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	saleService := sale.NewSaleService(saleRepository, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+	reportApi.RegisterReportRoutes(app, middleware.AuthMiddleware{}, saleService, vehicleService)
+
+	payloads := []map[string]any{
+		{"brand": "Ford", "model": "Ka", "year": 2022, "color": "Preto", "price": 50000},
+		{"brand": "Ford", "model": "Ka", "year": 2021, "color": "Branco", "price": 40000},
+		{"brand": "Chevrolet", "model": "Onix", "year": 2023, "color": "Prata", "price": 60000},
+	}
+
+	for _, payload := range payloads {
+		rawPayload, _ := json.Marshal(payload)
+		body := bytes.NewReader(rawPayload)
+
+		req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusCreated, resp.Code)
+
+		var response responses.Vehicle
+		err := json.Unmarshal(resp.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		req, _ = http.NewRequest(http.MethodPost, "/vehicles/"+response.ID+"/buy", nil)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp = httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusAccepted, resp.Code)
+
+		var paymentResponse responses.Payment
+		err = json.Unmarshal(resp.Body.Bytes(), &paymentResponse)
+		require.NoError(t, err)
+
+		resp = sendPaymentWebhook(app, paymentResponse.IntentID, "succeeded")
+
+		require.Equal(t, http.StatusOK, resp.Code)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/reports/sales?group_by=brand", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var report responses.SalesReport
+	err := json.Unmarshal(resp.Body.Bytes(), &report)
+	require.NoError(t, err)
+
+	assert.Equal(t, "brand", report.GroupBy)
+	assert.Equal(t, 3, report.Totals.Count)
+	assert.Equal(t, float64(150000), report.Totals.Revenue)
+	assert.Equal(t, float64(50000), report.Totals.AverageTicket)
+
+	require.Len(t, report.Groups, 2)
+
+	assert.Equal(t, "Chevrolet", report.Groups[0].Key)
+	assert.Equal(t, 1, report.Groups[0].Count)
+	assert.Equal(t, float64(60000), report.Groups[0].Revenue)
+
+	assert.Equal(t, "Ford", report.Groups[1].Key)
+	assert.Equal(t, 2, report.Groups[1].Count)
+	assert.Equal(t, float64(90000), report.Groups[1].Revenue)
+	assert.Equal(t, float64(45000), report.Groups[1].AverageTicket)
+
+	req, _ = http.NewRequest(http.MethodGet, "/reports/sales?group_by=year", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestInventoryAgingReport(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	saleRepository := saleRepository.NewSaleRepository(vehicleRepository)
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	saleService := sale.NewSaleService(saleRepository, auditService, transactor, outboxService)
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	reportApi.RegisterReportRoutes(app, middleware.AuthMiddleware{}, saleService, vehicleService)
+
+	payloads := []map[string]any{
+		{"brand": "Ford", "model": "Ka", "year": 2022, "color": "Preto", "price": 50000},
+		{"brand": "Ford", "model": "Ka", "year": 2021, "color": "Branco", "price": 40000},
+		{"brand": "Chevrolet", "model": "Onix", "year": 2023, "color": "Prata", "price": 60000},
+	}
+
+	created := make([]responses.Vehicle, 0, len(payloads))
+
+	for _, payload := range payloads {
+		rawPayload, _ := json.Marshal(payload)
+		body := bytes.NewReader(rawPayload)
+
+		req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusCreated, resp.Code)
+
+		var response responses.Vehicle
+		err := json.Unmarshal(resp.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		created = append(created, response)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/reports/inventory-aging?limit=2", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var report responses.InventoryAgingReport
+	err := json.Unmarshal(resp.Body.Bytes(), &report)
+	require.NoError(t, err)
+
+	require.Len(t, report.Buckets, 4)
+	assert.Equal(t, "0-30", report.Buckets[0].Label)
+	assert.Equal(t, 3, report.Buckets[0].Count)
+	assert.Equal(t, float64(150000), report.Buckets[0].TotalValue)
+
+	require.Len(t, report.Brands, 2)
+	assert.Equal(t, "Ford", report.Brands[0].Brand)
+	assert.Equal(t, float64(90000), report.Brands[0].TotalValue)
+
+	// Creation times have millisecond precision, so vehicles created in the
+	// same millisecond are equally old.
+	require.Len(t, report.Oldest, 2)
+	assert.Equal(t, created[0].CreatedAt, report.Oldest[0].CreatedAt)
+	assert.False(t, report.Oldest[1].CreatedAt.Before(report.Oldest[0].CreatedAt))
+	assert.Equal(t, 0, report.Oldest[0].DaysListed)
+}
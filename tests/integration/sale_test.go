@@ -10,13 +10,23 @@ import (
 	"testing"
 
 	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/payment"
 	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/sale"
 	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
 	"github.com/caiiomp/vehicle-resale-api/src/middleware"
 	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/paymentApi"
 	"github.com/caiiomp/vehicle-resale-api/src/presentation/saleApi"
 	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
 	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/saleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
 	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -25,17 +35,25 @@ import (
 
 func TestListSales(t *testing.T) {
 	vehicleRepository := vehicleRepository.NewVehicleRepository()
-	saleRepository := saleRepository.NewSaleRepository()
+	saleRepository := saleRepository.NewSaleRepository(vehicleRepository)
+	paymentRepository := paymentRepository.NewPaymentRepository()
 
-	vehicleService := vehicle.NewVehicleService(vehicleRepository, saleRepository)
-	saleService := sale.NewSaleService(saleRepository)
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	saleService := sale.NewSaleService(saleRepository, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
 
 	gin.SetMode(gin.TestMode)
 
 	app := presentation.SetupServer()
 
-	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, vehicleService)
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
 	saleApi.RegisterSaleRoutes(app, saleService)
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
 
 	payload := map[string]any{
 		"brand": "Ford",
@@ -70,6 +88,14 @@ func TestListSales(t *testing.T) {
 
 	app.ServeHTTP(resp, req)
 
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+
+	var paymentResponse responses.Payment
+	err = json.Unmarshal(resp.Body.Bytes(), &paymentResponse)
+	require.NoError(t, err)
+
+	resp = sendPaymentWebhook(app, paymentResponse.IntentID, "succeeded")
+
 	assert.Equal(t, http.StatusOK, resp.Code)
 
 	req, _ = http.NewRequest(http.MethodGet, "/sales", nil)
@@ -89,3 +115,120 @@ func TestListSales(t *testing.T) {
 	assert.Equal(t, float64(50000), salesResponse[0].Price)
 	assert.NotNil(t, salesResponse[0].SoldAt)
 }
+
+func TestListSalesWithTradeIn(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	saleRepository := saleRepository.NewSaleRepository(vehicleRepository)
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	saleService := sale.NewSaleService(saleRepository, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	saleApi.RegisterSaleRoutes(app, saleService)
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+
+	payload := map[string]any{
+		"brand": "Ford",
+		"model": "Ka",
+		"year":  2022,
+		"color": "Preto",
+		"price": 50000,
+	}
+
+	rawPayload, _ := json.Marshal(payload)
+	body := bytes.NewReader(rawPayload)
+
+	req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	var response responses.Vehicle
+	err := json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	vehicleID := response.ID
+
+	payload = map[string]any{
+		"trade_in": map[string]any{
+			"brand":     "Fiat",
+			"model":     "Uno",
+			"year":      2012,
+			"color":     "Branco",
+			"valuation": 15000,
+		},
+	}
+
+	rawPayload, _ = json.Marshal(payload)
+	body = bytes.NewReader(rawPayload)
+
+	req, _ = http.NewRequest(http.MethodPost, "/vehicles/"+vehicleID+"/buy", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+
+	var paymentResponse responses.Payment
+	err = json.Unmarshal(resp.Body.Bytes(), &paymentResponse)
+	require.NoError(t, err)
+
+	resp = sendPaymentWebhook(app, paymentResponse.IntentID, "succeeded")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/sales", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var salesResponse []responses.Sale
+	err = json.Unmarshal(resp.Body.Bytes(), &salesResponse)
+	require.NoError(t, err)
+
+	assert.Equal(t, vehicleID, salesResponse[0].VehicleID)
+	assert.Equal(t, float64(50000), salesResponse[0].Price)
+	assert.Equal(t, float64(15000), salesResponse[0].TradeInCredit)
+	assert.Equal(t, float64(35000), salesResponse[0].NetPrice)
+	assert.NotEmpty(t, salesResponse[0].TradeInVehicleID)
+
+	req, _ = http.NewRequest(http.MethodGet, "/vehicles/"+salesResponse[0].TradeInVehicleID, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	err = json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Fiat", response.Brand)
+	assert.Equal(t, "Uno", response.Model)
+	assert.Equal(t, float64(15000), response.Price)
+	assert.Equal(t, "draft", response.Status)
+	assert.Equal(t, "dealership", response.SellerID)
+	assert.Nil(t, response.SoldAt)
+}
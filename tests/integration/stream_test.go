@@ -0,0 +1,211 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/payment"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicleStream"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/paymentApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/saleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+// readStreamEvent reads the next event, skipping heartbeats.
+func readStreamEvent(t *testing.T, reader *bufio.Reader) streamEvent {
+	var event streamEvent
+
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "" && event.event != "":
+			return event
+		case strings.HasPrefix(line, "id: "):
+			event.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func openStream(t *testing.T, ctx context.Context, url, lastEventID string) (*http.Response, *bufio.Reader) {
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	return resp, bufio.NewReader(resp.Body)
+}
+
+func TestVehicleStream(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+	saleRepository := saleRepository.NewSaleRepository(vehicleRepository)
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	vehicleStreamService := vehicleStream.NewVehicleStreamService(100, 10)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), vehicleStreamService, 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	vehicleApi.RegisterVehicleStreamRoutes(app, vehicleStreamService)
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+
+	server := httptest.NewServer(app)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	allResp, all := openStream(t, ctx, server.URL+"/vehicles/stream", "")
+	defer allResp.Body.Close()
+
+	soldResp, sold := openStream(t, ctx, server.URL+"/vehicles/stream?is_sold=true", "")
+	defer soldResp.Body.Close()
+
+	created, err := vehicleService.Create(ctx, entity.Vehicle{Brand: "Ford", Model: "Ka", Year: 2022, Color: "Preto", Price: 50000})
+	require.NoError(t, err)
+
+	recolored := *created
+	recolored.Color = "Branco"
+
+	updated, err := vehicleService.Update(ctx, created.ID, recolored)
+	require.NoError(t, err)
+
+	repriced := *updated
+	repriced.Price = 48000
+
+	_, err = vehicleService.Update(ctx, created.ID, repriced)
+	require.NoError(t, err)
+
+	payment, err := vehicleService.Buy(ctx, created.ID, "buyer-123", nil)
+	require.NoError(t, err)
+
+	resp := sendPaymentWebhook(app, payment.IntentID, "succeeded")
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	_, err = outboxService.Relay(ctx)
+	require.NoError(t, err)
+
+	event := readStreamEvent(t, all)
+	assert.Equal(t, "1", event.id)
+	assert.Equal(t, entity.EventVehicleCreated, event.event)
+
+	var vehicleData responses.Vehicle
+	require.NoError(t, json.Unmarshal([]byte(event.data), &vehicleData))
+	assert.Equal(t, created.ID, vehicleData.ID)
+	assert.Equal(t, 50000.0, vehicleData.Price)
+
+	// Changing only the color is not pushed.
+	event = readStreamEvent(t, all)
+	assert.Equal(t, "2", event.id)
+	assert.Equal(t, entity.EventVehiclePriceChanged, event.event)
+
+	require.NoError(t, json.Unmarshal([]byte(event.data), &vehicleData))
+	assert.Equal(t, 48000.0, vehicleData.Price)
+
+	event = readStreamEvent(t, all)
+	assert.Equal(t, "3", event.id)
+	assert.Equal(t, entity.EventVehicleSold, event.event)
+
+	event = readStreamEvent(t, sold)
+	assert.Equal(t, "3", event.id)
+	assert.Equal(t, entity.EventVehicleSold, event.event)
+
+	resumedResp, resumed := openStream(t, ctx, server.URL+"/vehicles/stream", "1")
+	defer resumedResp.Body.Close()
+
+	event = readStreamEvent(t, resumed)
+	assert.Equal(t, "2", event.id)
+	assert.Equal(t, entity.EventVehiclePriceChanged, event.event)
+
+	event = readStreamEvent(t, resumed)
+	assert.Equal(t, "3", event.id)
+	assert.Equal(t, entity.EventVehicleSold, event.event)
+
+	resetResp, reset := openStream(t, ctx, server.URL+"/vehicles/stream", "99")
+	defer resetResp.Body.Close()
+
+	event = readStreamEvent(t, reset)
+	assert.Equal(t, "reset", event.event)
+}
+
+func TestVehicleStreamShutdown(t *testing.T) {
+	vehicleStreamService := vehicleStream.NewVehicleStreamService(100, 10)
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleStreamRoutes(app, vehicleStreamService)
+
+	server := httptest.NewServer(app)
+	defer server.Close()
+
+	server.Config.RegisterOnShutdown(vehicleStreamService.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, reader := openStream(t, ctx, server.URL+"/vehicles/stream", "")
+	defer resp.Body.Close()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelShutdown()
+
+	require.NoError(t, server.Config.Shutdown(shutdownCtx))
+
+	_, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+}
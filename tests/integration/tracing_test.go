@@ -0,0 +1,126 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/payment"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/paymentApi"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	instrumentedPaymentRepository "github.com/caiiomp/vehicle-resale-api/src/repository/instrumented/paymentRepository"
+	instrumentedSaleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/instrumented/saleRepository"
+	instrumentedVehicleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/instrumented/vehicleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/saleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+
+	tracerProvider := tracing.NewProvider(exporter, "vehicle-resale-api", 1)
+	tracing.SetProvider(tracerProvider)
+	defer tracing.SetProvider(nil)
+
+	memoryVehicleRepository := vehicleRepository.NewVehicleRepository()
+	vehicleRepository := instrumentedVehicleRepository.NewVehicleRepository(memoryVehicleRepository)
+	saleRepository := instrumentedSaleRepository.NewSaleRepository(saleRepository.NewSaleRepository(memoryVehicleRepository))
+	paymentRepository := instrumentedPaymentRepository.NewPaymentRepository(paymentRepository.NewPaymentRepository())
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer(middleware.Tracing)
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
+
+	payload := map[string]any{
+		"brand": "Ford",
+		"model": "Ka",
+		"year":  2022,
+		"color": "Preto",
+		"price": 50000,
+	}
+
+	rawPayload, _ := json.Marshal(payload)
+	body := bytes.NewReader(rawPayload)
+
+	req, _ := http.NewRequest(http.MethodPost, "/vehicles", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var response responses.Vehicle
+	err := json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	req, _ = http.NewRequest(http.MethodPost, "/vehicles/"+response.ID+"/buy", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusAccepted, resp.Code)
+
+	require.NoError(t, tracerProvider.ForceFlush(context.TODO()))
+
+	spans := map[string]tracetest.SpanStub{}
+
+	for _, span := range exporter.GetSpans() {
+		if span.SpanContext.TraceID().String() == "4bf92f3577b34da6a3ce929d0e0e4736" {
+			spans[span.Name] = span
+		}
+	}
+
+	server := spans["POST /vehicles/:vehicle_id/buy"]
+	buy := spans["vehicleService.Buy"]
+
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	assert.Contains(t, server.Attributes, attribute.Int("http.response.status_code", http.StatusAccepted))
+
+	assert.Equal(t, server.SpanContext.SpanID(), buy.Parent.SpanID())
+	assert.Contains(t, buy.Attributes, attribute.String("vehicle.id", response.ID))
+
+	for _, name := range []string{"vehicleRepository.GetByID", "vehicleRepository.Update", "paymentRepository.Create", "paymentRepository.Update"} {
+		assert.Equal(t, buy.SpanContext.SpanID(), spans[name].Parent.SpanID(), name)
+	}
+}
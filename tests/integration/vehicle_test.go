@@ -4,18 +4,33 @@ package integration
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
 	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/payment"
 	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/fake/paymentGateway"
 	"github.com/caiiomp/vehicle-resale-api/src/middleware"
 	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/paymentApi"
 	"github.com/caiiomp/vehicle-resale-api/src/presentation/vehicleApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/paymentRepository"
 	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/saleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
 	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleVersionRepository"
+	versionedVehicleRepository "github.com/caiiomp/vehicle-resale-api/src/repository/versioned/vehicleRepository"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,15 +38,20 @@ import (
 
 func TestCreateVehicle(t *testing.T) {
 	vehicleRepository := vehicleRepository.NewVehicleRepository()
-	saleRepository := saleRepository.NewSaleRepository()
+	paymentRepository := paymentRepository.NewPaymentRepository()
 
-	vehicleService := vehicle.NewVehicleService(vehicleRepository, saleRepository)
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
 
 	gin.SetMode(gin.TestMode)
 
 	app := presentation.SetupServer()
 
-	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, vehicleService)
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
 
 	payload := map[string]any{
 		"brand": "Ford",
@@ -62,6 +82,7 @@ func TestCreateVehicle(t *testing.T) {
 	assert.Equal(t, 2022, response.Year)
 	assert.Equal(t, "Preto", response.Color)
 	assert.Equal(t, float64(50000), response.Price)
+	assert.Equal(t, "available", response.Status)
 	assert.NotNil(t, response.CreatedAt)
 	assert.NotNil(t, response.UpdatedAt)
 	assert.Nil(t, response.SoldAt)
@@ -69,15 +90,20 @@ func TestCreateVehicle(t *testing.T) {
 
 func TestSearchVehicles(t *testing.T) {
 	vehicleRepository := vehicleRepository.NewVehicleRepository()
-	saleRepository := saleRepository.NewSaleRepository()
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
 
-	vehicleService := vehicle.NewVehicleService(vehicleRepository, saleRepository)
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
 
 	gin.SetMode(gin.TestMode)
 
 	app := presentation.SetupServer()
 
-	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, vehicleService)
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
 
 	payload := map[string]any{
 		"brand": "Ford",
@@ -131,15 +157,20 @@ func TestSearchVehicles(t *testing.T) {
 
 func TestGetVehicle(t *testing.T) {
 	vehicleRepository := vehicleRepository.NewVehicleRepository()
-	saleRepository := saleRepository.NewSaleRepository()
+	paymentRepository := paymentRepository.NewPaymentRepository()
+
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
 
-	vehicleService := vehicle.NewVehicleService(vehicleRepository, saleRepository)
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
 
 	gin.SetMode(gin.TestMode)
 
 	app := presentation.SetupServer()
 
-	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, vehicleService)
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
 
 	payload := map[string]any{
 		"brand": "Ford",
@@ -188,19 +219,32 @@ func TestGetVehicle(t *testing.T) {
 	assert.NotNil(t, response.CreatedAt)
 	assert.NotNil(t, response.UpdatedAt)
 	assert.Nil(t, response.SoldAt)
+
+	req, _ = http.NewRequest(http.MethodGet, "/vehicles/not-an-id", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
 }
 
 func TestUpdateVehicle(t *testing.T) {
 	vehicleRepository := vehicleRepository.NewVehicleRepository()
-	saleRepository := saleRepository.NewSaleRepository()
+	paymentRepository := paymentRepository.NewPaymentRepository()
 
-	vehicleService := vehicle.NewVehicleService(vehicleRepository, saleRepository)
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
 
 	gin.SetMode(gin.TestMode)
 
 	app := presentation.SetupServer()
 
-	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, vehicleService)
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
 
 	payload := map[string]any{
 		"brand": "Ford",
@@ -257,23 +301,173 @@ func TestUpdateVehicle(t *testing.T) {
 	assert.Equal(t, 2023, response.Year)
 	assert.Equal(t, "Branco", response.Color)
 	assert.Equal(t, float64(60000), response.Price)
-	assert.Greater(t, response.UpdatedAt, oldUpdatedAt)
+	// Update times have millisecond precision, so the update may happen in
+	// the same millisecond as the creation.
+	assert.GreaterOrEqual(t, response.UpdatedAt, oldUpdatedAt)
 	assert.NotNil(t, response.CreatedAt)
 	assert.NotNil(t, response.UpdatedAt)
 	assert.Nil(t, response.SoldAt)
 }
 
+func TestVehicleETags(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, nil, nil, auditService, transactor, outboxService)
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+
+	created, err := vehicleService.Create(context.TODO(), entity.Vehicle{Brand: "Ford", Model: "Ka", Year: 2022, Color: "Preto", Price: 50000})
+	require.NoError(t, err)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/vehicles/"+created.ID, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		return resp
+	}
+
+	patch := func(ifMatch, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPatch, "/vehicles/"+created.ID, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", ifMatch)
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		return resp
+	}
+
+	resp := get("")
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"1"`, resp.Header().Get("ETag"))
+
+	resp = get(`"1"`)
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Equal(t, `"1"`, resp.Header().Get("ETag"))
+	assert.Empty(t, resp.Body.String())
+
+	// Both staff members loaded version 1, and the second change is rejected.
+	resp = patch(`"1"`, `{"price":48000}`)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"2"`, resp.Header().Get("ETag"))
+
+	resp = patch(`"1"`, `{"color":"Branco"}`)
+	assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+	assert.JSONEq(t, `{"error":"vehicle was changed by another request"}`, resp.Body.String())
+
+	resp = patch(`W/"2"`, `{"color":"Branco"}`)
+	assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+
+	resp = get(`"1"`)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var response responses.Vehicle
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+	assert.Equal(t, int64(2), response.Version)
+	assert.Equal(t, 48000.0, response.Price)
+	assert.Equal(t, "Preto", response.Color)
+
+	resp = patch(`"2"`, `{"color":"Branco"}`)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"3"`, resp.Header().Get("ETag"))
+}
+
+func TestPatchVehicle(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, nil, nil, auditService, transactor, outboxService)
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+
+	created, err := vehicleService.Create(context.TODO(), entity.Vehicle{Brand: "Ford", Model: "Ka", Year: 2022, Color: "Preto", Price: 50000})
+	require.NoError(t, err)
+
+	patch := func(contentType, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPatch, "/vehicles/"+created.ID, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", contentType)
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		return resp
+	}
+
+	resp := patch("application/merge-patch+json", `{"color":null,"price":0}`)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var response responses.Vehicle
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+	assert.Equal(t, "Ford", response.Brand)
+	assert.Empty(t, response.Color)
+	assert.Zero(t, response.Price)
+
+	resp = patch("application/json-patch+json", `[{"op":"test","path":"/price","value":0},{"op":"add","path":"/color","value":"Branco"},{"op":"replace","path":"/price","value":45000}]`)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+	assert.Equal(t, "Branco", response.Color)
+	assert.Equal(t, 45000.0, response.Price)
+
+	resp = patch("application/json-patch+json", `[{"op":"test","path":"/price","value":0}]`)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp = patch("application/json-patch+json", `{"op":"remove","path":"/brand"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = patch("application/json-patch+json", `[{"op":"remove","path":"/brand"}]`)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+
+	resp = patch("text/plain", `price=1`)
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.Code)
+
+	vehicle, err := vehicleService.GetByID(context.TODO(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ford", vehicle.Brand)
+	assert.Equal(t, 45000.0, vehicle.Price)
+	assert.Equal(t, int64(3), vehicle.Version)
+}
+
 func TestBuyVehicle(t *testing.T) {
 	vehicleRepository := vehicleRepository.NewVehicleRepository()
-	saleRepository := saleRepository.NewSaleRepository()
+	saleRepository := saleRepository.NewSaleRepository(vehicleRepository)
+	paymentRepository := paymentRepository.NewPaymentRepository()
 
-	vehicleService := vehicle.NewVehicleService(vehicleRepository, saleRepository)
+	paymentGateway := paymentGateway.NewPaymentGateway(webhookSecret)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, paymentRepository, paymentGateway, auditService, transactor, outboxService)
+	paymentService := payment.NewPaymentService(paymentRepository, vehicleRepository, saleRepository, paymentGateway, auditService, transactor, outboxService, "dealership")
 
 	gin.SetMode(gin.TestMode)
 
 	app := presentation.SetupServer()
 
-	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, vehicleService)
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+	paymentApi.RegisterPaymentRoutes(app, middleware.AuthMiddleware{}, paymentService)
 
 	payload := map[string]any{
 		"brand": "Ford",
@@ -308,6 +502,42 @@ func TestBuyVehicle(t *testing.T) {
 
 	app.ServeHTTP(resp, req)
 
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+
+	var paymentResponse responses.Payment
+	err = json.Unmarshal(resp.Body.Bytes(), &paymentResponse)
+	require.NoError(t, err)
+
+	assert.Equal(t, vehicleID, paymentResponse.VehicleID)
+	assert.Equal(t, float64(50000), paymentResponse.Amount)
+	assert.Equal(t, "pending", paymentResponse.Status)
+	assert.NotEmpty(t, paymentResponse.IntentID)
+	assert.NotEmpty(t, paymentResponse.ClientSecret)
+
+	req, _ = http.NewRequest(http.MethodGet, "/vehicles/"+vehicleID, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	err = json.Unmarshal(resp.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "reserved", response.Status)
+	assert.Nil(t, response.SoldAt)
+
+	resp = sendPaymentWebhook(app, paymentResponse.IntentID, "succeeded")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/vehicles/"+vehicleID, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
 	assert.Equal(t, http.StatusOK, resp.Code)
 
 	err = json.Unmarshal(resp.Body.Bytes(), &response)
@@ -318,7 +548,218 @@ func TestBuyVehicle(t *testing.T) {
 	assert.Equal(t, 2022, response.Year)
 	assert.Equal(t, "Preto", response.Color)
 	assert.Equal(t, float64(50000), response.Price)
+	assert.Equal(t, "sold", response.Status)
 	assert.NotNil(t, response.CreatedAt)
 	assert.NotNil(t, response.UpdatedAt)
 	assert.NotNil(t, response.SoldAt)
 }
+
+func TestDeleteVehicle(t *testing.T) {
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, nil, nil, auditService, transactor, outboxService)
+
+	// Only admins may see and restore deleted vehicles, so these requests
+	// run with real tokens.
+	mode := gin.Mode()
+	gin.SetMode(gin.ReleaseMode)
+	defer gin.SetMode(mode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.NewAuthMiddleware(jwtSecretKey), newIdempotencyMiddleware(), vehicleService)
+
+	sellerToken := signToken(t, "seller-123", "")
+	adminToken := signToken(t, "admin-123", "admin")
+
+	ctx := context.TODO()
+
+	created, err := vehicleService.Create(ctx, entity.Vehicle{Brand: "Ford", Model: "Ka", Year: 2022, Color: "Preto", Price: 50000})
+	require.NoError(t, err)
+
+	sold, err := vehicleService.Create(ctx, entity.Vehicle{Brand: "Fiat", Model: "Uno", Year: 2010, Color: "Branco", Price: 20000, Status: entity.VehicleStatusSold})
+	require.NoError(t, err)
+
+	send := func(method, path, token string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		return resp
+	}
+
+	listed := func(path, token string) []string {
+		resp := send(http.MethodGet, path, token)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var vehicles []responses.Vehicle
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &vehicles))
+
+		ids := make([]string, len(vehicles))
+		for i, vehicle := range vehicles {
+			ids[i] = vehicle.ID
+		}
+
+		return ids
+	}
+
+	resp := send(http.MethodDelete, "/vehicles/"+created.ID, sellerToken)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var response responses.Vehicle
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+	assert.NotNil(t, response.DeletedAt)
+	assert.Equal(t, "seller-123", response.DeletedBy)
+
+	t.Run("should hide deleted vehicle", func(t *testing.T) {
+		assert.Equal(t, []string{sold.ID}, listed("/vehicles", ""))
+		assert.Equal(t, http.StatusNoContent, send(http.MethodGet, "/vehicles/"+created.ID, "").Code)
+		assert.Equal(t, http.StatusNoContent, send(http.MethodDelete, "/vehicles/"+created.ID, sellerToken).Code)
+	})
+
+	t.Run("should only show deleted vehicles to admins", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/vehicles?include_deleted=true", "").Code)
+		assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/vehicles?include_deleted=true", sellerToken).Code)
+		assert.ElementsMatch(t, []string{created.ID, sold.ID}, listed("/vehicles?include_deleted=true", adminToken))
+
+		resp := send(http.MethodGet, "/vehicles/"+created.ID+"?include_deleted=true", adminToken)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var response responses.Vehicle
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+		assert.NotNil(t, response.DeletedAt)
+	})
+
+	t.Run("should not delete sold vehicle", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, send(http.MethodDelete, "/vehicles/"+sold.ID, sellerToken).Code)
+	})
+
+	t.Run("should only let admins restore vehicle", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/vehicles/"+created.ID+"/restore", sellerToken).Code)
+
+		resp := send(http.MethodPost, "/vehicles/"+created.ID+"/restore", adminToken)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var response responses.Vehicle
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+		assert.Nil(t, response.DeletedAt)
+		assert.Empty(t, response.DeletedBy)
+
+		assert.ElementsMatch(t, []string{created.ID, sold.ID}, listed("/vehicles", ""))
+	})
+
+	t.Run("should purge vehicles deleted before the retention period", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, send(http.MethodDelete, "/vehicles/"+created.ID, sellerToken).Code)
+
+		purged, err := vehicleService.PurgeDeleted(ctx, time.Hour)
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+
+		purged, err = vehicleService.PurgeDeleted(ctx, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		vehicle, err := vehicleService.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Nil(t, vehicle)
+	})
+}
+
+func TestVehicleHistory(t *testing.T) {
+	vehicleVersionRepository := vehicleVersionRepository.NewVehicleVersionRepository()
+	vehicleRepository := versionedVehicleRepository.NewVehicleRepository(vehicleRepository.NewVehicleRepository(), vehicleVersionRepository)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), memoryPublisher.NewPublisher(), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, vehicleVersionRepository, nil, nil, auditService, transactor, outboxService)
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	vehicleApi.RegisterVehicleRoutes(app, middleware.AuthMiddleware{}, newIdempotencyMiddleware(), vehicleService)
+
+	created, err := vehicleService.Create(context.TODO(), entity.Vehicle{Brand: "Ford", Model: "Ka", Year: 2022, Color: "Preto", Price: 50000})
+	require.NoError(t, err)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+
+		resp := httptest.NewRecorder()
+
+		app.ServeHTTP(resp, req)
+
+		return resp
+	}
+
+	require.Equal(t, http.StatusOK, send(http.MethodPatch, "/vehicles/"+created.ID, `{"price":45000}`).Code)
+	require.Equal(t, http.StatusOK, send(http.MethodPatch, "/vehicles/"+created.ID, `{"color":"Branco"}`).Code)
+
+	t.Run("should list versions with the changed fields", func(t *testing.T) {
+		resp := send(http.MethodGet, "/vehicles/"+created.ID+"/history", "")
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var history []responses.VehicleHistoryEntry
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &history))
+		require.Len(t, history, 3)
+
+		assert.Equal(t, int64(1), history[0].Version)
+		assert.Equal(t, 50000.0, history[0].Vehicle.Price)
+		assert.NotEmpty(t, history[0].Changes)
+
+		assert.Equal(t, int64(2), history[1].Version)
+		assert.Equal(t, []responses.AuditChange{
+			{Field: "price", Before: float64(50000), After: float64(45000)},
+		}, history[1].Changes)
+
+		assert.Equal(t, int64(3), history[2].Version)
+		assert.Equal(t, []responses.AuditChange{
+			{Field: "color", Before: "Preto", After: "Branco"},
+		}, history[2].Changes)
+	})
+
+	t.Run("should get vehicle as it was at the time", func(t *testing.T) {
+		resp := send(http.MethodGet, "/vehicles/"+created.ID+"/history", "")
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var history []responses.VehicleHistoryEntry
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &history))
+		require.Len(t, history, 3)
+
+		// Versions are recorded with millisecond precision, so the updates
+		// may share the millisecond of the creation; the vehicle as of then
+		// is the last version recorded in it.
+		asOf := history[0].RecordedAt
+
+		expected := history[0]
+		for _, entry := range history {
+			if !entry.RecordedAt.After(asOf) {
+				expected = entry
+			}
+		}
+
+		resp = send(http.MethodGet, "/vehicles/"+created.ID+"?as_of="+asOf.UTC().Format(time.RFC3339Nano), "")
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var response responses.Vehicle
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+		assert.Equal(t, expected.Version, response.Version)
+		assert.Equal(t, expected.Vehicle.Price, response.Price)
+		assert.Equal(t, expected.Vehicle.Color, response.Color)
+
+		before := created.CreatedAt.Add(-time.Second).UTC().Format(time.RFC3339Nano)
+		assert.Equal(t, http.StatusNoContent, send(http.MethodGet, "/vehicles/"+created.ID+"?as_of="+before, "").Code)
+
+		assert.Equal(t, http.StatusBadRequest, send(http.MethodGet, "/vehicles/"+created.ID+"?as_of=yesterday", "").Code)
+	})
+}
@@ -0,0 +1,250 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/caiiomp/vehicle-resale-api/src/core/domain/entity"
+	"github.com/caiiomp/vehicle-resale-api/src/core/responses"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/audit"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/outbox"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/vehicle"
+	"github.com/caiiomp/vehicle-resale-api/src/core/useCases/webhook"
+	"github.com/caiiomp/vehicle-resale-api/src/gateway/webhookSender"
+	"github.com/caiiomp/vehicle-resale-api/src/middleware"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation"
+	"github.com/caiiomp/vehicle-resale-api/src/presentation/webhookApi"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher"
+	"github.com/caiiomp/vehicle-resale-api/src/publisher/memoryPublisher"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/auditRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/outboxRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/transactor"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/vehicleRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/webhookDeliveryRepository"
+	"github.com/caiiomp/vehicle-resale-api/src/repository/memory/webhookSubscriptionRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type partnerRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func TestWebhookDeadLetterAndReplay(t *testing.T) {
+	var accepting atomic.Bool
+
+	received := make(chan partnerRequest, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !accepting.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		received <- partnerRequest{header: r.Header, body: body}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	vehicleRepository := vehicleRepository.NewVehicleRepository()
+
+	// A single attempt dead-letters the first failure right away.
+	webhookService := webhook.NewWebhookService(
+		webhookSubscriptionRepository.NewWebhookSubscriptionRepository(),
+		webhookDeliveryRepository.NewWebhookDeliveryRepository(),
+		webhookSender.NewWebhookSender(server.Client()),
+		1,
+		100,
+	)
+
+	transactor := transactor.NewTransactor()
+	auditService := audit.NewAuditService(auditRepository.NewAuditRepository())
+	outboxService := outbox.NewOutboxService(outboxRepository.NewOutboxRepository(), publisher.Fanout(memoryPublisher.NewPublisher(), webhookService), 100)
+	vehicleService := vehicle.NewVehicleService(vehicleRepository, nil, nil, nil, auditService, transactor, outboxService)
+
+	gin.SetMode(gin.TestMode)
+
+	app := presentation.SetupServer()
+
+	webhookApi.RegisterWebhookRoutes(app, middleware.AuthMiddleware{}, webhookService)
+
+	payload := map[string]any{
+		"url":         server.URL,
+		"event_types": []string{entity.EventVehicleCreated},
+	}
+
+	rawPayload, _ := json.Marshal(payload)
+	body := bytes.NewReader(rawPayload)
+
+	req, _ := http.NewRequest(http.MethodPost, "/webhooks", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var subscriptionResponse responses.WebhookSubscription
+	err := json.Unmarshal(resp.Body.Bytes(), &subscriptionResponse)
+	require.NoError(t, err)
+	require.NotEmpty(t, subscriptionResponse.Secret)
+
+	req, _ = http.NewRequest(http.MethodGet, "/webhooks", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.NotContains(t, resp.Body.String(), subscriptionResponse.Secret)
+
+	ctx := context.TODO()
+
+	created, err := vehicleService.Create(ctx, entity.Vehicle{Brand: "Ford", Model: "Ka", Year: 2022, Price: 50000})
+	require.NoError(t, err)
+
+	// Updates are not subscribed to, so only the vehicle creation is enqueued.
+	repriced := *created
+	repriced.Price = 48000
+
+	_, err = vehicleService.Update(ctx, created.ID, repriced)
+	require.NoError(t, err)
+
+	published, err := outboxService.Relay(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+
+	delivered, err := webhookService.Deliver(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+
+	req, _ = http.NewRequest(http.MethodGet, "/webhooks/"+subscriptionResponse.ID+"/deliveries", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var deliveriesResponse []responses.WebhookDelivery
+	err = json.Unmarshal(resp.Body.Bytes(), &deliveriesResponse)
+	require.NoError(t, err)
+	require.Len(t, deliveriesResponse, 1)
+
+	deadLettered := deliveriesResponse[0]
+	assert.Equal(t, entity.EventVehicleCreated, deadLettered.EventType)
+	assert.Equal(t, entity.WebhookDeliveryStatusDead, deadLettered.Status)
+	assert.Equal(t, 1, deadLettered.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, deadLettered.LastStatusCode)
+	assert.Equal(t, "webhook responded with status 503", deadLettered.LastError)
+
+	// Dead-lettered deliveries are not retried on their own.
+	delivered, err = webhookService.Deliver(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+
+	accepting.Store(true)
+
+	req, _ = http.NewRequest(http.MethodPost, "/webhooks/"+subscriptionResponse.ID+"/deliveries/"+deadLettered.ID+"/replay", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusAccepted, resp.Code)
+
+	var deliveryResponse responses.WebhookDelivery
+	err = json.Unmarshal(resp.Body.Bytes(), &deliveryResponse)
+	require.NoError(t, err)
+	assert.Equal(t, entity.WebhookDeliveryStatusPending, deliveryResponse.Status)
+
+	req, _ = http.NewRequest(http.MethodPost, "/webhooks/"+subscriptionResponse.ID+"/deliveries/"+deadLettered.ID+"/replay", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	delivered, err = webhookService.Deliver(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	request := <-received
+
+	timestamp := request.header.Get("X-Webhook-Timestamp")
+	assert.Equal(t, "sha256="+webhook.Sign(subscriptionResponse.Secret, timestamp, request.body), request.header.Get("X-Webhook-Signature"))
+	assert.Equal(t, deadLettered.ID, request.header.Get("X-Webhook-ID"))
+	assert.Equal(t, deadLettered.EventID, request.header.Get("X-Event-ID"))
+
+	var envelope publisher.Envelope
+	require.NoError(t, json.Unmarshal(request.body, &envelope))
+	assert.Equal(t, entity.EventVehicleCreated, envelope.Type)
+	assert.Equal(t, created.ID, envelope.EntityID)
+
+	req, _ = http.NewRequest(http.MethodGet, "/webhooks/"+subscriptionResponse.ID+"/deliveries", nil)
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	err = json.Unmarshal(resp.Body.Bytes(), &deliveriesResponse)
+	require.NoError(t, err)
+	require.Len(t, deliveriesResponse, 1)
+	assert.Equal(t, entity.WebhookDeliveryStatusDelivered, deliveriesResponse[0].Status)
+	assert.NotNil(t, deliveriesResponse[0].DeliveredAt)
+}
+
+func TestWebhookSubscriptionsRequireAdmin(t *testing.T) {
+	webhookService := webhook.NewWebhookService(
+		webhookSubscriptionRepository.NewWebhookSubscriptionRepository(),
+		webhookDeliveryRepository.NewWebhookDeliveryRepository(),
+		nil,
+		1,
+		100,
+	)
+
+	// Auth and Admin are skipped in test mode, so these requests run with
+	// real tokens.
+	mode := gin.Mode()
+	gin.SetMode(gin.ReleaseMode)
+	defer gin.SetMode(mode)
+
+	app := presentation.SetupServer()
+
+	webhookApi.RegisterWebhookRoutes(app, middleware.NewAuthMiddleware(jwtSecretKey), webhookService)
+
+	req, _ := http.NewRequest(http.MethodGet, "/webhooks", nil)
+	req.Header.Set("Authorization", signToken(t, "user-123", "customer"))
+
+	resp := httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/webhooks", nil)
+	req.Header.Set("Authorization", signToken(t, "admin-123", "admin"))
+
+	resp = httptest.NewRecorder()
+
+	app.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[]`, resp.Body.String())
+}